@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-radar/internal/digest"
+	"remote-radar/internal/notifier"
+)
+
+// digestOptions 承载 `remote-radar digest` 子命令的解析结果。
+type digestOptions struct {
+	format Format
+	since  time.Duration
+	out    string
+}
+
+// runDigestCommand 解析 CLI 参数、复用 builder 构建的 appDeps，生成一份摘要文件。
+func runDigestCommand(ctx context.Context, cfg AppConfig, builder func(AppConfig) (appDeps, func(), error), args []string) error {
+	opts, err := parseDigestArgs(args)
+	if err != nil {
+		return fmt.Errorf("parse digest args: %w", err)
+	}
+
+	deps, cleanup, err := builder(cfg)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return fmt.Errorf("build app: %w", err)
+	}
+	if deps.digest == nil {
+		return fmt.Errorf("digest service unavailable")
+	}
+
+	since := time.Now().Add(-opts.since)
+	if err := deps.digest.Generate(ctx, since, opts.format, opts.out); err != nil {
+		return fmt.Errorf("generate digest: %w", err)
+	}
+	return nil
+}
+
+func parseDigestArgs(args []string) (digestOptions, error) {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	format := fs.String("format", "epub", "output format: pdf|epub|mobi|docx")
+	since := fs.String("since", "7d", "include jobs published since this duration, e.g. 7d, 24h")
+	out := fs.String("out", "digest.epub", "output file path")
+	if err := fs.Parse(args); err != nil {
+		return digestOptions{}, err
+	}
+
+	dur, err := parseSinceDuration(*since)
+	if err != nil {
+		return digestOptions{}, fmt.Errorf("invalid --since %q: %w", *since, err)
+	}
+
+	return digestOptions{format: Format(strings.ToLower(*format)), since: dur, out: *out}, nil
+}
+
+// Format 在 cmd 层复用 digest.Format 的取值，避免直接暴露 flag 解析细节给 digest 包。
+type Format = digest.Format
+
+// parseSinceDuration 支持 "7d" 这种以天为单位的写法，否则退回到标准 time.ParseDuration。
+func parseSinceDuration(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasSuffix(raw, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// emailSenderAdapter 适配 notifier.EmailSender 为 digest.EmailSender，桥接附件字段命名差异。
+type emailSenderAdapter struct {
+	sender notifier.EmailSender
+}
+
+func (a emailSenderAdapter) Send(ctx context.Context, msg digest.EmailAttachmentMessage) error {
+	return a.sender.Send(ctx, notifier.EmailMessage{
+		From:    msg.From,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		Attachment: &notifier.EmailAttachment{
+			Filename:    msg.Filename,
+			ContentType: msg.ContentType,
+			Content:     msg.Content,
+		},
+	})
+}
+
+// runDigestMailLoop 按配置的时间间隔定期生成摘要并发送给 email_digest 渠道订阅者，直至 ctx 取消。
+func runDigestMailLoop(ctx context.Context, job *digest.MailJob, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := job.Run(ctx, now); err != nil {
+				log.Printf("digest mail job error: %v", err)
+			}
+		}
+	}
+}
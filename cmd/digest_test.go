@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"remote-radar/internal/digest"
+)
+
+func TestParseDigestArgsDefaults(t *testing.T) {
+	t.Parallel()
+
+	opts, err := parseDigestArgs(nil)
+	if err != nil {
+		t.Fatalf("parseDigestArgs error: %v", err)
+	}
+	if opts.format != digest.FormatEPUB || opts.since != 7*24*time.Hour || opts.out != "digest.epub" {
+		t.Fatalf("unexpected defaults: %+v", opts)
+	}
+}
+
+func TestParseDigestArgsOverrides(t *testing.T) {
+	t.Parallel()
+
+	opts, err := parseDigestArgs([]string{"--format=pdf", "--since=48h", "--out=out.pdf"})
+	if err != nil {
+		t.Fatalf("parseDigestArgs error: %v", err)
+	}
+	if opts.format != digest.FormatPDF || opts.since != 48*time.Hour || opts.out != "out.pdf" {
+		t.Fatalf("unexpected overrides: %+v", opts)
+	}
+}
+
+func TestParseSinceDurationDays(t *testing.T) {
+	t.Parallel()
+
+	d, err := parseSinceDuration("7d")
+	if err != nil {
+		t.Fatalf("parseSinceDuration error: %v", err)
+	}
+	if d != 7*24*time.Hour {
+		t.Fatalf("expected 7 days, got %v", d)
+	}
+}
+
+func TestRunDigestCommandPropagatesBuilderError(t *testing.T) {
+	t.Parallel()
+
+	err := runDigestCommand(context.Background(), AppConfig{}, func(AppConfig) (appDeps, func(), error) {
+		return appDeps{}, func() {}, errors.New("build fail")
+	}, nil)
+	if err == nil {
+		t.Fatalf("expected error when builder fails")
+	}
+}
+
+func TestRunDigestCommandRequiresDigestService(t *testing.T) {
+	t.Parallel()
+
+	err := runDigestCommand(context.Background(), AppConfig{}, func(AppConfig) (appDeps, func(), error) {
+		return appDeps{}, func() {}, nil
+	}, nil)
+	if err == nil {
+		t.Fatalf("expected error when digest service unavailable")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"flag"
 	"fmt"
@@ -16,11 +17,18 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"remote-radar/internal/api"
+	"remote-radar/internal/cluster"
+	"remote-radar/internal/dedup"
+	"remote-radar/internal/digest"
 	"remote-radar/internal/fetcher"
+	"remote-radar/internal/filter"
+	"remote-radar/internal/jobs"
 	"remote-radar/internal/model"
 	"remote-radar/internal/notifier"
 	"remote-radar/internal/processor"
+	"remote-radar/internal/queue"
 	"remote-radar/internal/scheduler"
+	"remote-radar/internal/search"
 	"remote-radar/internal/storage"
 	"remote-radar/internal/subscription"
 
@@ -36,19 +44,107 @@ type AppConfig struct {
 	Database     DatabaseConfig       `yaml:"database"`
 	Processor    processor.Config     `yaml:"processor"`
 	Subscription subscription.Config  `yaml:"subscription"`
+	Search       search.Config        `yaml:"search"`
+	Digest       digest.Config        `yaml:"digest"`
+	Queue        QueueConfig          `yaml:"queue"`
+	Cluster      ClusterConfig        `yaml:"cluster"`
+	Retention    RetentionConfig      `yaml:"retention"`
+	Jobs         JobServerConfig      `yaml:"jobs"`
+	Archive      ArchiveConfig        `yaml:"archive"`
+}
+
+// JobServerConfig 控制 jobs.JobServer 的轮询节奏、卡死判定与重试上限，字段留空时使用
+// jobs.NewJobServer 的默认值。DisableSchedulers 为 true 时该实例只认领、执行已有任务，不再
+// 根据已注册 Scheduler 创建新任务，配合多实例部署可指定仅一个 leader 实例触发调度。
+type JobServerConfig struct {
+	PollInterval      string `yaml:"poll_interval"`
+	RunTimeout        string `yaml:"run_timeout"`
+	StallThreshold    string `yaml:"stall_threshold"`
+	MaxRetries        int    `yaml:"max_retries"`
+	DisableSchedulers bool   `yaml:"disable_schedulers"`
+}
+
+// RetentionConfig 控制历史数据的定期清理，字段为 0 时不清理。
+type RetentionConfig struct {
+	ScheduleRunDays int `yaml:"schedule_run_days"`
+}
+
+// ArchiveConfig 控制 RawJob/Job 历史数据归档为压缩 NDJSON 文件并从热表删除：Dir 为空时不启动归档
+// worker；Interval 留空时默认每 24 小时运行一次；Sources 按来源名配置差异化保留天数，未出现在
+// Sources 中的来源使用 DefaultRetention。
+type ArchiveConfig struct {
+	Dir              string                           `yaml:"dir"`
+	Interval         string                           `yaml:"interval"`
+	DefaultRetention SourceRetentionConfig            `yaml:"default_retention"`
+	Sources          map[string]SourceRetentionConfig `yaml:"sources"`
+}
+
+// SourceRetentionConfig 描述某个来源的归档保留策略：各字段均为天数，0 表示沿用该层级的默认值
+// （ArchiveConfig.DefaultRetention 留空的字段则整体跳过对应归档）。
+type SourceRetentionConfig struct {
+	RawJobProcessedDays int `yaml:"raw_job_processed_days"`
+	RawJobRejectedDays  int `yaml:"raw_job_rejected_days"`
+	JobDays             int `yaml:"job_days"`
+}
+
+// ClusterConfig 控制多实例部署下的 leader 选举，Redis.Addr 为空时使用单机 SingleLeader，
+// 即每个实例都会触发抓取调度（单实例部署下的默认行为）。
+type ClusterConfig struct {
+	Redis cluster.RedisConfig `yaml:"redis"`
+}
+
+// QueueConfig 控制分布式 RawJob 处理是否启用：Redis.Addr 为空时不启动 WorkerPool，
+// 继续沿用 Scheduler.runOnce 自带的串行处理阶段。Acquirer.Workers > 0 时额外启动基于数据库租约的
+// AcquirerPool，二者可同时开启，分别认领各自抓取到的 RawJob（Redis 队列与数据库租约互不重叠）。
+type QueueConfig struct {
+	Redis    queue.RedisConfig          `yaml:"redis"`
+	Pool     scheduler.WorkerPoolConfig `yaml:"pool"`
+	Acquirer scheduler.AcquirerConfig   `yaml:"acquirer"`
 }
 
 type ServerConfig struct {
 	Addr string `yaml:"addr"`
 }
 
+// DatabaseConfig 描述数据库连接方式。Path 为 SQLite 数据库文件路径，是历史单机部署的默认用法；
+// 配置了 Driver 时改为通过 Driver/DSN 连接（postgres/mysql），Path 被忽略。Cache 控制
+// GetJob/ListJobs/CountJobs 等只读查询结果的内存缓存，默认不开启。
 type DatabaseConfig struct {
-	Path string `yaml:"path"`
+	Path   string      `yaml:"path"`
+	Driver string      `yaml:"driver"`
+	DSN    string      `yaml:"dsn"`
+	Cache  CacheConfig `yaml:"cache"`
 }
 
-// NotifierConfig 控制通知方式。
+// CacheConfig 对应 storage.QueryCacheConfig：TTL 为空时默认 30 秒，Size 为 0 时默认 256 条。
+type CacheConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Size    int    `yaml:"size"`
+	TTL     string `yaml:"ttl"`
+}
+
+// NotifierConfig 控制通知方式，Webhook/Bark/Mastodon/Slack/Telegram 为对应渠道的共享配置，
+// 具体投递目标（URL/设备 key/访问令牌/Chat ID）来自订阅自身。Feed 为站点级 RSS/Atom 文件，不按订阅路由，
+// 配置了 Path 才会启用，通过 notifier.Multi 与其余渠道并行投递、互不影响成败。
+// Retry/Breaker 控制顶层 Notify 调用的退避重试与熔断，字段为 0 时使用 notifier.RetryingNotifier 的默认值。
 type NotifierConfig struct {
-	Driver string `yaml:"driver"`
+	Driver       string                        `yaml:"driver"`
+	Webhook      notifier.WebhookConfig        `yaml:"webhook"`
+	Bark         notifier.BarkConfig           `yaml:"bark"`
+	Mastodon     notifier.MastodonConfig       `yaml:"mastodon"`
+	Slack        notifier.SlackConfig          `yaml:"slack"`
+	Telegram     notifier.TelegramConfig       `yaml:"telegram"`
+	Feed         notifier.FeedConfig           `yaml:"feed"`
+	Retry        notifier.BackoffConfig        `yaml:"retry"`
+	Breaker      notifier.CircuitBreakerConfig `yaml:"breaker"`
+	StaleWarning StaleWarningConfig            `yaml:"stale_warning"`
+}
+
+// StaleWarningConfig 控制“职位停留过久”预警的触发阈值与检查频率，After 留空时默认 14 天，
+// CheckInterval 留空时默认每小时检查一次。
+type StaleWarningConfig struct {
+	After         string `yaml:"after"`
+	CheckInterval string `yaml:"check_interval"`
 }
 
 const defaultShutdownTimeout = 5 * time.Second
@@ -64,12 +160,32 @@ type serverRunner interface {
 }
 
 type appDeps struct {
-	store *storage.Store
-	sched schedulerRunner
-	proc  processor.JobProcessor
+	store        *storage.Store
+	sched        schedulerRunner
+	proc         processor.JobProcessor
+	search       *search.SearchService
+	digest       *digest.Service
+	subNotifier  *notifier.SubscriptionNotifier
+	jobServer    *jobs.JobServer
+	workerPool   *scheduler.WorkerPool
+	acquirerPool *scheduler.AcquirerPool
+	registry     *scheduler.JobRegistry
+	retryNotif   *notifier.RetryingNotifier
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "digest" {
+		cfg, err := loadConfig()
+		if err != nil {
+			log.Printf("load config error: %v", err)
+			return
+		}
+		if err := runDigestCommand(context.Background(), cfg, buildApp, os.Args[2:]); err != nil {
+			log.Printf("digest command error: %v", err)
+		}
+		return
+	}
+
 	var runOnce bool
 	flag.BoolVar(&runOnce, "once", false, "run crawler once and exit")
 	flag.Parse()
@@ -97,7 +213,12 @@ func main() {
 	}
 	defer cleanup()
 
-	subSvc := subscription.NewService(deps.store, subscription.Config{AllowedChannels: cfg.Subscription.AllowedChannels, TagCandidates: cfg.Processor.TagCandidates})
+	subSvc := subscription.NewService(deps.store, subscription.Config{
+		AllowedChannels:    cfg.Subscription.AllowedChannels,
+		TagCandidates:      cfg.Processor.TagCandidates,
+		Filter:             cfg.Subscription.Filter,
+		ViolationThreshold: cfg.Subscription.ViolationThreshold,
+	})
 	metaData := api.MetaResponse{
 		TagCandidates:   cfg.Processor.TagCandidates,
 		EmploymentTypes: cfg.Processor.EmploymentTypes,
@@ -106,7 +227,29 @@ func main() {
 		LanguageOptions: cfg.Processor.LanguageOptions,
 		Channels:        cfg.Subscription.AllowedChannels,
 	}
-	handler := api.NewHandler(storeAdapter{store: deps.store}, schedulerAdapter{deps.sched}, metaProvider{metaData}, subscriptionAdapter{subSvc})
+	var searchSvc api.SearchService
+	if deps.search != nil {
+		searchSvc = searchAdapter{svc: deps.search}
+	}
+	var notifSvc api.NotifierService
+	if deps.retryNotif != nil {
+		notifSvc = notifierAdapter{notif: deps.retryNotif}
+	}
+	var queueSvc api.QueueService
+	if cfg.Queue.Acquirer.Workers > 0 {
+		queueSvc = queueAdapter{store: deps.store}
+	}
+	llmUsageSvc := llmUsageAdapter{store: deps.store}
+	feedSvc := subscription.NewFeedService(deps.store)
+	var cacheSvc api.CacheService
+	if cfg.Database.Cache.Enabled {
+		cacheSvc = cacheAdapter{store: deps.store}
+	}
+	var archiveSvc api.ArchiveService
+	if cfg.Archive.Dir != "" {
+		archiveSvc = archiveAdapter{store: deps.store, cfg: cfg.Archive}
+	}
+	handler := api.NewHandler(storeAdapter{store: deps.store}, schedulerAdapter{deps.sched}, metaProvider{metaData}, subscriptionAdapter{subSvc}, searchSvc, adminAdapter{store: deps.store, subNotifier: deps.subNotifier, jobServer: deps.jobServer}, clusterAdapter{sched: deps.sched}, scheduleAdapter{registry: deps.registry}, runAdapter{store: deps.store, registry: deps.registry}, notifSvc, queueSvc, llmUsageSvc, feedSvc, cacheSvc, archiveSvc)
 
 	addr := cfg.Server.Addr
 	if addr == "" {
@@ -118,6 +261,40 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if deps.jobServer != nil {
+		go func() {
+			if err := deps.jobServer.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("job server stopped: %v", err)
+			}
+		}()
+	}
+
+	if deps.workerPool != nil {
+		go func() {
+			if err := deps.workerPool.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("worker pool stopped: %v", err)
+			}
+		}()
+	}
+
+	if deps.acquirerPool != nil {
+		go func() {
+			if err := deps.acquirerPool.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("acquirer pool stopped: %v", err)
+			}
+		}()
+	}
+
+	if cfg.Digest.MailIntervalHours > 0 && deps.digest != nil {
+		format := digest.Format(cfg.Digest.MailFormat)
+		if format == "" {
+			format = digest.FormatEPUB
+		}
+		interval := time.Duration(cfg.Digest.MailIntervalHours) * time.Hour
+		mailJob := digest.NewMailJob(deps.digest, deps.store, emailSenderAdapter{sender: notifier.NewSMTPClient(cfg.Email)}, cfg.Email.From, format, interval)
+		go runDigestMailLoop(ctx, mailJob, interval)
+	}
+
 	log.Printf("listening on %s", addr)
 	if err := runServer(ctx, srv, deps.sched, defaultShutdownTimeout); err != nil {
 		log.Printf("server stopped: %v", err)
@@ -184,7 +361,18 @@ func buildApp(cfg AppConfig) (appDeps, func(), error) {
 		dbPath = "jobs.db"
 	}
 
-	store, err := storage.NewStore(dbPath)
+	storeCfg := storage.Config{Driver: cfg.Database.Driver, DSN: cfg.Database.DSN}
+	if storeCfg.Driver == "" {
+		storeCfg = storage.Config{Driver: storage.DriverSQLite, DSN: dbPath}
+	}
+	storeCfg.Cache = storage.QueryCacheConfig{Enabled: cfg.Database.Cache.Enabled, Size: cfg.Database.Cache.Size}
+	if cfg.Database.Cache.TTL != "" {
+		if d, err := time.ParseDuration(cfg.Database.Cache.TTL); err == nil && d > 0 {
+			storeCfg.Cache.TTL = d
+		}
+	}
+
+	store, err := storage.NewStoreWithConfig(storeCfg)
 	if err != nil {
 		return appDeps{}, nil, fmt.Errorf("init store: %w", err)
 	}
@@ -193,14 +381,312 @@ func buildApp(cfg AppConfig) (appDeps, func(), error) {
 		store.Close()
 	}
 
-	client := &http.Client{Timeout: 15 * time.Second}
-	fetch := fetcher.NewEleduckFetcher("https://eleduck.com", cfg.Fetcher, client)
-	llm := processor.NewDeepseekClient(resolveDeepseekConfig(cfg.Processor.Deepseek), nil)
+	client := fetcher.NewRateLimitedClient(&http.Client{Timeout: 15 * time.Second}, cfg.Fetcher.RateLimit).Client
+	fetch, err := buildFetcher(cfg.Fetcher, client)
+	if err != nil {
+		cleanup()
+		return appDeps{}, nil, fmt.Errorf("build fetcher: %w", err)
+	}
+	llm, rawLLM, err := buildLLMClient(cfg.Processor, store)
+	if err != nil {
+		cleanup()
+		return appDeps{}, nil, fmt.Errorf("build llm client: %w", err)
+	}
 	proc := processor.New(cfg.Processor, llm)
+	if cfg.Processor.Dedup.Enabled {
+		if embedder, ok := rawLLM.(processor.EmbeddingClient); ok {
+			proc.WithDedup(embedder, store, cfg.Processor.Dedup)
+		}
+	}
+
+	var searchSvc *search.SearchService
+	if len(cfg.Search.Addresses) > 0 {
+		searchSvc, err = search.NewSearchService(context.Background(), cfg.Search)
+		if err != nil {
+			cleanup()
+			return appDeps{}, nil, fmt.Errorf("init search: %w", err)
+		}
+	}
+
 	notif := selectNotifier(cfg, store)
-	sched := scheduler.NewScheduler(fetch, store, proc, notif, scheduler.Config{Interval: cfg.Fetcher.Interval, Timeout: "30s", ProcessorBatchSize: cfg.Processor.BatchSize})
+	var subNotifier *notifier.SubscriptionNotifier
+	if sn, ok := notif.(*notifier.SubscriptionNotifier); ok {
+		sn.WithChannels(cfg.Notifier.Webhook, cfg.Notifier.Bark, cfg.Notifier.Mastodon, cfg.Notifier.Slack, cfg.Notifier.Telegram, store)
+		subNotifier = sn
+	}
+	if searchSvc != nil {
+		indexer := search.NewESIndexer(searchSvc, 3, 500*time.Millisecond)
+		if notif != nil {
+			notif = notifier.NewCompositeNotifier(notif, indexer)
+		} else {
+			notif = indexer
+		}
+	}
+	if cfg.Notifier.Feed.Path != "" {
+		feed := notifier.NewFeedNotifier(cfg.Notifier.Feed)
+		if notif != nil {
+			notif = notifier.NewMulti(notif, feed)
+		} else {
+			notif = feed
+		}
+	}
+
+	var retryNotif *notifier.RetryingNotifier
+	if notif != nil {
+		retryNotif = notifier.NewRetryingNotifier(notif, cfg.Notifier.Retry, cfg.Notifier.Breaker, store)
+		notif = retryNotif
+	}
+
+	registry := scheduler.NewJobRegistry(store, proc, notif).WithRunStore(store)
+
+	if cfg.Cluster.Redis.Addr != "" {
+		leader := cluster.NewRedisLeader(cfg.Cluster.Redis)
+		registry = registry.WithLeader(leader)
+		prevCleanup := cleanup
+		cleanup = func() {
+			if err := leader.Close(); err != nil {
+				log.Printf("close cluster leader: %v", err)
+			}
+			prevCleanup()
+		}
+	}
+
+	if err := registry.RegisterDefault(fetch, scheduler.Config{Interval: cfg.Fetcher.Interval, Timeout: "30s", ProcessorBatchSize: cfg.Processor.BatchSize, ArchiveAfter: cfg.Fetcher.ArchiveAfter}); err != nil {
+		cleanup()
+		return appDeps{}, nil, fmt.Errorf("register default schedule: %w", err)
+	}
+	var sched schedulerRunner = registry
+
+	digestSvc := digest.NewService(store, digest.NewCalibreConverterFromConfig(cfg.Digest))
+
+	jobServer := jobs.NewJobServer(store, buildJobServerConfig(cfg.Jobs))
+	jobServer.RegisterWorker(fetchJobWorker{sched: sched}, nil)
+	if subNotifier != nil {
+		jobServer.RegisterWorker(notifyJobWorker{store: store, subNotifier: subNotifier}, nil)
+
+		staleAfter := 14 * 24 * time.Hour
+		if cfg.Notifier.StaleWarning.After != "" {
+			if d, err := parseSinceDuration(cfg.Notifier.StaleWarning.After); err == nil && d > 0 {
+				staleAfter = d
+			}
+		}
+		staleCheckInterval := time.Hour
+		if cfg.Notifier.StaleWarning.CheckInterval != "" {
+			if d, err := time.ParseDuration(cfg.Notifier.StaleWarning.CheckInterval); err == nil && d > 0 {
+				staleCheckInterval = d
+			}
+		}
+		jobServer.RegisterWorker(staleWarningWorker{store: store, subNotifier: subNotifier, after: staleAfter}, jobs.IntervalScheduler{Interval: staleCheckInterval})
+	}
+	if cfg.Retention.ScheduleRunDays > 0 {
+		jobServer.RegisterWorker(pruneScheduleRunsWorker{store: store, retention: time.Duration(cfg.Retention.ScheduleRunDays) * 24 * time.Hour}, jobs.IntervalScheduler{Interval: 24 * time.Hour})
+	}
+	if cfg.Archive.Dir != "" {
+		archiveInterval := 24 * time.Hour
+		if d, err := time.ParseDuration(cfg.Archive.Interval); err == nil && d > 0 {
+			archiveInterval = d
+		}
+		jobServer.RegisterWorker(archiveJobWorker{store: store, cfg: cfg.Archive}, jobs.IntervalScheduler{Interval: archiveInterval})
+	}
+
+	var workerPool *scheduler.WorkerPool
+	if cfg.Queue.Redis.Addr != "" {
+		q := queue.NewRedisQueue(cfg.Queue.Redis)
+		workerPool = scheduler.NewWorkerPool(q, store, proc, notif, cfg.Queue.Pool)
+		prevCleanup := cleanup
+		cleanup = func() {
+			if err := q.Close(); err != nil {
+				log.Printf("close queue: %v", err)
+			}
+			prevCleanup()
+		}
+	}
+
+	var acquirerPool *scheduler.AcquirerPool
+	if cfg.Queue.Acquirer.Workers > 0 {
+		acquirer := scheduler.NewAcquirer(store, workerID(), cfg.Queue.Acquirer)
+		acquirerPool = scheduler.NewAcquirerPool(acquirer, store, proc, notif, cfg.Queue.Acquirer)
+	}
+
+	return appDeps{store: store, sched: sched, proc: proc, search: searchSvc, digest: digestSvc, subNotifier: subNotifier, jobServer: jobServer, workerPool: workerPool, acquirerPool: acquirerPool, registry: registry, retryNotif: retryNotif}, cleanup, nil
+}
+
+// buildJobServerConfig 将 JobServerConfig 的字符串时长字段解析为 jobs.Config，解析失败或留空的
+// 字段保持零值，交由 jobs.NewJobServer 填充默认值。
+func buildJobServerConfig(cfg JobServerConfig) jobs.Config {
+	out := jobs.Config{
+		MaxRetries:        cfg.MaxRetries,
+		DisableSchedulers: cfg.DisableSchedulers,
+	}
+	if d, err := time.ParseDuration(cfg.PollInterval); err == nil {
+		out.PollInterval = d
+	}
+	if d, err := time.ParseDuration(cfg.RunTimeout); err == nil {
+		out.RunTimeout = d
+	}
+	if d, err := time.ParseDuration(cfg.StallThreshold); err == nil {
+		out.StallThreshold = d
+	}
+	return out
+}
+
+// workerID 生成当前实例在分布式租约中使用的唯一标识，格式为 hostname:pid。
+func workerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// fetchJobWorker 将抓取调度包装为 jobs.Worker，供 JobServer 手动触发一次抓取。
+type fetchJobWorker struct {
+	sched schedulerRunner
+}
+
+func (w fetchJobWorker) Type() string { return "fetch" }
+
+func (w fetchJobWorker) Run(ctx context.Context, _ map[string]any) error {
+	_, err := w.sched.RunOnce(ctx)
+	return err
+}
+
+// notifyJobWorker 将 SubscriptionNotifier 包装为 jobs.Worker，对最近一段时间内发布的职位补发通知。
+type notifyJobWorker struct {
+	store       *storage.Store
+	subNotifier *notifier.SubscriptionNotifier
+}
+
+func (w notifyJobWorker) Type() string { return "notify" }
+
+func (w notifyJobWorker) Run(ctx context.Context, data map[string]any) error {
+	since := 15 * time.Minute
+	if minutes, ok := data["since_minutes"].(float64); ok && minutes > 0 {
+		since = time.Duration(minutes) * time.Minute
+	}
+
+	recent, err := w.store.ListJobsSince(ctx, time.Now().Add(-since))
+	if err != nil {
+		return fmt.Errorf("list jobs since: %w", err)
+	}
+	if len(recent) == 0 {
+		return nil
+	}
+	return w.subNotifier.Notify(ctx, recent)
+}
+
+// pruneScheduleRunsWorker 将调度运行历史的保留策略包装为 jobs.Worker，按固定间隔清理过期记录。
+type pruneScheduleRunsWorker struct {
+	store     *storage.Store
+	retention time.Duration
+}
+
+func (w pruneScheduleRunsWorker) Type() string { return "prune_schedule_runs" }
+
+func (w pruneScheduleRunsWorker) Run(ctx context.Context, _ map[string]any) error {
+	_, err := w.store.PruneScheduleRuns(ctx, time.Now().Add(-w.retention))
+	return err
+}
+
+// archiveJobWorker 按 cfg.Sources（未配置来源时回退到 cfg.DefaultRetention）对每个 source 分别计算
+// RawJob（按 processed/rejected 状态区分保留期）与 Job 的归档截止时间，调用 Store.ArchiveRawJobs/
+// ArchiveJobs 把到期数据写入压缩 NDJSON 并从热表删除，实现按来源、按状态差异化的保留策略。
+type archiveJobWorker struct {
+	store *storage.Store
+	cfg   ArchiveConfig
+}
+
+func (w archiveJobWorker) Type() string { return "archive" }
+
+func (w archiveJobWorker) Run(ctx context.Context, _ map[string]any) error {
+	sources := w.cfg.Sources
+	if len(sources) == 0 {
+		sources = map[string]SourceRetentionConfig{"": w.cfg.DefaultRetention}
+	}
+
+	for source, retention := range sources {
+		if retention.RawJobProcessedDays <= 0 {
+			retention.RawJobProcessedDays = w.cfg.DefaultRetention.RawJobProcessedDays
+		}
+		if retention.RawJobRejectedDays <= 0 {
+			retention.RawJobRejectedDays = w.cfg.DefaultRetention.RawJobRejectedDays
+		}
+		if retention.JobDays <= 0 {
+			retention.JobDays = w.cfg.DefaultRetention.JobDays
+		}
+
+		if retention.RawJobProcessedDays > 0 {
+			before := time.Now().Add(-time.Duration(retention.RawJobProcessedDays) * 24 * time.Hour)
+			if _, err := w.store.ArchiveRawJobs(ctx, storage.ArchiveRawJobsOptions{Source: source, Status: model.RawJobStatusProcessed, Before: before, Dir: w.cfg.Dir}); err != nil {
+				return fmt.Errorf("archive processed raw jobs for %q: %w", source, err)
+			}
+		}
+		if retention.RawJobRejectedDays > 0 {
+			before := time.Now().Add(-time.Duration(retention.RawJobRejectedDays) * 24 * time.Hour)
+			if _, err := w.store.ArchiveRawJobs(ctx, storage.ArchiveRawJobsOptions{Source: source, Status: model.RawJobStatusRejected, Before: before, Dir: w.cfg.Dir}); err != nil {
+				return fmt.Errorf("archive rejected raw jobs for %q: %w", source, err)
+			}
+		}
+		if retention.JobDays > 0 {
+			before := time.Now().Add(-time.Duration(retention.JobDays) * 24 * time.Hour)
+			if _, err := w.store.ArchiveJobs(ctx, storage.ArchiveJobsOptions{Source: source, Before: before, Dir: w.cfg.Dir}); err != nil {
+				return fmt.Errorf("archive jobs for %q: %w", source, err)
+			}
+		}
+	}
+	return nil
+}
+
+// staleWarningWorker 按固定间隔检查仍未下线但 open 过久的职位，通过 SubscriptionNotifier 按订阅路由
+// 推送一次性预警；Store.MarkStaleWarned 标记后同一职位不会重复提醒，直到它被归档后重新出现才会再次计时。
+type staleWarningWorker struct {
+	store       *storage.Store
+	subNotifier *notifier.SubscriptionNotifier
+	after       time.Duration
+}
+
+func (w staleWarningWorker) Type() string { return "stale_warning" }
+
+func (w staleWarningWorker) Run(ctx context.Context, _ map[string]any) error {
+	stale, err := w.store.ListStaleOpenJobs(ctx, w.after)
+	if err != nil {
+		return fmt.Errorf("list stale open jobs: %w", err)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	if err := w.subNotifier.Notify(ctx, stale); err != nil {
+		return fmt.Errorf("notify stale jobs: %w", err)
+	}
+	ids := make([]string, 0, len(stale))
+	for _, job := range stale {
+		ids = append(ids, job.ID)
+	}
+	return w.store.MarkStaleWarned(ctx, ids)
+}
 
-	return appDeps{store: store, sched: sched, proc: proc}, cleanup, nil
+// buildFetcher 根据配置构建抓取器：配置了 fetcher.sources 时通过 Registry 构建多源并发抓取，
+// 否则退回到默认的电鸭单源抓取，保持旧配置可用。
+func buildFetcher(cfg fetcher.Config, client *http.Client) (fetcher.JobFetcher, error) {
+	if len(cfg.Sources) == 0 {
+		f := fetcher.NewEleduckFetcher("https://eleduck.com", cfg, client).
+			WithSensitiveFilter(filter.NewService(cfg.Filter)).
+			WithHealthStore(fetcher.NewInMemorySourceHealthStore())
+		if cfg.Dedup.Addr != "" {
+			f = f.WithDedupStore(dedup.NewRedisStore(cfg.Dedup))
+		}
+		return f, nil
+	}
+
+	registry := fetcher.NewRegistry()
+	fetchers, err := registry.Build(cfg.Sources, client)
+	if err != nil {
+		return nil, err
+	}
+	if len(fetchers) == 0 {
+		return nil, fmt.Errorf("no fetcher sources enabled")
+	}
+	return fetcher.NewMultiFetcher(fetchers...), nil
 }
 
 // selectNotifier 根据配置决定使用哪种通知方式。
@@ -258,12 +744,358 @@ func (s schedulerAdapter) RunOnce(_ *http.Request) (int, error) {
 	return s.sched.RunOnce(context.Background())
 }
 
+// leaderStatusProvider 由支持 leader 选举状态查询的调度器实现（*scheduler.Scheduler 与 buildApp 使用的 *scheduler.JobRegistry）。
+type leaderStatusProvider interface {
+	LeaderStatus(ctx context.Context) (cluster.Status, error)
+}
+
+type clusterAdapter struct {
+	sched schedulerRunner
+}
+
+func (c clusterAdapter) LeaderStatus(ctx context.Context) (api.ClusterStatus, error) {
+	provider, ok := c.sched.(leaderStatusProvider)
+	if !ok {
+		return api.ClusterStatus{}, nil
+	}
+	status, err := provider.LeaderStatus(ctx)
+	if err != nil {
+		return api.ClusterStatus{}, err
+	}
+	return api.ClusterStatus{LeaderID: status.LeaderID, TTL: status.TTL}, nil
+}
+
+type scheduleAdapter struct {
+	registry *scheduler.JobRegistry
+}
+
+func (s scheduleAdapter) ListSchedules(ctx context.Context) []api.ScheduleStatus {
+	if s.registry == nil {
+		return nil
+	}
+	statuses := s.registry.List()
+	out := make([]api.ScheduleStatus, 0, len(statuses))
+	for _, status := range statuses {
+		out = append(out, api.ScheduleStatus{
+			Name: status.Name,
+			Spec: api.ScheduleSpec{
+				Interval:  status.Spec.Interval,
+				Timeout:   status.Spec.Timeout,
+				BatchSize: status.Spec.BatchSize,
+				Enabled:   status.Spec.Enabled,
+			},
+			LastRunAt: status.LastRunAt,
+			LastCount: status.LastCount,
+			LastErr:   status.LastErr,
+		})
+	}
+	return out
+}
+
+func (s scheduleAdapter) UpdateSchedule(ctx context.Context, name string, spec api.ScheduleSpec) error {
+	if s.registry == nil {
+		return fmt.Errorf("schedules disabled")
+	}
+	return s.registry.Update(name, scheduler.JobSpec{
+		Interval:  spec.Interval,
+		Timeout:   spec.Timeout,
+		BatchSize: spec.BatchSize,
+		Enabled:   spec.Enabled,
+	})
+}
+
+type runAdapter struct {
+	store    *storage.Store
+	registry *scheduler.JobRegistry
+}
+
+func (r runAdapter) ListRuns(ctx context.Context, q api.RunQuery) ([]api.RunSummary, error) {
+	runs, err := r.store.ListScheduleRuns(ctx, storage.ScheduleRunQuery{
+		JobName: q.JobName,
+		Status:  model.ScheduleRunStatus(q.Status),
+		Limit:   q.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]api.RunSummary, 0, len(runs))
+	for _, run := range runs {
+		out = append(out, runSummaryFromModel(run))
+	}
+	return out, nil
+}
+
+func (r runAdapter) GetRun(ctx context.Context, id uint) (*api.RunDetail, error) {
+	run, err := r.store.GetScheduleRun(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	outcomes, err := r.store.ListScheduleRunOutcomes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	detail := &api.RunDetail{RunSummary: runSummaryFromModel(*run), Outcomes: make([]api.RunOutcome, 0, len(outcomes))}
+	for _, outcome := range outcomes {
+		detail.Outcomes = append(detail.Outcomes, api.RunOutcome{RawJobID: outcome.RawJobID, Status: string(outcome.Status), Reason: outcome.Reason})
+	}
+	return detail, nil
+}
+
+func (r runAdapter) ActiveRuns(ctx context.Context) []api.ActiveRun {
+	if r.registry == nil {
+		return nil
+	}
+	active := r.registry.ActiveRuns()
+	out := make([]api.ActiveRun, 0, len(active))
+	for _, run := range active {
+		out = append(out, api.ActiveRun{
+			ID:        run.ID,
+			JobName:   run.JobName,
+			Phase:     string(run.Phase),
+			StartedAt: run.StartedAt,
+			ElapsedMS: time.Since(run.StartedAt).Milliseconds(),
+		})
+	}
+	return out
+}
+
+func (r runAdapter) CancelRun(ctx context.Context, id uint) error {
+	if r.registry == nil {
+		return fmt.Errorf("scheduler registry unavailable")
+	}
+	if !r.registry.Cancel(id) {
+		return fmt.Errorf("run %d not found", id)
+	}
+	return nil
+}
+
+type notifierAdapter struct {
+	notif *notifier.RetryingNotifier
+}
+
+func (n notifierAdapter) Status(ctx context.Context) api.NotifierStatus {
+	status := n.notif.Status()
+	out := api.NotifierStatus{State: string(status.State), Failures: status.Failures, LastError: status.LastError}
+	if !status.PausedUntil.IsZero() {
+		out.PausedUntil = &status.PausedUntil
+		out.NextRetryAt = &status.NextRetryAt
+	}
+	return out
+}
+
+func (n notifierAdapter) Resume(ctx context.Context) error {
+	n.notif.Resume()
+	return nil
+}
+
+type queueAdapter struct {
+	store *storage.Store
+}
+
+func (q queueAdapter) ListWorkers(ctx context.Context) ([]api.WorkerLease, error) {
+	leases, err := q.store.ListActiveLeases(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]api.WorkerLease, 0, len(leases))
+	for _, lease := range leases {
+		out = append(out, api.WorkerLease{WorkerID: lease.WorkerID, LeasedJobs: lease.LeasedJobs, OldestLease: lease.OldestLease})
+	}
+	return out, nil
+}
+
+func (q queueAdapter) QueueStats(ctx context.Context) (api.QueueStats, error) {
+	stats, err := q.store.QueueStats(ctx)
+	if err != nil {
+		return api.QueueStats{}, err
+	}
+	return api.QueueStats{Pending: stats.Pending, Leased: stats.Leased, Processed: stats.Processed, Rejected: stats.Rejected, DeadLetter: stats.DeadLetter}, nil
+}
+
+type cacheAdapter struct {
+	store *storage.Store
+}
+
+func (c cacheAdapter) CacheStats(ctx context.Context) (api.CacheStats, error) {
+	stats := c.store.CacheStats()
+	return api.CacheStats{Hits: stats.Hits, Misses: stats.Misses, Evictions: stats.Evictions}, nil
+}
+
+// archiveAdapter 按 archiveJobWorker.Run 相同的按来源、按状态保留期计算方式统计待归档数量，
+// 供 /api/admin/archive/stats 展示，避免在归档真正运行前对堆积量一无所知。
+type archiveAdapter struct {
+	store *storage.Store
+	cfg   ArchiveConfig
+}
+
+func (a archiveAdapter) ArchiveStats(ctx context.Context) (api.ArchiveStats, error) {
+	sources := a.cfg.Sources
+	if len(sources) == 0 {
+		sources = map[string]SourceRetentionConfig{"": a.cfg.DefaultRetention}
+	}
+
+	var stats api.ArchiveStats
+	for source, retention := range sources {
+		if retention.RawJobProcessedDays <= 0 {
+			retention.RawJobProcessedDays = a.cfg.DefaultRetention.RawJobProcessedDays
+		}
+		if retention.RawJobRejectedDays <= 0 {
+			retention.RawJobRejectedDays = a.cfg.DefaultRetention.RawJobRejectedDays
+		}
+		if retention.JobDays <= 0 {
+			retention.JobDays = a.cfg.DefaultRetention.JobDays
+		}
+
+		if retention.RawJobProcessedDays > 0 {
+			before := time.Now().Add(-time.Duration(retention.RawJobProcessedDays) * 24 * time.Hour)
+			count, err := a.store.PendingRawJobArchiveCount(ctx, source, model.RawJobStatusProcessed, before)
+			if err != nil {
+				return api.ArchiveStats{}, fmt.Errorf("pending processed raw jobs for %q: %w", source, err)
+			}
+			stats.PendingRawJobs += count
+		}
+		if retention.RawJobRejectedDays > 0 {
+			before := time.Now().Add(-time.Duration(retention.RawJobRejectedDays) * 24 * time.Hour)
+			count, err := a.store.PendingRawJobArchiveCount(ctx, source, model.RawJobStatusRejected, before)
+			if err != nil {
+				return api.ArchiveStats{}, fmt.Errorf("pending rejected raw jobs for %q: %w", source, err)
+			}
+			stats.PendingRawJobs += count
+		}
+		if retention.JobDays > 0 {
+			before := time.Now().Add(-time.Duration(retention.JobDays) * 24 * time.Hour)
+			count, err := a.store.PendingJobArchiveCount(ctx, source, before)
+			if err != nil {
+				return api.ArchiveStats{}, fmt.Errorf("pending jobs for %q: %w", source, err)
+			}
+			stats.PendingJobs += count
+		}
+	}
+	return stats, nil
+}
+
+type llmUsageAdapter struct {
+	store *storage.Store
+}
+
+func (l llmUsageAdapter) Usage(ctx context.Context) (api.LLMUsageSummary, error) {
+	since := time.Now().Truncate(24 * time.Hour)
+	summary, err := l.store.LLMUsageSince(ctx, since)
+	if err != nil {
+		return api.LLMUsageSummary{}, err
+	}
+	return api.LLMUsageSummary{Calls: summary.Calls, PromptTokens: summary.PromptTokens, CompletionTokens: summary.CompletionTokens, CostUSD: summary.CostUSD}, nil
+}
+
+func runSummaryFromModel(run model.ScheduleRun) api.RunSummary {
+	return api.RunSummary{
+		ID:            run.ID,
+		JobName:       run.JobName,
+		Status:        string(run.Status),
+		TriggeredBy:   string(run.TriggeredBy),
+		FetchedCount:  run.FetchedCount,
+		AcceptedCount: run.AcceptedCount,
+		RejectedCount: run.RejectedCount,
+		CreatedCount:  run.CreatedCount,
+		Error:         run.Error,
+		StartedAt:     run.StartedAt,
+		FinishedAt:    run.FinishedAt,
+	}
+}
+
 type metaProvider struct {
 	data api.MetaResponse
 }
 
 func (m metaProvider) Snapshot() api.MetaResponse { return m.data }
 
+type searchAdapter struct {
+	svc *search.SearchService
+}
+
+func (s searchAdapter) Query(ctx context.Context, q api.SearchQuery) ([]model.Job, error) {
+	return s.svc.Query(ctx, search.SearchQuery{
+		Keyword: q.Keyword,
+		Tags:    q.Tags,
+		Sources: q.Sources,
+		Since:   q.Since,
+		Until:   q.Until,
+		Limit:   q.Limit,
+		Offset:  q.Offset,
+	})
+}
+
+type adminAdapter struct {
+	store       *storage.Store
+	subNotifier *notifier.SubscriptionNotifier
+	jobServer   *jobs.JobServer
+}
+
+func (a adminAdapter) UnfreezeSubscription(ctx context.Context, id uint) error {
+	return a.store.UnfreezeSubscription(ctx, id)
+}
+
+func (a adminAdapter) RetryNotifications(ctx context.Context) error {
+	if a.subNotifier == nil {
+		return fmt.Errorf("notification retry disabled")
+	}
+	return a.subNotifier.RetryOutbox(ctx)
+}
+
+func (a adminAdapter) SendTestNotification(ctx context.Context, id uint) error {
+	if a.subNotifier == nil {
+		return fmt.Errorf("notification disabled")
+	}
+	sub, err := a.store.GetSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	return a.subNotifier.SendTest(ctx, sub)
+}
+
+func (a adminAdapter) TriggerJob(ctx context.Context, jobType string) (uint, error) {
+	if a.jobServer == nil {
+		return 0, fmt.Errorf("job server disabled")
+	}
+	run, err := a.jobServer.Enqueue(ctx, jobType, nil)
+	if err != nil {
+		return 0, err
+	}
+	return run.ID, nil
+}
+
+func (a adminAdapter) ListJobs(ctx context.Context, jobType, status string) ([]api.JobRunSummary, error) {
+	runs, err := a.store.ListJobRuns(ctx, jobType, status)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]api.JobRunSummary, 0, len(runs))
+	for _, run := range runs {
+		summaries = append(summaries, api.JobRunSummary{
+			ID:         run.ID,
+			Type:       run.Type,
+			Status:     string(run.Status),
+			RetryCount: run.RetryCount,
+			Error:      run.Error,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+			CreatedAt:  run.CreatedAt,
+		})
+	}
+	return summaries, nil
+}
+
+func (a adminAdapter) CancelJob(ctx context.Context, id uint) error {
+	return a.store.CancelJobRun(ctx, id)
+}
+
+func (a adminAdapter) RescheduleJob(ctx context.Context, id uint) error {
+	return a.store.RescheduleJobRun(ctx, id)
+}
+
 type subscriptionAdapter struct {
 	service *subscription.Service
 }
@@ -272,7 +1104,7 @@ func (s subscriptionAdapter) Create(ctx context.Context, req api.SubscriptionReq
 	if s.service == nil {
 		return fmt.Errorf("subscription disabled")
 	}
-	_, err := s.service.Create(ctx, subscription.Request{Email: req.Email, Channel: req.Channel, Tags: req.Tags})
+	_, err := s.service.Create(ctx, subscription.Request{Email: req.Email, Channel: req.Channel, Target: req.Target, Tags: req.Tags, FilterExpr: req.FilterExpr})
 	return err
 }
 
@@ -285,6 +1117,14 @@ func buildJobQuery(r *http.Request, limit, offset int) storage.JobQueryOptions {
 	if len(tags) > 0 {
 		opts.Tags = tags
 	}
+	if status := strings.TrimSpace(r.URL.Query().Get("status")); status != "" {
+		opts.Status = status
+	}
+	if maxAge := strings.TrimSpace(r.URL.Query().Get("max_age")); maxAge != "" {
+		if d, err := parseSinceDuration(maxAge); err == nil && d > 0 {
+			opts.MaxAge = d
+		}
+	}
 	return opts
 }
 
@@ -325,3 +1165,41 @@ func resolveDeepseekConfig(cfg processor.DeepseekConfig) processor.DeepseekConfi
 	}
 	return cfg
 }
+
+// buildLLMClient 依据 cfg.LLM.Provider 通过 ProviderRegistry 构建 LLMClient，Provider 为空时沿用
+// 历史的顶层 processor.deepseek 配置以兼容旧配置文件；cfg.LLM.Cache 为 true 时额外包一层 CachingClient。
+// 除返回值供 Processor 使用的 LLMClient 外，还返回包装前的原始 client，供调用方按需对其做
+// processor.EmbeddingClient 之类的可选能力断言（CachingClient/ChainedClient 等包装层不透传该能力）。
+func buildLLMClient(cfg processor.Config, store *storage.Store) (processor.LLMClient, processor.LLMClient, error) {
+	llmCfg := cfg.LLM
+	if strings.TrimSpace(llmCfg.Provider) == "" || strings.TrimSpace(llmCfg.Provider) == "deepseek" {
+		llmCfg.Deepseek = resolveDeepseekConfig(cfg.Deepseek)
+	}
+
+	provider := strings.TrimSpace(llmCfg.Provider)
+	if provider == "" {
+		provider = "deepseek"
+	}
+
+	client, err := buildLLMChain(llmCfg, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !llmCfg.Cache {
+		return client, client, nil
+	}
+	return processor.NewCachingClient(client, store, provider, llmCfg.ResolvedModel(), llmCfg.Prices, llmCfg.SpendLimit), client, nil
+}
+
+// buildLLMChain 未配置 LLM.Fallback 时直接构建单一供应商客户端，与旧版单供应商部署的错误语义
+// 完全一致；配置了 Fallback 时按 [主供应商, ...Fallback] 顺序组装为 processor.ChainedClient，
+// 各供应商各自包一层 CircuitBreaker，连续失败后暂时跳过转向下一个。
+func buildLLMChain(llmCfg processor.LLMConfig, primary string) (processor.LLMClient, error) {
+	registry := processor.NewProviderRegistry()
+	if len(llmCfg.Fallback) == 0 {
+		return registry.Build(llmCfg, nil)
+	}
+	providers := append([]string{primary}, llmCfg.Fallback...)
+	return processor.BuildChain(registry, llmCfg, providers, nil, 3, 200*time.Millisecond)
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"remote-radar/internal/model"
 )
@@ -32,6 +34,211 @@ type SubscriptionService interface {
 	Create(ctx context.Context, req SubscriptionRequest) error
 }
 
+// AdminService 提供管理员操作，支持解冻被自动冻结的订阅、手动触发通知重试、按类型触发后台任务，
+// 列出/取消/重新调度 internal/jobs 的任务运行记录，以及向指定订阅发送测试通知以验证渠道可达性。
+type AdminService interface {
+	UnfreezeSubscription(ctx context.Context, id uint) error
+	RetryNotifications(ctx context.Context) error
+	TriggerJob(ctx context.Context, jobType string) (uint, error)
+	SendTestNotification(ctx context.Context, id uint) error
+	ListJobs(ctx context.Context, jobType, status string) ([]JobRunSummary, error)
+	CancelJob(ctx context.Context, id uint) error
+	RescheduleJob(ctx context.Context, id uint) error
+}
+
+// JobRunSummary 描述 internal/jobs 一次任务运行的状态摘要，供 /api/admin/jobs 列表展示。
+type JobRunSummary struct {
+	ID         uint       `json:"id"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	RetryCount int        `json:"retry_count"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// FeedService 按 token 渲染订阅的个人化 Atom feed，供 /feeds/{token}.xml 暴露。
+type FeedService interface {
+	Render(ctx context.Context, token string) ([]byte, error)
+}
+
+// ClusterStatus 描述当前 leader 的 ID 与租约剩余时间，TTL 为 0 表示单机部署无租约限制。
+type ClusterStatus struct {
+	LeaderID string        `json:"leader_id"`
+	TTL      time.Duration `json:"ttl"`
+}
+
+// ClusterService 暴露多实例部署下的 leader 选举状态。
+type ClusterService interface {
+	LeaderStatus(ctx context.Context) (ClusterStatus, error)
+}
+
+// ScheduleSpec 描述单个命名抓取任务的调度参数。
+type ScheduleSpec struct {
+	Interval  string `json:"interval"`
+	Timeout   string `json:"timeout"`
+	BatchSize int    `json:"batch_size"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// ScheduleStatus 描述某个命名任务当前的调度参数与最近一次运行状态。
+type ScheduleStatus struct {
+	Name      string       `json:"name"`
+	Spec      ScheduleSpec `json:"spec"`
+	LastRunAt time.Time    `json:"last_run_at"`
+	LastCount int          `json:"last_count"`
+	LastErr   string       `json:"last_err,omitempty"`
+}
+
+// ScheduleService 抽象命名抓取任务的调度状态查询与更新。
+type ScheduleService interface {
+	ListSchedules(ctx context.Context) []ScheduleStatus
+	UpdateSchedule(ctx context.Context, name string, spec ScheduleSpec) error
+}
+
+// RunOutcome 描述一次调度运行中单条 RawJob 的处理结果，Reason 为拒绝原因，接受时为空。
+type RunOutcome struct {
+	RawJobID uint   `json:"raw_job_id"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// RunSummary 描述一次调度运行的统计信息。
+type RunSummary struct {
+	ID            uint       `json:"id"`
+	JobName       string     `json:"job_name"`
+	Status        string     `json:"status"`
+	TriggeredBy   string     `json:"triggered_by"`
+	FetchedCount  int        `json:"fetched_count"`
+	AcceptedCount int        `json:"accepted_count"`
+	RejectedCount int        `json:"rejected_count"`
+	CreatedCount  int        `json:"created_count"`
+	Error         string     `json:"error,omitempty"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// RunDetail 在 RunSummary 基础上附带每条 RawJob 的处理结果，供 /api/jobs/runs/{id} 暴露。
+type RunDetail struct {
+	RunSummary
+	Outcomes []RunOutcome `json:"outcomes"`
+}
+
+// RunQuery 描述 /api/jobs/runs 支持的过滤条件。
+type RunQuery struct {
+	JobName string
+	Status  string
+	Limit   int
+}
+
+// ActiveRun 描述一次仍在执行的调度批次，供 /api/jobs/runs/active 暴露。
+type ActiveRun struct {
+	ID        uint      `json:"id"`
+	JobName   string    `json:"job_name"`
+	Phase     string    `json:"phase"`
+	StartedAt time.Time `json:"started_at"`
+	ElapsedMS int64     `json:"elapsed_ms"`
+}
+
+// RunService 抽象调度运行历史的查询，供 /api/refresh 等调用方轮询执行结果，GetRun 查无记录时返回 (nil, nil)。
+type RunService interface {
+	ListRuns(ctx context.Context, q RunQuery) ([]RunSummary, error)
+	GetRun(ctx context.Context, id uint) (*RunDetail, error)
+	ActiveRuns(ctx context.Context) []ActiveRun
+	CancelRun(ctx context.Context, id uint) error
+}
+
+// NotifierStatus 描述顶层通知熔断器当前的运行状态，供 /api/notifier/status 暴露。
+type NotifierStatus struct {
+	State       string     `json:"state"`
+	Failures    int        `json:"failures"`
+	LastError   string     `json:"last_error,omitempty"`
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
+	NextRetryAt *time.Time `json:"next_retry_at,omitempty"`
+}
+
+// NotifierService 抽象顶层通知熔断器的状态查询与手动恢复。
+type NotifierService interface {
+	Status(ctx context.Context) NotifierStatus
+	Resume(ctx context.Context) error
+}
+
+// WorkerLease 描述一个分布式 worker 当前持有的 RawJob 租约情况，供 /api/workers 暴露。
+type WorkerLease struct {
+	WorkerID    string    `json:"worker_id"`
+	LeasedJobs  int64     `json:"leased_jobs"`
+	OldestLease time.Time `json:"oldest_lease"`
+}
+
+// QueueStats 汇总 RawJob 各状态及租约占用数量，供 /api/queue/stats 暴露。
+type QueueStats struct {
+	Pending    int64 `json:"pending"`
+	Leased     int64 `json:"leased"`
+	Processed  int64 `json:"processed"`
+	Rejected   int64 `json:"rejected"`
+	DeadLetter int64 `json:"dead_letter"`
+}
+
+// QueueService 抽象基于数据库租约的分布式 RawJob 认领状态查询，供 /api/workers、/api/queue/stats 暴露。
+type QueueService interface {
+	ListWorkers(ctx context.Context) ([]WorkerLease, error)
+	QueueStats(ctx context.Context) (QueueStats, error)
+}
+
+// CacheStats 汇总 Store 只读查询缓存的累计命中、未命中与淘汰次数，供 /api/cache/stats 暴露。
+type CacheStats struct {
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+}
+
+// CacheService 抽象 Store 只读查询缓存的统计查询，供 /api/cache/stats 暴露。
+type CacheService interface {
+	CacheStats(ctx context.Context) (CacheStats, error)
+}
+
+// ArchiveStats 汇总各来源待归档的 RawJob/Job 数量，供 /api/admin/archive/stats 暴露，替代
+// Prometheus 指标（本项目未引入 Prometheus 客户端）。
+type ArchiveStats struct {
+	PendingRawJobs int64 `json:"pending_raw_jobs"`
+	PendingJobs    int64 `json:"pending_jobs"`
+}
+
+// ArchiveService 抽象归档待处理量查询，供 /api/admin/archive/stats 暴露。
+type ArchiveService interface {
+	ArchiveStats(ctx context.Context) (ArchiveStats, error)
+}
+
+// LLMUsageSummary 汇总当日 LLM 调用次数、token 用量与预估花费。
+type LLMUsageSummary struct {
+	Calls            int64   `json:"calls"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+// LLMUsageService 抽象 LLM 用量/花费查询，供 /api/llm/usage 暴露。
+type LLMUsageService interface {
+	Usage(ctx context.Context) (LLMUsageSummary, error)
+}
+
+// SearchQuery 表示 /api/jobs/search 支持的查询条件。
+type SearchQuery struct {
+	Keyword string
+	Tags    []string
+	Sources []string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+	Offset  int
+}
+
+// SearchService 抽象全文检索接口，底层由 internal/search 的 ElasticSearch 实现提供。
+type SearchService interface {
+	Query(ctx context.Context, q SearchQuery) ([]model.Job, error)
+}
+
 // MetaResponse 暴露筛选元数据。
 type MetaResponse struct {
 	TagCandidates   []string `json:"tag_candidates"`
@@ -43,14 +250,23 @@ type MetaResponse struct {
 }
 
 // SubscriptionRequest 表示订阅 API 请求。
+// Target 为非邮件渠道的投递目标（如 webhook URL、推送 key）。
+// FilterExpr 为可选的布尔表达式 DSL（如 "tag:backend AND NOT source:acme"），设置时优先于 Tags 生效。
 type SubscriptionRequest struct {
-	Email   string   `json:"email"`
-	Channel string   `json:"channel"`
-	Tags    []string `json:"tags"`
+	Email      string   `json:"email"`
+	Channel    string   `json:"channel"`
+	Target     string   `json:"target"`
+	Tags       []string `json:"tags"`
+	FilterExpr string   `json:"filter_expr"`
 }
 
-// NewHandler 构造 HTTP 多路复用器。
-func NewHandler(store Store, sched Scheduler, meta MetaProvider, subs SubscriptionService) http.Handler {
+// NewHandler 构造 HTTP 多路复用器。search 为 nil 时 /api/jobs/search 返回 503，admin 为 nil 时解冻接口返回 503，
+// cluster 为 nil 时 /api/cluster/leader 返回 503，schedules 为 nil 时 /api/schedules 返回 503，
+// runs 为 nil 时 /api/jobs/runs 返回 503，notif 为 nil 时 /api/notifier/* 返回 503，
+// queue 为 nil 时 /api/workers、/api/queue/stats 返回 503，llmUsage 为 nil 时 /api/llm/usage 返回 503，
+// cache 为 nil 时 /api/cache/stats 返回 503，archive 为 nil 时 /api/admin/archive/stats 返回 503，
+// feeds 为 nil 时 /feeds/ 返回 503。
+func NewHandler(store Store, sched Scheduler, meta MetaProvider, subs SubscriptionService, search SearchService, admin AdminService, cluster ClusterService, schedules ScheduleService, runs RunService, notif NotifierService, queue QueueService, llmUsage LLMUsageService, feeds FeedService, cache CacheService, archive ArchiveService) http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -101,6 +317,23 @@ func NewHandler(store Store, sched Scheduler, meta MetaProvider, subs Subscripti
 		writeJSON(w, http.StatusOK, jobs)
 	})
 
+	mux.HandleFunc("/api/jobs/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if search == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "search disabled"})
+			return
+		}
+		jobs, err := search.Query(r.Context(), parseSearchQuery(r))
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, jobs)
+	})
+
 	mux.HandleFunc("/api/refresh", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -143,6 +376,386 @@ func NewHandler(store Store, sched Scheduler, meta MetaProvider, subs Subscripti
 		writeJSON(w, http.StatusCreated, map[string]string{"status": "ok"})
 	})
 
+	mux.HandleFunc("/api/admin/subscriptions/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/unfreeze") {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if admin == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "admin disabled"})
+			return
+		}
+		idPart := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/admin/subscriptions/"), "/unfreeze")
+		id, err := strconv.ParseUint(idPart, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid subscription id"})
+			return
+		}
+		if err := admin.UnfreezeSubscription(r.Context(), uint(id)); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	mux.HandleFunc("/api/admin/subscriptions/test/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if admin == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "admin disabled"})
+			return
+		}
+		idPart := strings.TrimPrefix(r.URL.Path, "/api/admin/subscriptions/test/")
+		id, err := strconv.ParseUint(idPart, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid subscription id"})
+			return
+		}
+		if err := admin.SendTestNotification(r.Context(), uint(id)); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	mux.HandleFunc("/feeds/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if feeds == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "feeds disabled"})
+			return
+		}
+		token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/feeds/"), ".xml")
+		if token == "" {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "feed not found"})
+			return
+		}
+		data, err := feeds.Render(r.Context(), token)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "feed not found"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	})
+
+	mux.HandleFunc("/api/notifications/retry", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if admin == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "admin disabled"})
+			return
+		}
+		if err := admin.RetryNotifications(r.Context()); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	mux.HandleFunc("/api/notifier/status", func(w http.ResponseWriter, r *http.Request) {
+		if notif == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "notifier disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, http.StatusOK, notif.Status(r.Context()))
+	})
+
+	mux.HandleFunc("/api/notifier/resume", func(w http.ResponseWriter, r *http.Request) {
+		if notif == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "notifier disabled"})
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := notif.Resume(r.Context()); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	mux.HandleFunc("/api/workers", func(w http.ResponseWriter, r *http.Request) {
+		if queue == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "queue disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		workers, err := queue.ListWorkers(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, workers)
+	})
+
+	mux.HandleFunc("/api/queue/stats", func(w http.ResponseWriter, r *http.Request) {
+		if queue == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "queue disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		stats, err := queue.QueueStats(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, stats)
+	})
+
+	mux.HandleFunc("/api/cache/stats", func(w http.ResponseWriter, r *http.Request) {
+		if cache == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "cache disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		stats, err := cache.CacheStats(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, stats)
+	})
+
+	mux.HandleFunc("/api/admin/archive/stats", func(w http.ResponseWriter, r *http.Request) {
+		if archive == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "archive disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		stats, err := archive.ArchiveStats(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, stats)
+	})
+
+	mux.HandleFunc("/api/llm/usage", func(w http.ResponseWriter, r *http.Request) {
+		if llmUsage == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "llm usage disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		summary, err := llmUsage.Usage(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, summary)
+	})
+
+	mux.HandleFunc("/api/admin/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		if admin == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "admin disabled"})
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/jobs/":
+			list, err := admin.ListJobs(r.Context(), r.URL.Query().Get("type"), r.URL.Query().Get("status"))
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, list)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/run"):
+			jobType := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/admin/jobs/"), "/run")
+			if jobType == "" {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid job type"})
+				return
+			}
+			id, err := admin.TriggerJob(r.Context(), jobType)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusAccepted, map[string]uint{"job_id": id})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/cancel"):
+			id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/admin/jobs/"), "/cancel"), 10, 64)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid job id"})
+				return
+			}
+			if err := admin.CancelJob(r.Context(), uint(id)); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/reschedule"):
+			id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/admin/jobs/"), "/reschedule"), 10, 64)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid job id"})
+				return
+			}
+			if err := admin.RescheduleJob(r.Context(), uint(id)); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/cluster/leader", func(w http.ResponseWriter, r *http.Request) {
+		if cluster == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "cluster disabled"})
+			return
+		}
+		status, err := cluster.LeaderStatus(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, status)
+	})
+
+	mux.HandleFunc("/api/schedules", func(w http.ResponseWriter, r *http.Request) {
+		if schedules == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "schedules disabled"})
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, schedules.ListSchedules(r.Context()))
+		case http.MethodPut:
+			var req struct {
+				Name string       `json:"name"`
+				Spec ScheduleSpec `json:"spec"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid payload"})
+				return
+			}
+			if req.Name == "" {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "missing job name"})
+				return
+			}
+			if err := schedules.UpdateSchedule(r.Context(), req.Name, req.Spec); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/jobs/runs", func(w http.ResponseWriter, r *http.Request) {
+		if runs == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "runs disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		limit := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			if v, err := strconv.Atoi(l); err == nil && v > 0 {
+				limit = v
+			}
+		}
+		list, err := runs.ListRuns(r.Context(), RunQuery{
+			JobName: r.URL.Query().Get("job"),
+			Status:  r.URL.Query().Get("status"),
+			Limit:   limit,
+		})
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	mux.HandleFunc("/api/jobs/runs/active", func(w http.ResponseWriter, r *http.Request) {
+		if runs == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "runs disabled"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, http.StatusOK, runs.ActiveRuns(r.Context()))
+	})
+
+	mux.HandleFunc("/api/jobs/runs/", func(w http.ResponseWriter, r *http.Request) {
+		if runs == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "runs disabled"})
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			idPart := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/jobs/runs/"), "/cancel")
+			id, err := strconv.ParseUint(idPart, 10, 64)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid run id"})
+				return
+			}
+			if err := runs.CancelRun(r.Context(), uint(id)); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		idPart := strings.TrimPrefix(r.URL.Path, "/api/jobs/runs/")
+		id, err := strconv.ParseUint(idPart, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid run id"})
+			return
+		}
+		run, err := runs.GetRun(r.Context(), uint(id))
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		if run == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "run not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, run)
+	})
+
 	webFS := http.FileServer(http.Dir("web"))
 	mux.Handle("/static/", http.StripPrefix("/static/", webFS))
 
@@ -165,6 +778,62 @@ func NewHandler(store Store, sched Scheduler, meta MetaProvider, subs Subscripti
 	return mux
 }
 
+// parseSearchQuery 从查询参数解析 SearchQuery，时间范围使用 RFC3339，解析失败则忽略该字段。
+func parseSearchQuery(r *http.Request) SearchQuery {
+	values := r.URL.Query()
+	q := SearchQuery{Keyword: strings.TrimSpace(values.Get("q"))}
+
+	q.Tags = splitCSVParams(values, "tag", "tags")
+	q.Sources = splitCSVParams(values, "source", "sources")
+
+	if since := values.Get("since"); since != "" {
+		if t, err := time.Parse(time.RFC3339, since); err == nil {
+			q.Since = t
+		}
+	}
+	if until := values.Get("until"); until != "" {
+		if t, err := time.Parse(time.RFC3339, until); err == nil {
+			q.Until = t
+		}
+	}
+
+	q.Limit = 20
+	if l := values.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			q.Limit = v
+		}
+	}
+	if p := values.Get("page"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil && v > 1 {
+			q.Offset = (v - 1) * q.Limit
+		}
+	}
+
+	return q
+}
+
+func splitCSVParams(values map[string][]string, keys ...string) []string {
+	set := make(map[string]struct{})
+	for _, key := range keys {
+		for _, raw := range values[key] {
+			for _, part := range strings.Split(raw, ",") {
+				trimmed := strings.TrimSpace(part)
+				if trimmed != "" {
+					set[trimmed] = struct{}{}
+				}
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(set))
+	for v := range set {
+		out = append(out, v)
+	}
+	return out
+}
+
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
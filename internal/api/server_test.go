@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"remote-radar/internal/model"
 )
@@ -19,7 +20,7 @@ func TestListJobs(t *testing.T) {
 	meta := &stubMetaProvider{}
 	subscriber := &stubSubscriber{}
 
-	h := NewHandler(st, sch, meta, subscriber)
+	h := NewHandler(st, sch, meta, subscriber, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/api/jobs?limit=1&page=1", nil)
 	w := httptest.NewRecorder()
 	h.ServeHTTP(w, req)
@@ -56,7 +57,7 @@ func TestRefresh(t *testing.T) {
 	meta := &stubMetaProvider{}
 	subscriber := &stubSubscriber{}
 
-	h := NewHandler(st, sch, meta, subscriber)
+	h := NewHandler(st, sch, meta, subscriber, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodPost, "/api/refresh", nil)
 	w := httptest.NewRecorder()
 	h.ServeHTTP(w, req)
@@ -72,7 +73,7 @@ func TestRefresh(t *testing.T) {
 func TestCreateSubscription(t *testing.T) {
 	t.Parallel()
 
-	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{})
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodPost, "/api/subscriptions", bytes.NewBufferString(`{"email":"a@b.com","channel":"email","tags":["backend"]}`))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
@@ -83,11 +84,30 @@ func TestCreateSubscription(t *testing.T) {
 	}
 }
 
+func TestCreateSubscriptionPassesFilterExpr(t *testing.T) {
+	t.Parallel()
+
+	sub := &stubSubscriber{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, sub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	body := `{"email":"a@b.com","channel":"email","filter_expr":"tag:backend AND NOT source:acme"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/subscriptions", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if sub.lastReq.FilterExpr != "tag:backend AND NOT source:acme" {
+		t.Fatalf("expected filter_expr to be forwarded, got %+v", sub.lastReq)
+	}
+}
+
 func TestMetaEndpoint(t *testing.T) {
 	t.Parallel()
 
 	meta := &stubMetaProvider{data: MetaResponse{TagCandidates: []string{"backend"}}}
-	h := NewHandler(&stubStore{}, &stubScheduler{}, meta, &stubSubscriber{})
+	h := NewHandler(&stubStore{}, &stubScheduler{}, meta, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/api/meta", nil)
 	w := httptest.NewRecorder()
 	h.ServeHTTP(w, req)
@@ -104,6 +124,697 @@ func TestMetaEndpoint(t *testing.T) {
 	}
 }
 
+func TestSearchJobsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/search?q=go", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when search disabled, got %d", w.Code)
+	}
+}
+
+func TestSearchJobsQueriesService(t *testing.T) {
+	t.Parallel()
+
+	search := &stubSearchService{jobs: []model.Job{{ID: "1", Title: "Remote Go Engineer"}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, search, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/search?q=go&tags=backend,go&limit=10", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if search.lastQuery.Keyword != "go" {
+		t.Fatalf("expected keyword go, got %q", search.lastQuery.Keyword)
+	}
+	if len(search.lastQuery.Tags) != 2 {
+		t.Fatalf("expected 2 tags, got %+v", search.lastQuery.Tags)
+	}
+	var resp []model.Job
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if len(resp) != 1 || resp[0].ID != "1" {
+		t.Fatalf("expected search results passthrough, got %+v", resp)
+	}
+}
+
+func TestUnfreezeSubscriptionDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/subscriptions/1/unfreeze", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when admin disabled, got %d", w.Code)
+	}
+}
+
+func TestUnfreezeSubscriptionCallsService(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/subscriptions/7/unfreeze", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if admin.lastID != 7 {
+		t.Fatalf("expected unfreeze called with id 7, got %d", admin.lastID)
+	}
+}
+
+func TestRetryNotificationsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/retry", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when admin disabled, got %d", w.Code)
+	}
+}
+
+func TestRetryNotificationsCallsService(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/retry", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if admin.retryCalls != 1 {
+		t.Fatalf("expected retry called once, got %d", admin.retryCalls)
+	}
+}
+
+func TestTriggerJobDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/jobs/fetch/run", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when admin disabled, got %d", w.Code)
+	}
+}
+
+func TestTriggerJobCallsServiceWithType(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{jobID: 9}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/jobs/fetch/run", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+	if admin.lastJobType != "fetch" {
+		t.Fatalf("expected job type 'fetch', got %s", admin.lastJobType)
+	}
+}
+
+func TestListJobsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/jobs/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when admin disabled, got %d", w.Code)
+	}
+}
+
+func TestListJobsPassesQueryFilters(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{listJobs: []JobRunSummary{{ID: 1, Type: "llm_classify", Status: "success"}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/jobs/?type=llm_classify&status=success", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if admin.lastListType != "llm_classify" || admin.lastListStatus != "success" {
+		t.Fatalf("expected query filters forwarded, got type=%s status=%s", admin.lastListType, admin.lastListStatus)
+	}
+}
+
+func TestCancelJobCallsServiceWithID(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/jobs/42/cancel", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if admin.cancelID != 42 {
+		t.Fatalf("expected cancel called with id 42, got %d", admin.cancelID)
+	}
+}
+
+func TestRescheduleJobCallsServiceWithID(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/jobs/42/reschedule", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if admin.rescheduleID != 42 {
+		t.Fatalf("expected reschedule called with id 42, got %d", admin.rescheduleID)
+	}
+}
+
+func TestClusterLeaderDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/cluster/leader", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when cluster disabled, got %d", w.Code)
+	}
+}
+
+func TestClusterLeaderReturnsStatus(t *testing.T) {
+	t.Parallel()
+
+	cluster := &stubClusterService{status: ClusterStatus{LeaderID: "node-a", TTL: 10 * time.Second}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, cluster, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/cluster/leader", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got ClusterStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.LeaderID != "node-a" || got.TTL != 10*time.Second {
+		t.Fatalf("unexpected status: %+v", got)
+	}
+}
+
+func TestSchedulesDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/schedules", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when schedules disabled, got %d", w.Code)
+	}
+}
+
+func TestSchedulesListReturnsStatuses(t *testing.T) {
+	t.Parallel()
+
+	schedules := &stubScheduleService{statuses: []ScheduleStatus{{Name: "eleduck", Spec: ScheduleSpec{Interval: "1h", Enabled: true}}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, schedules, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/schedules", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got []ScheduleStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "eleduck" {
+		t.Fatalf("unexpected schedules: %+v", got)
+	}
+}
+
+func TestSchedulesUpdateCallsService(t *testing.T) {
+	t.Parallel()
+
+	schedules := &stubScheduleService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, schedules, nil, nil, nil, nil, nil, nil, nil)
+	body := `{"name":"eleduck","spec":{"interval":"30m","enabled":true}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/schedules", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if schedules.lastName != "eleduck" || schedules.lastSpec.Interval != "30m" {
+		t.Fatalf("unexpected update call: name=%s spec=%+v", schedules.lastName, schedules.lastSpec)
+	}
+}
+
+func TestJobRunsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/runs", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when runs disabled, got %d", w.Code)
+	}
+}
+
+func TestJobRunsListReturnsSummaries(t *testing.T) {
+	t.Parallel()
+
+	runs := &stubRunService{summaries: []RunSummary{{ID: 1, JobName: "eleduck", Status: "success"}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, runs, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/runs?job=eleduck&limit=5", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if runs.lastQuery.JobName != "eleduck" || runs.lastQuery.Limit != 5 {
+		t.Fatalf("unexpected query forwarded: %+v", runs.lastQuery)
+	}
+	var got []RunSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].JobName != "eleduck" {
+		t.Fatalf("unexpected runs: %+v", got)
+	}
+}
+
+func TestJobRunsGetReturnsDetail(t *testing.T) {
+	t.Parallel()
+
+	runs := &stubRunService{detail: &RunDetail{RunSummary: RunSummary{ID: 9, JobName: "eleduck"}, Outcomes: []RunOutcome{{RawJobID: 1, Status: "processed"}}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, runs, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/runs/9", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if runs.lastID != 9 {
+		t.Fatalf("expected GetRun called with id 9, got %d", runs.lastID)
+	}
+	var got RunDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got.Outcomes) != 1 || got.Outcomes[0].RawJobID != 1 {
+		t.Fatalf("unexpected run detail: %+v", got)
+	}
+}
+
+func TestJobRunsGetReturnsNotFound(t *testing.T) {
+	t.Parallel()
+
+	runs := &stubRunService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, runs, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/runs/42", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestJobRunsActiveReturnsList(t *testing.T) {
+	t.Parallel()
+
+	runs := &stubRunService{active: []ActiveRun{{ID: 3, JobName: "eleduck", Phase: "fetching"}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, runs, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/runs/active", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got []ActiveRun
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 3 {
+		t.Fatalf("unexpected active runs: %+v", got)
+	}
+}
+
+func TestJobRunsCancelCallsService(t *testing.T) {
+	t.Parallel()
+
+	runs := &stubRunService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, runs, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/jobs/runs/7/cancel", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if runs.cancelID != 7 {
+		t.Fatalf("expected CancelRun called with id 7, got %d", runs.cancelID)
+	}
+}
+
+func TestNotifierStatusDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/notifier/status", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when notifier disabled, got %d", w.Code)
+	}
+}
+
+func TestNotifierStatusReturnsState(t *testing.T) {
+	t.Parallel()
+
+	notif := &stubNotifierService{status: NotifierStatus{State: "paused", Failures: 5}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, notif, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/notifier/status", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got NotifierStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.State != "paused" || got.Failures != 5 {
+		t.Fatalf("unexpected status: %+v", got)
+	}
+}
+
+func TestNotifierResumeCallsService(t *testing.T) {
+	t.Parallel()
+
+	notif := &stubNotifierService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, notif, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/notifier/resume", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !notif.resumed {
+		t.Fatal("expected Resume to be called")
+	}
+}
+
+func TestWorkersDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/workers", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when queue disabled, got %d", w.Code)
+	}
+}
+
+func TestWorkersReturnsLeases(t *testing.T) {
+	t.Parallel()
+
+	queue := &stubQueueService{workers: []WorkerLease{{WorkerID: "worker-a", LeasedJobs: 3}}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, queue, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/workers", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got []WorkerLease
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].WorkerID != "worker-a" || got[0].LeasedJobs != 3 {
+		t.Fatalf("unexpected workers: %+v", got)
+	}
+}
+
+func TestQueueStatsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/queue/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when queue disabled, got %d", w.Code)
+	}
+}
+
+func TestQueueStatsReturnsCounts(t *testing.T) {
+	t.Parallel()
+
+	queue := &stubQueueService{stats: QueueStats{Pending: 2, Leased: 1, DeadLetter: 1}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, queue, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/queue/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got QueueStats
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Pending != 2 || got.Leased != 1 || got.DeadLetter != 1 {
+		t.Fatalf("unexpected stats: %+v", got)
+	}
+}
+
+func TestCacheStatsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/cache/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when cache disabled, got %d", w.Code)
+	}
+}
+
+func TestCacheStatsReturnsCounts(t *testing.T) {
+	t.Parallel()
+
+	cache := &stubCacheService{stats: CacheStats{Hits: 5, Misses: 2, Evictions: 1}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, cache, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/cache/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got CacheStats
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Hits != 5 || got.Misses != 2 || got.Evictions != 1 {
+		t.Fatalf("unexpected stats: %+v", got)
+	}
+}
+
+func TestArchiveStatsDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/archive/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when archive disabled, got %d", w.Code)
+	}
+}
+
+func TestArchiveStatsReturnsCounts(t *testing.T) {
+	t.Parallel()
+
+	archive := &stubArchiveService{stats: ArchiveStats{PendingRawJobs: 7, PendingJobs: 3}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, archive)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/archive/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got ArchiveStats
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.PendingRawJobs != 7 || got.PendingJobs != 3 {
+		t.Fatalf("unexpected stats: %+v", got)
+	}
+}
+
+func TestLLMUsageDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/llm/usage", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when llm usage disabled, got %d", w.Code)
+	}
+}
+
+func TestLLMUsageReturnsSummary(t *testing.T) {
+	t.Parallel()
+
+	usage := &stubLLMUsageService{summary: LLMUsageSummary{Calls: 4, PromptTokens: 100, CompletionTokens: 50, CostUSD: 0.02}}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, usage, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/llm/usage", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got LLMUsageSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Calls != 4 || got.PromptTokens != 100 || got.CompletionTokens != 50 || got.CostUSD != 0.02 {
+		t.Fatalf("unexpected summary: %+v", got)
+	}
+}
+
+func TestSendTestNotificationCallsService(t *testing.T) {
+	t.Parallel()
+
+	admin := &stubAdminService{}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, admin, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/subscriptions/test/7", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if admin.testID != 7 {
+		t.Fatalf("expected test notification sent for id 7, got %d", admin.testID)
+	}
+}
+
+func TestSendTestNotificationDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/subscriptions/test/7", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when admin disabled, got %d", w.Code)
+	}
+}
+
+func TestFeedDisabledWithoutService(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/feeds/abc123.xml", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when feeds disabled, got %d", w.Code)
+	}
+}
+
+func TestFeedRendersByToken(t *testing.T) {
+	t.Parallel()
+
+	feeds := &stubFeedService{data: []byte("<feed/>")}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, feeds, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/feeds/abc123.xml", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if feeds.lastToken != "abc123" {
+		t.Fatalf("expected token 'abc123', got %s", feeds.lastToken)
+	}
+	if w.Body.String() != "<feed/>" {
+		t.Fatalf("expected feed body written, got %s", w.Body.String())
+	}
+}
+
+func TestFeedReturnsNotFoundForUnknownToken(t *testing.T) {
+	t.Parallel()
+
+	feeds := &stubFeedService{err: context.DeadlineExceeded}
+	h := NewHandler(&stubStore{}, &stubScheduler{}, &stubMetaProvider{}, &stubSubscriber{}, nil, nil, nil, nil, nil, nil, nil, nil, feeds, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/feeds/missing.xml", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
+
 // --- stubs ---
 
 type stubStore struct {
@@ -138,11 +849,13 @@ func (s *stubScheduler) RunOnce(r *http.Request) (int, error) {
 }
 
 type stubSubscriber struct {
-	calls int
+	calls   int
+	lastReq SubscriptionRequest
 }
 
 func (s *stubSubscriber) Create(ctx context.Context, req SubscriptionRequest) error {
 	s.calls++
+	s.lastReq = req
 	return nil
 }
 
@@ -151,3 +864,197 @@ type stubMetaProvider struct {
 }
 
 func (m *stubMetaProvider) Snapshot() MetaResponse { return m.data }
+
+type stubSearchService struct {
+	jobs      []model.Job
+	lastQuery SearchQuery
+}
+
+func (s *stubSearchService) Query(ctx context.Context, q SearchQuery) ([]model.Job, error) {
+	s.lastQuery = q
+	return s.jobs, nil
+}
+
+type stubClusterService struct {
+	status ClusterStatus
+	err    error
+}
+
+func (c *stubClusterService) LeaderStatus(ctx context.Context) (ClusterStatus, error) {
+	return c.status, c.err
+}
+
+type stubNotifierService struct {
+	status    NotifierStatus
+	resumeErr error
+	resumed   bool
+}
+
+func (n *stubNotifierService) Status(ctx context.Context) NotifierStatus {
+	return n.status
+}
+
+func (n *stubNotifierService) Resume(ctx context.Context) error {
+	n.resumed = true
+	return n.resumeErr
+}
+
+type stubQueueService struct {
+	workers []WorkerLease
+	stats   QueueStats
+	err     error
+}
+
+func (q *stubQueueService) ListWorkers(ctx context.Context) ([]WorkerLease, error) {
+	return q.workers, q.err
+}
+
+func (q *stubQueueService) QueueStats(ctx context.Context) (QueueStats, error) {
+	return q.stats, q.err
+}
+
+type stubCacheService struct {
+	stats CacheStats
+	err   error
+}
+
+func (c *stubCacheService) CacheStats(ctx context.Context) (CacheStats, error) {
+	return c.stats, c.err
+}
+
+type stubArchiveService struct {
+	stats ArchiveStats
+	err   error
+}
+
+func (a *stubArchiveService) ArchiveStats(ctx context.Context) (ArchiveStats, error) {
+	return a.stats, a.err
+}
+
+type stubLLMUsageService struct {
+	summary LLMUsageSummary
+	err     error
+}
+
+func (s *stubLLMUsageService) Usage(ctx context.Context) (LLMUsageSummary, error) {
+	return s.summary, s.err
+}
+
+type stubFeedService struct {
+	data      []byte
+	err       error
+	lastToken string
+}
+
+func (s *stubFeedService) Render(ctx context.Context, token string) ([]byte, error) {
+	s.lastToken = token
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.data, nil
+}
+
+type stubScheduleService struct {
+	statuses  []ScheduleStatus
+	listErr   error
+	lastName  string
+	lastSpec  ScheduleSpec
+	updateErr error
+}
+
+func (s *stubScheduleService) ListSchedules(ctx context.Context) []ScheduleStatus {
+	return s.statuses
+}
+
+func (s *stubScheduleService) UpdateSchedule(ctx context.Context, name string, spec ScheduleSpec) error {
+	s.lastName = name
+	s.lastSpec = spec
+	return s.updateErr
+}
+
+type stubRunService struct {
+	summaries  []RunSummary
+	detail     *RunDetail
+	lastQuery  RunQuery
+	lastID     uint
+	listErr    error
+	getErr     error
+	active     []ActiveRun
+	cancelID   uint
+	cancelErr  error
+}
+
+func (s *stubRunService) ListRuns(ctx context.Context, q RunQuery) ([]RunSummary, error) {
+	s.lastQuery = q
+	return s.summaries, s.listErr
+}
+
+func (s *stubRunService) GetRun(ctx context.Context, id uint) (*RunDetail, error) {
+	s.lastID = id
+	return s.detail, s.getErr
+}
+
+func (s *stubRunService) ActiveRuns(ctx context.Context) []ActiveRun {
+	return s.active
+}
+
+func (s *stubRunService) CancelRun(ctx context.Context, id uint) error {
+	s.cancelID = id
+	return s.cancelErr
+}
+
+type stubAdminService struct {
+	lastID          uint
+	err             error
+	retryCalls      int
+	retryErr        error
+	lastJobType     string
+	jobID           uint
+	jobErr          error
+	testID          uint
+	testErr         error
+	listJobs        []JobRunSummary
+	listErr         error
+	lastListType    string
+	lastListStatus  string
+	cancelID        uint
+	cancelErr       error
+	rescheduleID    uint
+	rescheduleErr   error
+}
+
+func (s *stubAdminService) UnfreezeSubscription(ctx context.Context, id uint) error {
+	s.lastID = id
+	return s.err
+}
+
+func (s *stubAdminService) RetryNotifications(ctx context.Context) error {
+	s.retryCalls++
+	return s.retryErr
+}
+
+func (s *stubAdminService) TriggerJob(ctx context.Context, jobType string) (uint, error) {
+	s.lastJobType = jobType
+	return s.jobID, s.jobErr
+}
+
+func (s *stubAdminService) SendTestNotification(ctx context.Context, id uint) error {
+	s.testID = id
+	return s.testErr
+}
+
+func (s *stubAdminService) ListJobs(ctx context.Context, jobType, status string) ([]JobRunSummary, error) {
+	s.lastListType = jobType
+	s.lastListStatus = status
+	return s.listJobs, s.listErr
+}
+
+func (s *stubAdminService) CancelJob(ctx context.Context, id uint) error {
+	s.cancelID = id
+	return s.cancelErr
+}
+
+func (s *stubAdminService) RescheduleJob(ctx context.Context, id uint) error {
+	s.rescheduleID = id
+	return s.rescheduleErr
+}
@@ -0,0 +1,27 @@
+// Package cluster 提供多实例部署下的 leader 选举：单机实现始终持有 leader 身份，
+// Redis 实现基于 SET NX + 租约续约，续约失败时自动让位，避免多个实例同时触发抓取调度。
+package cluster
+
+import (
+	"context"
+	"time"
+)
+
+// Leader 抽象 leader 选举状态，供 Scheduler 判断本实例是否应执行抓取调度。
+type Leader interface {
+	// IsLeader 返回本实例当前是否持有 leader 租约。
+	IsLeader() bool
+	// Status 返回当前 leader 的 ID 与租约剩余时间，供 /api/cluster/leader 暴露。
+	Status(ctx context.Context) (Status, error)
+}
+
+// Runnable 可选地由需要后台续约循环的 Leader 实现，调用方应将其纳入自身的 errgroup 一并启动。
+type Runnable interface {
+	Run(ctx context.Context) error
+}
+
+// Status 描述当前 leader 状态。
+type Status struct {
+	LeaderID string
+	TTL      time.Duration
+}
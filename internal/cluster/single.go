@@ -0,0 +1,24 @@
+package cluster
+
+import "context"
+
+// SingleLeader 是单机部署下的 Leader 实现，始终持有 leader 身份，供未配置 Redis 时使用。
+type SingleLeader struct {
+	id string
+}
+
+// NewSingleLeader 创建 SingleLeader，id 为空时使用 "single"。
+func NewSingleLeader(id string) *SingleLeader {
+	if id == "" {
+		id = "single"
+	}
+	return &SingleLeader{id: id}
+}
+
+// IsLeader 始终返回 true。
+func (l *SingleLeader) IsLeader() bool { return true }
+
+// Status 返回固定的本机 ID，TTL 为 0 表示无租约限制。
+func (l *SingleLeader) Status(ctx context.Context) (Status, error) {
+	return Status{LeaderID: l.id}, nil
+}
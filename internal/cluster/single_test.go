@@ -0,0 +1,36 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSingleLeaderAlwaysLeader(t *testing.T) {
+	t.Parallel()
+
+	l := NewSingleLeader("node-a")
+	if !l.IsLeader() {
+		t.Fatal("expected SingleLeader to always be leader")
+	}
+
+	status, err := l.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status error: %v", err)
+	}
+	if status.LeaderID != "node-a" {
+		t.Fatalf("expected LeaderID=node-a, got %q", status.LeaderID)
+	}
+}
+
+func TestSingleLeaderDefaultsID(t *testing.T) {
+	t.Parallel()
+
+	l := NewSingleLeader("")
+	status, err := l.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status error: %v", err)
+	}
+	if status.LeaderID != "single" {
+		t.Fatalf("expected default LeaderID=single, got %q", status.LeaderID)
+	}
+}
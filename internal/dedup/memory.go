@@ -0,0 +1,43 @@
+package dedup
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore 是 Store 的进程内实现，供未配置 Redis 时的单实例部署或测试使用。
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	now     func() time.Time
+}
+
+// NewMemoryStore 创建 MemoryStore。
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]time.Time), now: time.Now}
+}
+
+// Seen 返回 source+id 是否仍处于未过期的已见状态。
+func (m *MemoryStore) Seen(ctx context.Context, source, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt, ok := m.entries[jobKey(source, id)]
+	if !ok {
+		return false, nil
+	}
+	if m.now().After(expiresAt) {
+		delete(m.entries, jobKey(source, id))
+		return false, nil
+	}
+	return true, nil
+}
+
+// MarkSeen 标记 source+id，ttl 过后视为未见过。
+func (m *MemoryStore) MarkSeen(ctx context.Context, source, id string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[jobKey(source, id)] = m.now().Add(ttl)
+	return nil
+}
@@ -0,0 +1,46 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreMarksAndExpires(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if seen, err := store.Seen(ctx, "eleduck", "1"); err != nil || seen {
+		t.Fatalf("expected unseen before marking, seen=%v err=%v", seen, err)
+	}
+
+	if err := store.MarkSeen(ctx, "eleduck", "1", time.Hour); err != nil {
+		t.Fatalf("MarkSeen error: %v", err)
+	}
+	if seen, err := store.Seen(ctx, "eleduck", "1"); err != nil || !seen {
+		t.Fatalf("expected seen after marking, seen=%v err=%v", seen, err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	store.now = func() time.Time { return now }
+	if seen, err := store.Seen(ctx, "eleduck", "1"); err != nil || seen {
+		t.Fatalf("expected expired entry to be unseen, seen=%v err=%v", seen, err)
+	}
+}
+
+func TestMemoryStoreDistinguishesSourceAndID(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	ctx := context.Background()
+	if err := store.MarkSeen(ctx, "eleduck", "1", time.Hour); err != nil {
+		t.Fatalf("MarkSeen error: %v", err)
+	}
+	if seen, err := store.Seen(ctx, "v2ex", "1"); err != nil || seen {
+		t.Fatalf("expected different source to be unseen, seen=%v err=%v", seen, err)
+	}
+}
@@ -0,0 +1,64 @@
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store 判断某条职位是否已在此前抓取中出现过，用于跨次运行去重，避免重复写库与重复通知。
+type Store interface {
+	// Seen 返回 source+id 对应的职位是否已被标记见过。
+	Seen(ctx context.Context, source, id string) (bool, error)
+	// MarkSeen 标记 source+id 已见过，ttl 过后自动过期。
+	MarkSeen(ctx context.Context, source, id string, ttl time.Duration) error
+}
+
+// Config 配置 Redis 去重存储连接。
+type Config struct {
+	Addr     string `yaml:"addr" json:"addr"`
+	Password string `yaml:"password" json:"password"`
+	DB       int    `yaml:"db" json:"db"`
+}
+
+// RedisStore 基于 Redis 实现去重，key 形如 job:<source>:<id>。
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore 创建 RedisStore。
+func NewRedisStore(cfg Config) *RedisStore {
+	return &RedisStore{client: redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})}
+}
+
+// Seen 查询 source+id 是否已被标记过。
+func (s *RedisStore) Seen(ctx context.Context, source, id string) (bool, error) {
+	n, err := s.client.Exists(ctx, jobKey(source, id)).Result()
+	if err != nil {
+		return false, fmt.Errorf("dedup exists: %w", err)
+	}
+	return n > 0, nil
+}
+
+// MarkSeen 标记 source+id，ttl 过后该标记自动过期。
+func (s *RedisStore) MarkSeen(ctx context.Context, source, id string, ttl time.Duration) error {
+	if err := s.client.Set(ctx, jobKey(source, id), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("dedup set: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭底层 Redis 连接。
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
+
+func jobKey(source, id string) string {
+	return fmt.Sprintf("job:%s:%s", source, id)
+}
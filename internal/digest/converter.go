@@ -0,0 +1,106 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Config 控制 CalibreConverter 的二进制路径、超时时间与定时邮件摘要任务。
+type Config struct {
+	CalibreBinPath    string `yaml:"calibre_bin_path" json:"calibre_bin_path"`
+	TimeoutSeconds    int    `yaml:"timeout_seconds" json:"timeout_seconds"`
+	MailIntervalHours int    `yaml:"mail_interval_hours" json:"mail_interval_hours"`
+	MailFormat        string `yaml:"mail_format" json:"mail_format"`
+}
+
+// Format 表示目标电子书格式。
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatEPUB Format = "epub"
+	FormatMOBI Format = "mobi"
+	FormatDOCX Format = "docx"
+)
+
+// Converter 将 Document 渲染为指定格式并写入 outPath。
+type Converter interface {
+	Convert(ctx context.Context, doc Document, format Format, outPath string) error
+}
+
+const (
+	defaultCalibreBinary  = "ebook-convert"
+	defaultCalibreTimeout = 2 * time.Minute
+)
+
+// CalibreConverter 通过 shell 调用 Calibre 的 ebook-convert 完成格式转换。
+// 输入先渲染为临时 HTML 文件，再交由 ebook-convert 直接写入目标路径，避免将输出全量缓冲在内存中。
+type CalibreConverter struct {
+	binPath string
+	timeout time.Duration
+	newCmd  func(ctx context.Context, name string, arg ...string) *exec.Cmd
+}
+
+// NewCalibreConverterFromConfig 根据 Config 创建 CalibreConverter。
+func NewCalibreConverterFromConfig(cfg Config) *CalibreConverter {
+	var timeout time.Duration
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	return NewCalibreConverter(cfg.CalibreBinPath, timeout)
+}
+
+// NewCalibreConverter 创建 CalibreConverter，binPath 为空时使用 PATH 中的 ebook-convert，timeout<=0 时使用默认 2 分钟。
+func NewCalibreConverter(binPath string, timeout time.Duration) *CalibreConverter {
+	if binPath == "" {
+		binPath = defaultCalibreBinary
+	}
+	if timeout <= 0 {
+		timeout = defaultCalibreTimeout
+	}
+	return &CalibreConverter{
+		binPath: binPath,
+		timeout: timeout,
+		newCmd:  exec.CommandContext,
+	}
+}
+
+// Convert 渲染 doc 为临时 HTML 后调用 ebook-convert 输出到 outPath，失败时清理临时文件与残留输出。
+func (c *CalibreConverter) Convert(ctx context.Context, doc Document, format Format, outPath string) error {
+	if format == "" {
+		return fmt.Errorf("convert: format required")
+	}
+	if outPath == "" {
+		return fmt.Errorf("convert: outPath required")
+	}
+
+	tmp, err := os.CreateTemp("", "remote-radar-digest-*.html")
+	if err != nil {
+		return fmt.Errorf("create temp input: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := renderHTML(tmp, doc); err != nil {
+		tmp.Close()
+		return fmt.Errorf("render html: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := c.newCmd(ctx, c.binPath, tmpPath, outPath)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		os.Remove(outPath)
+		return fmt.Errorf("ebook-convert: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
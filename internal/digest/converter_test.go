@@ -0,0 +1,65 @@
+package digest
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCalibreConverterWritesOutputAndCleansUpInput(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), "digest.epub")
+	c := NewCalibreConverter("", time.Second)
+	var inputPath string
+	c.newCmd = func(ctx context.Context, name string, arg ...string) *exec.Cmd {
+		inputPath = arg[0]
+		return exec.CommandContext(ctx, "cp", arg...)
+	}
+
+	doc := Document{Title: "Weekly Digest"}
+	if err := c.Convert(context.Background(), doc, FormatEPUB, outPath); err != nil {
+		t.Fatalf("Convert error: %v", err)
+	}
+
+	if _, err := os.Stat(outPath); err != nil {
+		t.Fatalf("expected output file to exist: %v", err)
+	}
+	if _, err := os.Stat(inputPath); !os.IsNotExist(err) {
+		t.Fatalf("expected temp input file to be removed, stat err=%v", err)
+	}
+}
+
+func TestCalibreConverterCleansUpOutputOnFailure(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), "digest.epub")
+	c := NewCalibreConverter("", time.Second)
+	c.newCmd = func(ctx context.Context, name string, arg ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "false")
+	}
+
+	doc := Document{Title: "Weekly Digest"}
+	if err := c.Convert(context.Background(), doc, FormatEPUB, outPath); err == nil {
+		t.Fatalf("expected error when ebook-convert fails")
+	}
+
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Fatalf("expected output file to be removed after failure, stat err=%v", err)
+	}
+}
+
+func TestCalibreConverterRequiresFormatAndOutPath(t *testing.T) {
+	t.Parallel()
+
+	c := NewCalibreConverter("", time.Second)
+	if err := c.Convert(context.Background(), Document{}, "", "out.epub"); err == nil {
+		t.Fatalf("expected error when format missing")
+	}
+	if err := c.Convert(context.Background(), Document{}, FormatEPUB, ""); err == nil {
+		t.Fatalf("expected error when outPath missing")
+	}
+}
@@ -0,0 +1,67 @@
+package digest
+
+import (
+	"sort"
+
+	"remote-radar/internal/model"
+)
+
+// Section 表示摘要文档中按标签分组的一组职位。
+type Section struct {
+	Tag  string
+	Jobs []model.Job
+}
+
+// Document 是待渲染的摘要文档，按标签分组、组内按发布时间升序排列。
+type Document struct {
+	Title    string
+	Sections []Section
+}
+
+// BuildDocument 将职位列表按标签分组并排序，生成可供 Converter 渲染的 Document。
+// 未携带任何标签的职位归入 "未分类" 分组。
+func BuildDocument(title string, jobs []model.Job) Document {
+	grouped := make(map[string][]model.Job)
+	for _, job := range jobs {
+		tags := tagNames(job)
+		if len(tags) == 0 {
+			tags = []string{"未分类"}
+		}
+		for _, tag := range tags {
+			grouped[tag] = append(grouped[tag], job)
+		}
+	}
+
+	tagList := make([]string, 0, len(grouped))
+	for tag := range grouped {
+		tagList = append(tagList, tag)
+	}
+	sort.Strings(tagList)
+
+	doc := Document{Title: title}
+	for _, tag := range tagList {
+		section := grouped[tag]
+		sort.Slice(section, func(i, j int) bool {
+			return section[i].PublishedAt.Before(section[j].PublishedAt)
+		})
+		doc.Sections = append(doc.Sections, Section{Tag: tag, Jobs: section})
+	}
+	return doc
+}
+
+func tagNames(job model.Job) []string {
+	tags := make([]string, 0, len(job.NormalizedTags))
+	for tag, v := range job.NormalizedTags {
+		if b, ok := v.(bool); ok && !b {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > 0 {
+		return tags
+	}
+	for tag := range job.Tags {
+		tags = append(tags, tag)
+	}
+	return tags
+}
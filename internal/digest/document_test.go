@@ -0,0 +1,47 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+func TestBuildDocumentGroupsByTagAndOrdersByPublishedAt(t *testing.T) {
+	t.Parallel()
+
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	jobs := []model.Job{
+		{ID: "2", Title: "Newer Backend", PublishedAt: newer, NormalizedTags: datatypes.JSONMap{"backend": true}},
+		{ID: "1", Title: "Older Backend", PublishedAt: older, NormalizedTags: datatypes.JSONMap{"backend": true}},
+		{ID: "3", Title: "Design Role", PublishedAt: older, NormalizedTags: datatypes.JSONMap{"design": true}},
+	}
+
+	doc := BuildDocument("Weekly Digest", jobs)
+
+	if len(doc.Sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(doc.Sections))
+	}
+	if doc.Sections[0].Tag != "backend" {
+		t.Fatalf("expected backend section first (alphabetical), got %s", doc.Sections[0].Tag)
+	}
+	backend := doc.Sections[0].Jobs
+	if len(backend) != 2 || backend[0].ID != "1" || backend[1].ID != "2" {
+		t.Fatalf("expected backend jobs ordered oldest first, got %+v", backend)
+	}
+}
+
+func TestBuildDocumentUncategorizedFallback(t *testing.T) {
+	t.Parallel()
+
+	jobs := []model.Job{{ID: "1", Title: "No Tags"}}
+	doc := BuildDocument("Digest", jobs)
+
+	if len(doc.Sections) != 1 || doc.Sections[0].Tag != "未分类" {
+		t.Fatalf("expected fallback section, got %+v", doc.Sections)
+	}
+}
@@ -0,0 +1,120 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+const emailDigestChannel = "email_digest"
+
+// SubscriptionStore 提供邮件摘要所需的订阅读取能力。
+type SubscriptionStore interface {
+	ListSubscriptions(ctx context.Context) ([]model.Subscription, error)
+}
+
+// EmailSender 抽象邮件发送接口，便于测试替换，形状与 notifier.EmailSender 一致。
+type EmailSender interface {
+	Send(ctx context.Context, msg EmailAttachmentMessage) error
+}
+
+// EmailAttachmentMessage 表示携带附件的邮件，字段与 notifier.EmailMessage/EmailAttachment 对齐。
+type EmailAttachmentMessage struct {
+	From        string
+	To          []string
+	Subject     string
+	Body        string
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// MailJob 定期生成摘要文件并发送给 channel=email_digest 的订阅者。
+type MailJob struct {
+	service  *Service
+	subs     SubscriptionStore
+	sender   EmailSender
+	from     string
+	format   Format
+	interval time.Duration
+}
+
+// NewMailJob 创建 MailJob，format 决定附件格式，interval 为摘要覆盖的时间窗口（如 7 天）。
+func NewMailJob(service *Service, subs SubscriptionStore, sender EmailSender, from string, format Format, interval time.Duration) *MailJob {
+	if interval <= 0 {
+		interval = 7 * 24 * time.Hour
+	}
+	return &MailJob{service: service, subs: subs, sender: sender, from: from, format: format, interval: interval}
+}
+
+// Run 生成一份摘要文件并发送给所有 email_digest 渠道的订阅者，完成后清理临时文件。
+func (j *MailJob) Run(ctx context.Context, now time.Time) error {
+	subs, err := j.subs.ListSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("list subscriptions: %w", err)
+	}
+
+	recipients := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		if strings.EqualFold(strings.TrimSpace(sub.Channel), emailDigestChannel) {
+			recipients = append(recipients, sub.Email)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	out, err := os.CreateTemp("", fmt.Sprintf("remote-radar-digest-*.%s", j.format))
+	if err != nil {
+		return fmt.Errorf("create temp output: %w", err)
+	}
+	outPath := out.Name()
+	out.Close()
+	defer os.Remove(outPath)
+
+	since := now.Add(-j.interval)
+	if err := j.service.Generate(ctx, since, j.format, outPath); err != nil {
+		return fmt.Errorf("generate digest: %w", err)
+	}
+
+	content, err := os.ReadFile(outPath)
+	if err != nil {
+		return fmt.Errorf("read generated digest: %w", err)
+	}
+
+	filename := fmt.Sprintf("remote-radar-digest-%s.%s", now.Format("2006-01-02"), j.format)
+	for _, email := range recipients {
+		msg := EmailAttachmentMessage{
+			From:        j.from,
+			To:          []string{email},
+			Subject:     "Remote Radar Digest",
+			Body:        "Your periodic remote jobs digest is attached.",
+			Filename:    filename,
+			ContentType: contentTypeFor(j.format),
+			Content:     content,
+		}
+		if err := j.sender.Send(ctx, msg); err != nil {
+			return fmt.Errorf("send digest to %s: %w", email, err)
+		}
+	}
+	return nil
+}
+
+func contentTypeFor(format Format) string {
+	switch format {
+	case FormatPDF:
+		return "application/pdf"
+	case FormatEPUB:
+		return "application/epub+zip"
+	case FormatMOBI:
+		return "application/x-mobipocket-ebook"
+	case FormatDOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,73 @@
+package digest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestMailJobSendsOnlyToEmailDigestSubscribers(t *testing.T) {
+	t.Parallel()
+
+	subs := &stubSubscriptionStore{subs: []model.Subscription{
+		{Email: "a@example.com", Channel: "email_digest"},
+		{Email: "b@example.com", Channel: "email"},
+	}}
+	sender := &stubEmailSender{}
+	svc := NewService(&stubJobStore{}, &stubConverter{})
+
+	job := NewMailJob(svc, subs, sender, "digest@example.com", FormatEPUB, 7*24*time.Hour)
+	if err := job.Run(context.Background(), time.Now()); err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+
+	if sender.calls != 1 {
+		t.Fatalf("expected exactly 1 email sent, got %d", sender.calls)
+	}
+	if sender.lastMsg.To[0] != "a@example.com" {
+		t.Fatalf("expected email sent to email_digest subscriber, got %+v", sender.lastMsg.To)
+	}
+}
+
+func TestMailJobSkipsWhenNoDigestSubscribers(t *testing.T) {
+	t.Parallel()
+
+	subs := &stubSubscriptionStore{subs: []model.Subscription{{Email: "a@example.com", Channel: "email"}}}
+	sender := &stubEmailSender{}
+	svc := NewService(&stubJobStore{}, &stubConverter{})
+
+	job := NewMailJob(svc, subs, sender, "digest@example.com", FormatEPUB, 7*24*time.Hour)
+	if err := job.Run(context.Background(), time.Now()); err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+	if sender.calls != 0 {
+		t.Fatalf("expected no emails sent, got %d", sender.calls)
+	}
+}
+
+// --- stubs ---
+
+type stubSubscriptionStore struct {
+	subs []model.Subscription
+	err  error
+}
+
+func (s *stubSubscriptionStore) ListSubscriptions(ctx context.Context) ([]model.Subscription, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.subs, nil
+}
+
+type stubEmailSender struct {
+	calls   int
+	lastMsg EmailAttachmentMessage
+}
+
+func (s *stubEmailSender) Send(ctx context.Context, msg EmailAttachmentMessage) error {
+	s.calls++
+	s.lastMsg = msg
+	return nil
+}
@@ -0,0 +1,41 @@
+package digest
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// renderHTML 将 Document 渲染为简单的 HTML，作为 ebook-convert 的输入格式。
+func renderHTML(w io.Writer, doc Document) error {
+	if _, err := fmt.Fprintf(w, "<html><head><meta charset=\"utf-8\"><title>%s</title></head><body>\n", html.EscapeString(doc.Title)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "<h1>%s</h1>\n", html.EscapeString(doc.Title)); err != nil {
+		return err
+	}
+
+	for _, section := range doc.Sections {
+		if _, err := fmt.Fprintf(w, "<h2>%s</h2>\n<ul>\n", html.EscapeString(section.Tag)); err != nil {
+			return err
+		}
+		for _, job := range section.Jobs {
+			line := fmt.Sprintf(
+				"<li><a href=\"%s\">%s</a> (%s) — %s</li>\n",
+				html.EscapeString(job.URL),
+				html.EscapeString(job.Title),
+				html.EscapeString(job.Source),
+				html.EscapeString(job.Summary),
+			)
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "</ul>\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "</body></html>\n")
+	return err
+}
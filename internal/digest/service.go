@@ -0,0 +1,41 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// JobStore 提供摘要所需的职位读取能力。
+type JobStore interface {
+	ListJobsSince(ctx context.Context, since time.Time) ([]model.Job, error)
+}
+
+// Service 组合职位读取与格式转换，生成摘要文件。
+type Service struct {
+	store     JobStore
+	converter Converter
+}
+
+// NewService 创建 Service。
+func NewService(store JobStore, converter Converter) *Service {
+	return &Service{store: store, converter: converter}
+}
+
+// Generate 拉取 since 之后的职位，按标签分组生成文档，并转换为 format 格式写入 outPath。
+func (s *Service) Generate(ctx context.Context, since time.Time, format Format, outPath string) error {
+	jobs, err := s.store.ListJobsSince(ctx, since)
+	if err != nil {
+		return fmt.Errorf("list jobs since %s: %w", since.Format(time.RFC3339), err)
+	}
+
+	title := fmt.Sprintf("Remote Radar Digest since %s", since.Format("2006-01-02"))
+	doc := BuildDocument(title, jobs)
+
+	if err := s.converter.Convert(ctx, doc, format, outPath); err != nil {
+		return fmt.Errorf("convert digest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestServiceGeneratePassesJobsToConverter(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &stubJobStore{jobs: []model.Job{{ID: "1", Title: "Remote Go"}}}
+	converter := &stubConverter{}
+
+	svc := NewService(store, converter)
+	if err := svc.Generate(context.Background(), since, FormatEPUB, "out.epub"); err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+
+	if store.lastSince != since {
+		t.Fatalf("expected store queried with since=%v, got %v", since, store.lastSince)
+	}
+	if converter.lastFormat != FormatEPUB || converter.lastOutPath != "out.epub" {
+		t.Fatalf("unexpected converter args: %+v", converter)
+	}
+	if len(converter.lastDoc.Sections) != 1 {
+		t.Fatalf("expected 1 section passed to converter, got %+v", converter.lastDoc)
+	}
+}
+
+func TestServiceGeneratePropagatesStoreError(t *testing.T) {
+	t.Parallel()
+
+	store := &stubJobStore{err: errors.New("boom")}
+	svc := NewService(store, &stubConverter{})
+
+	if err := svc.Generate(context.Background(), time.Now(), FormatEPUB, "out.epub"); err == nil {
+		t.Fatalf("expected error when store fails")
+	}
+}
+
+// --- stubs ---
+
+type stubJobStore struct {
+	jobs      []model.Job
+	err       error
+	lastSince time.Time
+}
+
+func (s *stubJobStore) ListJobsSince(ctx context.Context, since time.Time) ([]model.Job, error) {
+	s.lastSince = since
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.jobs, nil
+}
+
+type stubConverter struct {
+	lastDoc     Document
+	lastFormat  Format
+	lastOutPath string
+	err         error
+}
+
+func (c *stubConverter) Convert(ctx context.Context, doc Document, format Format, outPath string) error {
+	c.lastDoc = doc
+	c.lastFormat = format
+	c.lastOutPath = outPath
+	return c.err
+}
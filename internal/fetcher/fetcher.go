@@ -2,36 +2,53 @@ package fetcher
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"remote-radar/internal/dedup"
+	"remote-radar/internal/filter"
 	"remote-radar/internal/model"
 
 	"golang.org/x/net/html"
+	"golang.org/x/sync/errgroup"
 	"gorm.io/datatypes"
 )
 
 // Config 定义抓取配置。
 type Config struct {
-	MaxAgeDays    int      `yaml:"max_age_days" json:"max_age_days"`
-	MaxPages      int      `yaml:"max_pages" json:"max_pages"`
-	Interval      string   `yaml:"interval" json:"interval"`
-	CategoryPaths []string `yaml:"category_paths" json:"category_paths"`
+	MaxAgeDays     int             `yaml:"max_age_days" json:"max_age_days"`
+	MaxPages       int             `yaml:"max_pages" json:"max_pages"`
+	MaxRetries     int             `yaml:"max_retries" json:"max_retries"`
+	Concurrency    int             `yaml:"concurrency" json:"concurrency"`
+	RequestTimeout time.Duration   `yaml:"request_timeout" json:"request_timeout"`
+	Interval       string          `yaml:"interval" json:"interval"`
+	CategoryPaths  []string        `yaml:"category_paths" json:"category_paths"`
+	Sources        []SourceConfig  `yaml:"sources" json:"sources"`
+	Filter         filter.Config   `yaml:"filter" json:"filter"`
+	Dedup          dedup.Config    `yaml:"dedup" json:"dedup"`
+	RateLimit      RateLimitConfig `yaml:"rate_limit" json:"rate_limit"`
+	ArchiveAfter   string          `yaml:"archive_after" json:"archive_after"`
 }
 
+const (
+	defaultConcurrency    = 1
+	defaultRequestTimeout = 15 * time.Second
+)
+
 // JobFetcher 抓取统一接口。
 type JobFetcher interface {
 	Fetch(ctx context.Context) ([]model.Job, error)
 }
 
-// EleduckFetcher 抓取电鸭职位列表。
+// EleduckFetcher 抓取基于 Next.js __NEXT_DATA__ 的职位列表，默认解析电鸭站点。
 type EleduckFetcher struct {
 	baseURL       string
 	categoryPaths []string
@@ -39,6 +56,30 @@ type EleduckFetcher struct {
 	cfg           Config
 	now           func() time.Time
 	logger        *log.Logger
+	parser        PostParser
+	source        string
+	sensitive     *filter.Service
+	dedup         dedup.Store
+	health        SourceHealthStore
+	maxRetries    int
+}
+
+// WithSensitiveFilter 设置职位标题/摘要敏感词过滤器，命中的职位会在抓取阶段被直接丢弃。
+func (e *EleduckFetcher) WithSensitiveFilter(svc *filter.Service) *EleduckFetcher {
+	e.sensitive = svc
+	return e
+}
+
+// WithDedupStore 设置跨次抓取去重存储，命中的职位不会再次进入 jobs 结果，避免重复写库与重复通知。
+func (e *EleduckFetcher) WithDedupStore(store dedup.Store) *EleduckFetcher {
+	e.dedup = store
+	return e
+}
+
+// WithHealthStore 设置分类健康状态存储，持续失败的分类会在 NextUpdate 到期前被跳过。
+func (e *EleduckFetcher) WithHealthStore(store SourceHealthStore) *EleduckFetcher {
+	e.health = store
+	return e
 }
 
 // NewEleduckFetcher 创建电鸭抓取器，baseURL 形如 https://eleduck.com。
@@ -52,6 +93,15 @@ func NewEleduckFetcher(baseURL string, cfg Config, client *http.Client) *Eleduck
 	if cfg.MaxAgeDays <= 0 {
 		cfg.MaxAgeDays = 30
 	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = defaultRequestTimeout
+	}
 	categoryPaths := normalizeCategoryPaths(cfg.CategoryPaths)
 	return &EleduckFetcher{
 		baseURL:       strings.TrimSuffix(baseURL, "/"),
@@ -60,127 +110,307 @@ func NewEleduckFetcher(baseURL string, cfg Config, client *http.Client) *Eleduck
 		cfg:           cfg,
 		now:           time.Now,
 		logger:        log.New(os.Stdout, "[fetcher] ", log.LstdFlags),
+		parser:        eleduckParser{},
+		source:        "eleduck",
+		maxRetries:    cfg.MaxRetries,
 	}
 }
 
-// Fetch 抓取最新职位列表，按配置分页与时间窗口限制。
+// orderedJob 携带排序所需的位置信息，使并发抓取的结果仍可还原为分类抓取时原本的顺序。
+type orderedJob struct {
+	job           model.Job
+	categoryIndex int
+	page          int
+	position      int
+}
+
+// Fetch 并发抓取各分类的最新职位列表，按配置分页、时间窗口与并发度限制。
+// 各分类互不阻塞：某一分类的慢请求或持续失败只会影响自身，不会拖慢其余分类。
 func (e *EleduckFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
 	cutoff := e.now().AddDate(0, 0, -e.cfg.MaxAgeDays)
 	cutoffText := cutoff.Format(time.RFC3339)
 
-	jobs := make([]model.Job, 0)
-	seen := make(map[string]struct{})
+	source := e.source
+	if source == "" {
+		source = "eleduck"
+	}
 
-	e.logf("start fetch: base=%s categories=%s max_pages=%d max_age_days=%d cutoff=%s", e.baseURL, strings.Join(e.categoryPaths, ","), e.cfg.MaxPages, e.cfg.MaxAgeDays, cutoffText)
+	concurrency := e.cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
 
-	for _, category := range e.categoryPaths {
-		stopCategory := false
-		for page := 1; page <= e.cfg.MaxPages; page++ {
-			pageURL, err := e.buildPageURL(category, page)
-			if err != nil {
-				return nil, fmt.Errorf("build url: %w", err)
-			}
-			e.logf("category=%s page=%d url=%s", category, page, pageURL)
+	start := e.now()
+	e.logf("start fetch: base=%s categories=%s max_pages=%d max_age_days=%d concurrency=%d cutoff=%s", e.baseURL, strings.Join(e.categoryPaths, ","), e.cfg.MaxPages, e.cfg.MaxAgeDays, concurrency, cutoffText)
 
-			req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
-			if err != nil {
-				return nil, fmt.Errorf("new request: %w", err)
-			}
+	results := make([][]orderedJob, len(e.categoryPaths))
+	var nextWorkerID atomic.Int32
 
-			resp, err := e.client.Do(req)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for categoryIndex, category := range e.categoryPaths {
+		categoryIndex, category := categoryIndex, category
+		g.Go(func() error {
+			workerID := int(nextWorkerID.Add(1))
+			jobs, err := e.fetchCategory(gctx, workerID, categoryIndex, category, source, cutoff, cutoffText)
 			if err != nil {
-				return nil, fmt.Errorf("http get: %w", err)
-			}
-			if resp.Body != nil {
-				defer resp.Body.Close()
-			}
-			if resp.StatusCode != http.StatusOK {
-				return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+				return err
 			}
+			results[categoryIndex] = jobs
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
 
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("read body: %w", err)
+	merged := make([]orderedJob, 0)
+	for _, jobs := range results {
+		merged = append(merged, jobs...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.categoryIndex != b.categoryIndex {
+			return a.categoryIndex < b.categoryIndex
+		}
+		if a.page != b.page {
+			return a.page < b.page
+		}
+		return a.position < b.position
+	})
+
+	jobs := make([]model.Job, 0, len(merged))
+	seen := make(map[string]struct{}, len(merged))
+	for _, oj := range merged {
+		if oj.job.ID != "" {
+			if _, exists := seen[oj.job.ID]; exists {
+				continue
 			}
+			seen[oj.job.ID] = struct{}{}
+		}
+		jobs = append(jobs, oj.job)
+	}
 
-			nextJSON, err := extractNextData(string(body))
-			if err != nil {
-				return nil, fmt.Errorf("extract __NEXT_DATA__: %w", err)
+	e.logf("fetch done total_jobs=%d elapsed_ms=%d", len(jobs), e.now().Sub(start).Milliseconds())
+
+	return jobs, nil
+}
+
+// fetchCategory 抓取单个分类的全部分页，workerID 仅用于区分并发抓取时的日志来源，
+// 不代表真实的运行时 goroutine ID。
+func (e *EleduckFetcher) fetchCategory(ctx context.Context, workerID, categoryIndex int, category, source string, cutoff time.Time, cutoffText string) ([]orderedJob, error) {
+	parser := e.parser
+	if parser == nil {
+		parser = eleduckParser{}
+	}
+
+	start := e.now()
+	if e.health != nil {
+		health, herr := e.health.Get(ctx, source, category)
+		if herr != nil {
+			return nil, fmt.Errorf("get source health: %w", herr)
+		}
+		if !health.NextUpdate.IsZero() && health.NextUpdate.After(e.now()) {
+			e.logStage(workerID, start, "category=%s skip_unhealthy errors=%d next_update=%s", category, health.Errors, health.NextUpdate.Format(time.RFC3339))
+			return nil, nil
+		}
+	}
+
+	jobs := make([]orderedJob, 0)
+	seen := make(map[string]struct{})
+	stopCategory := false
+	categoryFailed := false
+
+	for page := 1; page <= e.cfg.MaxPages; page++ {
+		pageURL, err := e.buildPageURL(category, page)
+		if err != nil {
+			return nil, fmt.Errorf("build url: %w", err)
+		}
+		e.logStage(workerID, start, "category=%s page=%d url=%s", category, page, pageURL)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("new request: %w", err)
+		}
+
+		resp, err := e.fetchWithDeadline(ctx, req)
+		if err != nil {
+			e.recordCategoryFailure(ctx, source, category, fmt.Errorf("http get: %w", err))
+			categoryFailed = true
+			break
+		}
+		if resp.Body != nil {
+			defer resp.Body.Close()
+		}
+		if resp.StatusCode != http.StatusOK {
+			e.recordCategoryFailure(ctx, source, category, fmt.Errorf("unexpected status %d", resp.StatusCode))
+			categoryFailed = true
+			break
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read body: %w", err)
+		}
+
+		nextJSON, err := extractNextData(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("extract __NEXT_DATA__: %w", err)
+		}
+
+		posts, err := parser.ParsePosts(nextJSON)
+		if err != nil {
+			return nil, fmt.Errorf("parse posts: %w", err)
+		}
+		e.logStage(workerID, start, "category=%s page=%d parsed_posts=%d", category, page, len(posts))
+
+		pageAccepted := 0
+		for position, p := range posts {
+			if p.PublishedAt == "" {
+				continue // 缺少发布时间，跳过
 			}
 
-			posts, err := parseEleduckPosts(nextJSON)
-			if err != nil {
-				return nil, fmt.Errorf("parse posts: %w", err)
+			publishedAt, err := time.Parse(time.RFC3339, p.PublishedAt)
+			if err != nil || publishedAt.IsZero() {
+				continue // 时间格式异常，跳过
 			}
-			e.logf("category=%s page=%d parsed_posts=%d", category, page, len(posts))
 
-			pageAccepted := 0
-			for _, p := range posts {
-				publishedAtText := pickPublishedAt(p)
-				if publishedAtText == "" {
-					continue // 缺少发布时间，跳过
-				}
+			if publishedAt.Before(cutoff) {
+				e.logStage(workerID, start, "category=%s page=%d reached_cutoff job_id=%s published_at=%s cutoff=%s", category, page, p.ID, publishedAt.Format(time.RFC3339), cutoffText)
+				stopCategory = true
+				break
+			}
 
-				publishedAt, err := time.Parse(time.RFC3339, publishedAtText)
-				if err != nil || publishedAt.IsZero() {
-					continue // 时间格式异常，跳过
+			if p.ID != "" {
+				if _, exists := seen[p.ID]; exists {
+					e.logStage(workerID, start, "category=%s page=%d skip_duplicate job_id=%s", category, page, p.ID)
+					continue
 				}
+				seen[p.ID] = struct{}{}
+			}
 
-				if publishedAt.Before(cutoff) {
-					jobID := normalizeID(p.ID)
-					e.logf("category=%s page=%d reached_cutoff job_id=%s published_at=%s cutoff=%s", category, page, jobID, publishedAt.Format(time.RFC3339), cutoffText)
-					stopCategory = true
-					break
+			if e.dedup != nil && p.ID != "" {
+				seenBefore, err := e.dedup.Seen(ctx, source, p.ID)
+				if err != nil {
+					return nil, fmt.Errorf("dedup check: %w", err)
 				}
-
-				if !hasRemoteTag(p.Tags) {
+				if seenBefore {
+					e.logStage(workerID, start, "category=%s page=%d skip_dedup job_id=%s", category, page, p.ID)
 					continue
 				}
+			}
 
-				jobID := normalizeID(p.ID)
-				if jobID != "" {
-					if _, exists := seen[jobID]; exists {
-						e.logf("category=%s page=%d skip_duplicate job_id=%s", category, page, jobID)
-						continue
-					}
-					seen[jobID] = struct{}{}
-				}
+			if matched, keyword := e.sensitive.MatchTitle(p.Title); matched {
+				e.logStage(workerID, start, "category=%s page=%d skip_sensitive_title job_id=%s keyword=%q", category, page, p.ID, keyword)
+				continue
+			}
+			if matched, keyword := e.sensitive.MatchContent(p.Summary); matched {
+				e.logStage(workerID, start, "category=%s page=%d skip_sensitive_summary job_id=%s keyword=%q", category, page, p.ID, keyword)
+				continue
+			}
 
-				jobTitle := p.Title
-				if jobTitle == "" {
-					jobTitle = p.FullTitle
-				}
-				jobURL := p.URL
-				if jobURL == "" && jobID != "" {
-					jobURL = "/posts/" + jobID
-				}
+			jobURL := p.URL
+			if jobURL == "" && p.ID != "" {
+				jobURL = "/posts/" + p.ID
+			}
 
-				job := model.Job{
-					ID:            jobID,
-					Title:         jobTitle,
-					Summary:       pickSummary(p),
-					PublishedAt:   publishedAt,
-					Source:        "eleduck",
-					URL:           e.fullURL(jobURL),
-					Tags:          toTagMap(p.Tags),
-					RawAttributes: toRawAttributes(p),
-				}
-				jobs = append(jobs, job)
-				pageAccepted++
+			job := model.Job{
+				ID:            p.ID,
+				Title:         p.Title,
+				Summary:       p.Summary,
+				PublishedAt:   publishedAt,
+				Source:        source,
+				URL:           e.fullURL(jobURL),
+				Tags:          toTagMap(p.Tags),
+				RawAttributes: p.Raw,
 			}
+			jobs = append(jobs, orderedJob{job: job, categoryIndex: categoryIndex, page: page, position: position})
+			pageAccepted++
 
-			e.logf("category=%s page=%d accepted=%d cumulative=%d", category, page, pageAccepted, len(jobs))
-			if stopCategory {
-				break
+			if e.dedup != nil && p.ID != "" {
+				ttl := time.Duration(e.cfg.MaxAgeDays) * 2 * 24 * time.Hour
+				if err := e.dedup.MarkSeen(ctx, source, p.ID, ttl); err != nil {
+					e.logStage(workerID, start, "category=%s page=%d dedup_mark_failed job_id=%s err=%v", category, page, p.ID, err)
+				}
 			}
 		}
+
+		e.logStage(workerID, start, "category=%s page=%d accepted=%d cumulative=%d", category, page, pageAccepted, len(jobs))
+		if stopCategory {
+			break
+		}
 	}
 
-	e.logf("fetch done total_jobs=%d", len(jobs))
+	if !categoryFailed && e.health != nil {
+		if err := e.health.Set(ctx, source, category, SourceHealth{}); err != nil {
+			e.logStage(workerID, start, "category=%s reset_health_failed err=%v", category, err)
+		}
+	}
 
 	return jobs, nil
 }
 
+// fetchWithDeadline 对单次请求施加 Config.RequestTimeout 超时。每次调用都会创建一个新的
+// cancelCh：一旦超时或外层 ctx 被取消，select 立即返回，迟到的响应会在 cancelCh 关闭后被
+// 后台 goroutine 丢弃，不会污染下一次请求的结果（类似 netstack 的 deadline 处理方式）。
+func (e *EleduckFetcher) fetchWithDeadline(ctx context.Context, req *http.Request) (*http.Response, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, e.requestTimeout())
+	defer cancel()
+
+	cancelCh := make(chan struct{})
+	defer close(cancelCh)
+
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	resultCh := make(chan result)
+
+	go func() {
+		resp, err := doWithRetry(reqCtx, e.client, req.WithContext(reqCtx), e.maxRetries)
+		select {
+		case resultCh <- result{resp: resp, err: err}:
+		case <-cancelCh:
+			if resp != nil && resp.Body != nil {
+				resp.Body.Close()
+			}
+		}
+	}()
+
+	select {
+	case res := <-resultCh:
+		return res.resp, res.err
+	case <-reqCtx.Done():
+		return nil, reqCtx.Err()
+	}
+}
+
+func (e *EleduckFetcher) requestTimeout() time.Duration {
+	if e.cfg.RequestTimeout > 0 {
+		return e.cfg.RequestTimeout
+	}
+	return defaultRequestTimeout
+}
+
+// recordCategoryFailure 在一个分类的所有重试耗尽后记录健康状态：错误计数加一，
+// NextUpdate 设置为按错误次数指数退避后的时间点，使后续 Fetch 暂时跳过该分类。
+func (e *EleduckFetcher) recordCategoryFailure(ctx context.Context, source, category string, cause error) {
+	errors := 1
+	if e.health != nil {
+		if prev, err := e.health.Get(ctx, source, category); err == nil {
+			errors = prev.Errors + 1
+		}
+	}
+	nextUpdate := e.now().Add(backoffForErrors(errors))
+	e.logf("category=%s errors=%d next_update=%s cause=%v", category, errors, nextUpdate.Format(time.RFC3339), cause)
+
+	if e.health != nil {
+		if err := e.health.Set(ctx, source, category, SourceHealth{Errors: errors, NextUpdate: nextUpdate}); err != nil {
+			e.logf("category=%s set_health_failed err=%v", category, err)
+		}
+	}
+}
+
 func (e *EleduckFetcher) buildPageURL(categoryPath string, page int) (string, error) {
 	base, err := url.Parse(e.baseURL)
 	if err != nil {
@@ -219,6 +449,12 @@ func (e *EleduckFetcher) logf(format string, args ...any) {
 	e.logger.Printf(format, args...)
 }
 
+// logStage 在一条分类抓取阶段日志后追加 elapsed_ms（距该分类开始抓取的耗时）与 goroutine_id
+// （并发 worker 序号，而非运行时真实 goroutine ID），便于排查某个分类并发抓取时的耗时分布。
+func (e *EleduckFetcher) logStage(workerID int, start time.Time, format string, args ...any) {
+	e.logf(format+" elapsed_ms=%d goroutine_id=%d", append(args, e.now().Sub(start).Milliseconds(), workerID)...)
+}
+
 func normalizeCategoryPaths(paths []string) []string {
 	clean := make([]string, 0, len(paths))
 	for _, p := range paths {
@@ -234,59 +470,6 @@ func normalizeCategoryPaths(paths []string) []string {
 	return clean
 }
 
-func toRawAttributes(p eleduckPost) datatypes.JSONMap {
-	tags := make([]map[string]any, 0, len(p.Tags))
-	for _, tag := range p.Tags {
-		tags = append(tags, map[string]any{"name": tag.Name})
-	}
-	return datatypes.JSONMap{
-		"id":               p.ID,
-		"title":            p.Title,
-		"full_title":       p.FullTitle,
-		"summary":          p.Summary,
-		"excerpt":          p.Excerpt,
-		"publishedAt":      p.PublishedAt,
-		"published_at":     p.PublishedAtAlt,
-		"tags":             tags,
-		"url":              p.URL,
-		"normalized_title": pickSummary(p),
-	}
-}
-
-// nextData mirrors __NEXT_DATA__ 结构（精简字段）。
-type nextData struct {
-	Props struct {
-		PageProps    *pageProps    `json:"pageProps"`
-		InitialProps *initialProps `json:"initialProps"`
-	} `json:"props"`
-}
-
-type initialProps struct {
-	PageProps *pageProps `json:"pageProps"`
-}
-
-type pageProps struct {
-	PostList *struct {
-		Posts []eleduckPost `json:"posts"`
-	} `json:"postList"`
-}
-
-type eleduckTag struct {
-	Name string `json:"name"`
-}
-
-type eleduckPost struct {
-	ID             any          `json:"id"`
-	Title          string       `json:"title"`
-	FullTitle      string       `json:"full_title"`
-	Summary        string       `json:"summary"`
-	Excerpt        string       `json:"excerpt"`
-	PublishedAt    string       `json:"publishedAt"`
-	PublishedAtAlt string       `json:"published_at"`
-	Tags           []eleduckTag `json:"tags"`
-	URL            string       `json:"url"`
-}
-
 func extractNextData(htmlText string) (string, error) {
 	node, err := html.Parse(strings.NewReader(htmlText))
 	if err != nil {
@@ -321,69 +504,10 @@ func extractNextData(htmlText string) (string, error) {
 	return scriptText, nil
 }
 
-func parseEleduckPosts(jsonText string) ([]eleduckPost, error) {
-	var nd nextData
-	if err := json.Unmarshal([]byte(jsonText), &nd); err != nil {
-		return nil, fmt.Errorf("unmarshal next data: %w", err)
-	}
-
-	if nd.Props.PageProps != nil && nd.Props.PageProps.PostList != nil {
-		return nd.Props.PageProps.PostList.Posts, nil
-	}
-
-	if nd.Props.InitialProps != nil && nd.Props.InitialProps.PageProps != nil && nd.Props.InitialProps.PageProps.PostList != nil {
-		return nd.Props.InitialProps.PageProps.PostList.Posts, nil
-	}
-
-	return nil, fmt.Errorf("postList not found in __NEXT_DATA__")
-}
-
-func hasRemoteTag(tags []eleduckTag) bool {
-	for _, t := range tags {
-		if strings.Contains(t.Name, "远程") {
-			return true
-		}
-	}
-	return false
-}
-
-func normalizeID(id any) string {
-	switch v := id.(type) {
-	case string:
-		return v
-	case json.Number:
-		return v.String()
-	case float64:
-		return strings.TrimSuffix(strings.TrimSuffix(fmt.Sprintf("%.f", v), ".0"), ".00")
-	default:
-		return fmt.Sprintf("%v", v)
-	}
-}
-
-func pickPublishedAt(p eleduckPost) string {
-	if p.PublishedAt != "" {
-		return p.PublishedAt
-	}
-	return p.PublishedAtAlt
-}
-
-func pickSummary(p eleduckPost) string {
-	if p.Summary != "" {
-		return p.Summary
-	}
-	if p.Excerpt != "" {
-		return p.Excerpt
-	}
-	if p.FullTitle != "" {
-		return p.FullTitle
-	}
-	return p.Title
-}
-
-func toTagMap(tags []eleduckTag) datatypes.JSONMap {
+func toTagMap(tags []string) datatypes.JSONMap {
 	m := datatypes.JSONMap{}
 	for _, t := range tags {
-		m[t.Name] = true
+		m[t] = true
 	}
 	return m
 }
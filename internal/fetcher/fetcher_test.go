@@ -12,6 +12,9 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"remote-radar/internal/dedup"
+	"remote-radar/internal/filter"
 )
 
 func TestEleduckFetchFiltersByTagAndAge(t *testing.T) {
@@ -457,6 +460,98 @@ func TestEleduckFetchCapturesRawAttributes(t *testing.T) {
 	}
 }
 
+func TestEleduckFetchSkipsSensitiveJobs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	rt := newStubRoundTripper(map[string]string{
+		"http://example.com/categories/5?sort=new": buildEleduckHTML([]postFixture{
+			{
+				ID:          "clean",
+				Title:       "Remote Backend Engineer",
+				Summary:     "Go role",
+				PublishedAt: now.Add(-2 * time.Hour),
+				Tags:        []string{"远程工作"},
+				URL:         "/post/clean",
+			},
+			{
+				ID:          "scam",
+				Title:       "Crypto Scam Opportunity",
+				Summary:     "Get rich quick",
+				PublishedAt: now.Add(-2 * time.Hour),
+				Tags:        []string{"远程工作"},
+				URL:         "/post/scam",
+			},
+		}),
+	}, &atomic.Int32{})
+
+	cfg := Config{MaxPages: 1, MaxAgeDays: 30, CategoryPaths: []string{"/categories/5?sort=new"}}
+	fetcher := NewEleduckFetcher("http://example.com", cfg, &http.Client{Transport: rt}).
+		WithSensitiveFilter(filter.NewService(filter.Config{TitleSensitives: []string{"scam"}}))
+	fetcher.now = func() time.Time { return now }
+
+	jobs, err := fetcher.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "clean" {
+		t.Fatalf("expected only the clean job to survive, got %+v", jobs)
+	}
+}
+
+func TestEleduckFetchSkipsJobsSeenInPriorRun(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	rt := newStubRoundTripper(map[string]string{
+		"http://example.com/categories/5?sort=new": buildEleduckHTML([]postFixture{
+			{
+				ID:          "already-seen",
+				Title:       "Remote Backend Engineer",
+				Summary:     "Go role",
+				PublishedAt: now.Add(-2 * time.Hour),
+				Tags:        []string{"远程工作"},
+				URL:         "/post/already-seen",
+			},
+			{
+				ID:          "fresh",
+				Title:       "Remote Frontend Engineer",
+				Summary:     "React role",
+				PublishedAt: now.Add(-2 * time.Hour),
+				Tags:        []string{"远程工作"},
+				URL:         "/post/fresh",
+			},
+		}),
+	}, &atomic.Int32{})
+
+	store := dedup.NewMemoryStore()
+	if err := store.MarkSeen(context.Background(), "eleduck", "already-seen", time.Hour); err != nil {
+		t.Fatalf("MarkSeen error: %v", err)
+	}
+
+	cfg := Config{MaxPages: 1, MaxAgeDays: 30, CategoryPaths: []string{"/categories/5?sort=new"}}
+	fetcher := NewEleduckFetcher("http://example.com", cfg, &http.Client{Transport: rt}).WithDedupStore(store)
+	fetcher.now = func() time.Time { return now }
+
+	jobs, err := fetcher.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "fresh" {
+		t.Fatalf("expected only the fresh job to survive, got %+v", jobs)
+	}
+
+	seen, err := store.Seen(context.Background(), "eleduck", "fresh")
+	if err != nil {
+		t.Fatalf("Seen error: %v", err)
+	}
+	if !seen {
+		t.Fatalf("expected fresh job to be marked seen after fetch")
+	}
+}
+
 func TestEleduckFetchRealManual(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
@@ -621,6 +716,327 @@ func buildEleduckHTMLSnakeCase(posts []postFixture) string {
 	return "<html><head></head><body><script id=\"__NEXT_DATA__\" type=\"application/json\">" + string(jsonBytes) + "</script></body></html>"
 }
 
+func TestEleduckFetchRetriesTransientFailureThenSucceeds(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	pageHTML := buildEleduckHTML([]postFixture{{
+		ID:          "ok",
+		Title:       "Remote Role",
+		Summary:     "Go dev",
+		PublishedAt: now.Add(-time.Hour),
+		Tags:        []string{"远程工作"},
+		URL:         "/post/ok",
+	}})
+
+	rt := &flakyRoundTripper{failFor: 1, status: http.StatusServiceUnavailable, body: pageHTML}
+
+	fetcher := &EleduckFetcher{
+		baseURL:       "http://example.com",
+		categoryPaths: []string{"/categories/5?sort=new"},
+		client:        &http.Client{Transport: rt},
+		cfg:           Config{MaxPages: 1, MaxAgeDays: 30},
+		now:           func() time.Time { return now },
+		maxRetries:    1,
+	}
+
+	jobs, err := fetcher.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job after retrying the transient failure, got %d", len(jobs))
+	}
+	if rt.attempts.Load() != 2 {
+		t.Fatalf("expected 2 attempts (1 failure + success), got %d", rt.attempts.Load())
+	}
+}
+
+func TestEleduckFetchRecordsHealthAndSkipsPersistentlyFailingCategory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	rt := &alwaysFailRoundTripper{}
+	health := NewInMemorySourceHealthStore()
+	var buf bytes.Buffer
+
+	fetcher := &EleduckFetcher{
+		baseURL:       "http://example.com",
+		categoryPaths: []string{"/categories/5?sort=new"},
+		client:        &http.Client{Transport: rt},
+		cfg:           Config{MaxPages: 1, MaxAgeDays: 30},
+		now:           func() time.Time { return now },
+		health:        health,
+		logger:        log.New(&buf, "", 0),
+	}
+
+	jobs, err := fetcher.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch should not fail the whole run on a persistent category failure, got err: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Fatalf("expected no jobs, got %d", len(jobs))
+	}
+
+	h, err := health.Get(context.Background(), "eleduck", "/categories/5?sort=new")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if h.Errors != 1 || !h.NextUpdate.After(now) {
+		t.Fatalf("expected health recorded with errors=1 and a future next_update, got %+v", h)
+	}
+
+	firstAttempts := rt.attempts.Load()
+
+	if _, err := fetcher.Fetch(context.Background()); err != nil {
+		t.Fatalf("second Fetch error: %v", err)
+	}
+	if rt.attempts.Load() != firstAttempts {
+		t.Fatalf("expected category to be skipped while unhealthy, got %d new attempts", rt.attempts.Load()-firstAttempts)
+	}
+	if !strings.Contains(buf.String(), "skip_unhealthy") {
+		t.Fatalf("expected skip_unhealthy log line, got: %s", buf.String())
+	}
+}
+
+func TestEleduckFetchPreservesOrderAcrossConcurrentCategories(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	categoryURLs := map[string]string{
+		"http://example.com/categories/5?sort=new": buildEleduckHTML([]postFixture{
+			{ID: "c0-0", Title: "A", Summary: "a", PublishedAt: now.Add(-time.Hour), Tags: []string{"远程工作"}, URL: "/post/c0-0"},
+			{ID: "c0-1", Title: "B", Summary: "b", PublishedAt: now.Add(-time.Hour), Tags: []string{"远程工作"}, URL: "/post/c0-1"},
+		}),
+		"http://example.com/categories/22?sort=new": buildEleduckHTML([]postFixture{
+			{ID: "c1-0", Title: "C", Summary: "c", PublishedAt: now.Add(-time.Hour), Tags: []string{"远程工作"}, URL: "/post/c1-0"},
+		}),
+	}
+
+	rt := &delayedRoundTripper{
+		responses: categoryURLs,
+		delayFor: map[string]time.Duration{
+			"http://example.com/categories/5?sort=new":  30 * time.Millisecond,
+			"http://example.com/categories/22?sort=new": 0,
+		},
+	}
+
+	var buf bytes.Buffer
+	fetcher := &EleduckFetcher{
+		baseURL:       "http://example.com",
+		categoryPaths: []string{"/categories/5?sort=new", "/categories/22?sort=new"},
+		client:        &http.Client{Transport: rt},
+		cfg:           Config{MaxPages: 1, MaxAgeDays: 30, Concurrency: 2},
+		now:           func() time.Time { return now },
+		logger:        log.New(&buf, "", 0),
+	}
+
+	jobs, err := fetcher.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+
+	var ids []string
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	got := strings.Join(ids, ",")
+	want := "c0-0,c0-1,c1-0"
+	if got != want {
+		t.Fatalf("expected jobs ordered by (categoryIndex,page,position) regardless of which category's request finished first, got %s want %s", got, want)
+	}
+
+	if !strings.Contains(buf.String(), "goroutine_id=") || !strings.Contains(buf.String(), "elapsed_ms=") {
+		t.Fatalf("expected stage logs to carry elapsed_ms and goroutine_id, got: %s", buf.String())
+	}
+}
+
+func TestEleduckFetchBoundsCategoryConcurrency(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	categories := []string{
+		"/categories/1?sort=new",
+		"/categories/2?sort=new",
+		"/categories/3?sort=new",
+		"/categories/4?sort=new",
+	}
+
+	rt := &concurrencyTrackingRoundTripper{}
+	for _, c := range categories {
+		rt.register("http://example.com" + c)
+	}
+
+	fetcher := &EleduckFetcher{
+		baseURL:       "http://example.com",
+		categoryPaths: categories,
+		client:        &http.Client{Transport: rt},
+		cfg:           Config{MaxPages: 1, MaxAgeDays: 30, Concurrency: 2},
+		now:           func() time.Time { return now },
+	}
+
+	if _, err := fetcher.Fetch(context.Background()); err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+
+	if rt.maxInFlight.Load() > 2 {
+		t.Fatalf("expected at most 2 categories in flight at once, observed %d", rt.maxInFlight.Load())
+	}
+	if rt.maxInFlight.Load() < 2 {
+		t.Fatalf("expected categories to actually run concurrently up to the configured limit, observed only %d in flight", rt.maxInFlight.Load())
+	}
+}
+
+func TestEleduckFetchRequestTimeoutFailsSlowCategoryWithoutAffectingOthers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	fastURL := "http://example.com/categories/22?sort=new"
+	fastHTML := buildEleduckHTML([]postFixture{{
+		ID:          "fast",
+		Title:       "Fast",
+		Summary:     "ok",
+		PublishedAt: now.Add(-time.Hour),
+		Tags:        []string{"远程工作"},
+		URL:         "/post/fast",
+	}})
+
+	rt := &contextAwareRoundTripper{
+		blockHosts: map[string]bool{"http://example.com/categories/5?sort=new": true},
+		responses:  map[string]string{fastURL: fastHTML},
+	}
+	health := NewInMemorySourceHealthStore()
+
+	fetcher := &EleduckFetcher{
+		baseURL:       "http://example.com",
+		categoryPaths: []string{"/categories/5?sort=new", "/categories/22?sort=new"},
+		client:        &http.Client{Transport: rt},
+		cfg:           Config{MaxPages: 1, MaxAgeDays: 30, RequestTimeout: 20 * time.Millisecond},
+		now:           func() time.Time { return now },
+		health:        health,
+	}
+
+	jobs, err := fetcher.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch should not fail the whole run when one category times out, got err: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "fast" {
+		t.Fatalf("expected only the healthy category's job to survive, got %+v", jobs)
+	}
+
+	h, err := health.Get(context.Background(), "eleduck", "/categories/5?sort=new")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if h.Errors != 1 {
+		t.Fatalf("expected the slow category to be recorded as failed due to request timeout, got %+v", h)
+	}
+}
+
+// delayedRoundTripper 按 URL 返回预设的 HTML 响应，并在返回前等待该 URL 对应的延迟，
+// 用于验证不同分类的请求以不同速度完成时，最终结果仍按 (categoryIndex, page, position) 排序。
+type delayedRoundTripper struct {
+	responses map[string]string
+	delayFor  map[string]time.Duration
+}
+
+func (rt *delayedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.URL.String()
+	if d := rt.delayFor[key]; d > 0 {
+		time.Sleep(d)
+	}
+	body, ok := rt.responses[key]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("not found")), Header: make(http.Header)}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header), Request: req}, nil
+}
+
+// concurrencyTrackingRoundTripper 记录同一时刻有多少个请求在途，用于验证 Dispatcher 风格的
+// 并发上限（errgroup.SetLimit）确实生效：既不超过配置值，也确实并发而非退化为串行。
+type concurrencyTrackingRoundTripper struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight atomic.Int32
+	bodies      map[string]string
+}
+
+func (rt *concurrencyTrackingRoundTripper) register(url string) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.bodies == nil {
+		rt.bodies = make(map[string]string)
+	}
+	rt.bodies[url] = buildEleduckHTML(nil)
+}
+
+func (rt *concurrencyTrackingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.inFlight++
+	if int32(rt.inFlight) > rt.maxInFlight.Load() {
+		rt.maxInFlight.Store(int32(rt.inFlight))
+	}
+	rt.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	rt.mu.Lock()
+	rt.inFlight--
+	body := rt.bodies[req.URL.String()]
+	rt.mu.Unlock()
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header), Request: req}, nil
+}
+
+// contextAwareRoundTripper 对 blockHosts 命中的请求阻塞直到其 context 被取消（模拟卡死的慢请求），
+// 用于验证 Config.RequestTimeout 能让单个分类超时失败而不影响其它分类。
+type contextAwareRoundTripper struct {
+	blockHosts map[string]bool
+	responses  map[string]string
+}
+
+func (rt *contextAwareRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.blockHosts[req.URL.String()] {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	body, ok := rt.responses[req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("not found")), Header: make(http.Header)}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header), Request: req}, nil
+}
+
+// flakyRoundTripper 前 failFor 次请求返回 status，之后返回携带 body 的 200 响应。
+type flakyRoundTripper struct {
+	mu       sync.Mutex
+	attempts atomic.Int32
+	failFor  int
+	status   int
+	body     string
+}
+
+func (rt *flakyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	attempt := int(rt.attempts.Add(1))
+	rt.mu.Unlock()
+	if attempt <= rt.failFor {
+		return &http.Response{StatusCode: rt.status, Body: io.NopCloser(strings.NewReader("fail")), Header: make(http.Header)}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(rt.body)), Header: make(http.Header), Request: req}, nil
+}
+
+// alwaysFailRoundTripper 始终返回 500，用于验证分类健康状态的持久失败路径。
+type alwaysFailRoundTripper struct {
+	attempts atomic.Int32
+}
+
+func (rt *alwaysFailRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.attempts.Add(1)
+	return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("boom")), Header: make(http.Header)}, nil
+}
+
 type stubRoundTripper struct {
 	responses map[string]string
 	hits      *atomic.Int32
@@ -0,0 +1,122 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+// GreenhouseFetcher 抓取单个 Greenhouse 招聘看板（board）的公开职位 JSON。
+type GreenhouseFetcher struct {
+	baseURL string
+	board   string
+	client  *http.Client
+	cfg     Config
+	now     func() time.Time
+}
+
+// NewGreenhouseFetcher 创建 Greenhouse 抓取器，baseURL 形如 https://boards-api.greenhouse.io。
+func NewGreenhouseFetcher(baseURL, board string, cfg Config, client *http.Client) *GreenhouseFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if cfg.MaxAgeDays <= 0 {
+		cfg.MaxAgeDays = 30
+	}
+	return &GreenhouseFetcher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		board:   board,
+		client:  client,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+func newGreenhouseFromSource(cfg SourceConfig, client *http.Client) (JobFetcher, error) {
+	board := cfg.Options["board"]
+	if board == "" {
+		return nil, fmt.Errorf("greenhouse source requires options.board")
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://boards-api.greenhouse.io"
+	}
+	fetchCfg := Config{MaxAgeDays: optionInt(cfg.Options, "max_age_days", 30)}
+	return NewGreenhouseFetcher(baseURL, board, fetchCfg, client), nil
+}
+
+type greenhouseJob struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	UpdatedAt   string `json:"updated_at"` // RFC3339
+	AbsoluteURL string `json:"absolute_url"`
+	Location    struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Content string `json:"content"`
+}
+
+type greenhouseJobsResponse struct {
+	Jobs []greenhouseJob `json:"jobs"`
+}
+
+// Fetch 调用 /v1/boards/{board}/jobs?content=true，按 MaxAgeDays 过滤。
+func (f *GreenhouseFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
+	endpoint := fmt.Sprintf("%s/v1/boards/%s/jobs?content=true", f.baseURL, f.board)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var payload greenhouseJobsResponse
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal jobs: %w", err)
+	}
+
+	cutoff := f.now().AddDate(0, 0, -f.cfg.MaxAgeDays)
+	jobs := make([]model.Job, 0, len(payload.Jobs))
+	for _, j := range payload.Jobs {
+		publishedAt, err := time.Parse(time.RFC3339, j.UpdatedAt)
+		if err != nil || publishedAt.Before(cutoff) {
+			continue
+		}
+
+		jobs = append(jobs, model.Job{
+			ID:          fmt.Sprintf("greenhouse-%s-%d", f.board, j.ID),
+			Title:       j.Title,
+			Summary:     j.Content,
+			PublishedAt: publishedAt,
+			Source:      "greenhouse",
+			URL:         j.AbsoluteURL,
+			Tags:        datatypes.JSONMap{j.Location.Name: true},
+			RawAttributes: datatypes.JSONMap{
+				"id":       j.ID,
+				"board":    f.board,
+				"location": j.Location.Name,
+			},
+		})
+	}
+	return jobs, nil
+}
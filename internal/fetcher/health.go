@@ -0,0 +1,72 @@
+package fetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	healthBaseBackoff = time.Minute
+	healthMaxBackoff  = 7 * 24 * time.Hour
+)
+
+// SourceHealth 记录某个抓取来源/分类的健康状态：连续失败次数与下次允许抓取的时间。
+type SourceHealth struct {
+	Errors     int
+	NextUpdate time.Time
+}
+
+// SourceHealthStore 持久化各来源分类的健康状态，使 Fetch 可以跳过近期持续失败的分类。
+type SourceHealthStore interface {
+	Get(ctx context.Context, source, category string) (SourceHealth, error)
+	Set(ctx context.Context, source, category string, health SourceHealth) error
+}
+
+// InMemorySourceHealthStore 是 SourceHealthStore 的进程内默认实现，重启后状态丢失。
+type InMemorySourceHealthStore struct {
+	mu    sync.Mutex
+	state map[string]SourceHealth
+}
+
+// NewInMemorySourceHealthStore 创建一个空的进程内健康状态存储。
+func NewInMemorySourceHealthStore() *InMemorySourceHealthStore {
+	return &InMemorySourceHealthStore{state: make(map[string]SourceHealth)}
+}
+
+// Get 实现 SourceHealthStore，未记录过的来源分类视为健康（零值）。
+func (s *InMemorySourceHealthStore) Get(_ context.Context, source, category string) (SourceHealth, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state[healthKey(source, category)], nil
+}
+
+// Set 实现 SourceHealthStore。
+func (s *InMemorySourceHealthStore) Set(_ context.Context, source, category string, health SourceHealth) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state[healthKey(source, category)] = health
+	return nil
+}
+
+func healthKey(source, category string) string {
+	return source + "|" + category
+}
+
+// backoffForErrors 按连续错误次数计算指数退避时长，封顶 healthMaxBackoff（约一周）。
+func backoffForErrors(errors int) time.Duration {
+	if errors <= 0 {
+		return 0
+	}
+	d := healthBaseBackoff
+	for i := 1; i < errors; i++ {
+		if d >= healthMaxBackoff {
+			return healthMaxBackoff
+		}
+		d *= 2
+	}
+	if d > healthMaxBackoff {
+		d = healthMaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBackoffForErrorsEscalatesAndCaps(t *testing.T) {
+	t.Parallel()
+
+	if d := backoffForErrors(0); d != 0 {
+		t.Fatalf("expected zero backoff for zero errors, got %s", d)
+	}
+
+	prev := backoffForErrors(1)
+	for errors := 2; errors <= 20; errors++ {
+		d := backoffForErrors(errors)
+		if d < prev {
+			t.Fatalf("expected non-decreasing backoff, errors=%d prev=%s got=%s", errors, prev, d)
+		}
+		if d > healthMaxBackoff {
+			t.Fatalf("expected backoff capped at %s, got %s for errors=%d", healthMaxBackoff, d, errors)
+		}
+		prev = d
+	}
+
+	if d := backoffForErrors(20); d != healthMaxBackoff {
+		t.Fatalf("expected backoff to reach the cap %s for a persistently failing category, got %s", healthMaxBackoff, d)
+	}
+}
+
+func TestInMemorySourceHealthStoreGetSet(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemorySourceHealthStore()
+	ctx := context.Background()
+
+	empty, err := store.Get(ctx, "eleduck", "/categories/5?sort=new")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if empty.Errors != 0 || !empty.NextUpdate.IsZero() {
+		t.Fatalf("expected zero-value health for unrecorded category, got %+v", empty)
+	}
+
+	want := SourceHealth{Errors: 3, NextUpdate: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)}
+	if err := store.Set(ctx, "eleduck", "/categories/5?sort=new", want); err != nil {
+		t.Fatalf("Set error: %v", err)
+	}
+
+	got, err := store.Get(ctx, "eleduck", "/categories/5?sort=new")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	other, err := store.Get(ctx, "eleduck", "/categories/22?sort=new")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if other.Errors != 0 {
+		t.Fatalf("expected a different category to remain unaffected, got %+v", other)
+	}
+}
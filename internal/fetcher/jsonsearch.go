@@ -0,0 +1,209 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+// JSONSearchFetcher 通过任意 JSON 搜索接口抓取职位，不需要为新来源编写 Go 代码：
+// URLTemplate 中的 {query} 会替换为 URL 转义后的 Query，ResultsPath/字段路径用简化版 JSONPath
+// （仅支持用点号分隔的对象字段访问，如 "data.jobs"）从响应中取出列表与各字段。
+// 典型用途是 Jenkins 之类提供通用搜索 JSON 接口的站点，故命名沿用 jenkins_search。
+type JSONSearchFetcher struct {
+	source       string
+	urlTemplate  string
+	query        string
+	resultsPath  string
+	fields       JSONSearchFields
+	dateLayout   string
+	client       *http.Client
+	cfg          Config
+	now          func() time.Time
+}
+
+// JSONSearchFields 描述从单条搜索结果中提取各字段所用的点号路径，留空则该字段不填充。
+type JSONSearchFields struct {
+	ID          string
+	Title       string
+	Summary     string
+	URL         string
+	PublishedAt string
+}
+
+// NewJSONSearchFetcher 创建通用 JSON 搜索抓取器，source 用于标识 model.Job.Source 与生成 ID 前缀。
+func NewJSONSearchFetcher(source, urlTemplate, query, resultsPath string, fields JSONSearchFields, dateLayout string, cfg Config, client *http.Client) *JSONSearchFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if dateLayout == "" {
+		dateLayout = time.RFC3339
+	}
+	return &JSONSearchFetcher{
+		source:      source,
+		urlTemplate: urlTemplate,
+		query:       query,
+		resultsPath: resultsPath,
+		fields:      fields,
+		dateLayout:  dateLayout,
+		client:      client,
+		cfg:         cfg,
+		now:         time.Now,
+	}
+}
+
+func newJSONSearchFromSource(cfg SourceConfig, client *http.Client) (JobFetcher, error) {
+	urlTemplate := cfg.Options["url_template"]
+	if urlTemplate == "" {
+		return nil, fmt.Errorf("jenkins_search source requires options.url_template")
+	}
+	fields := JSONSearchFields{
+		ID:          cfg.Options["field_id"],
+		Title:       cfg.Options["field_title"],
+		Summary:     cfg.Options["field_summary"],
+		URL:         cfg.Options["field_url"],
+		PublishedAt: cfg.Options["field_published_at"],
+	}
+	if fields.Title == "" {
+		return nil, fmt.Errorf("jenkins_search source requires options.field_title")
+	}
+	source := cfg.Name
+	if source == "" {
+		source = "jenkins_search"
+	}
+	fetchCfg := Config{MaxAgeDays: optionInt(cfg.Options, "max_age_days", 30)}
+	return NewJSONSearchFetcher(source, urlTemplate, cfg.Options["query"], cfg.Options["results_path"], fields, cfg.Options["date_layout"], fetchCfg, client), nil
+}
+
+// Fetch 将 URLTemplate 中的 {query} 替换为转义后的 Query，请求并解析 JSON 响应。
+func (f *JSONSearchFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
+	endpoint := strings.ReplaceAll(f.urlTemplate, "{query}", url.QueryEscape(f.query))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	results, err := jsonPathList(payload, f.resultsPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve results_path %q: %w", f.resultsPath, err)
+	}
+
+	cutoff := f.now().AddDate(0, 0, -f.cfg.MaxAgeDays)
+	jobs := make([]model.Job, 0, len(results))
+	for i, item := range results {
+		title := jsonPathString(item, f.fields.Title)
+		if title == "" {
+			continue
+		}
+
+		publishedAt := f.now()
+		if f.fields.PublishedAt != "" {
+			if raw := jsonPathString(item, f.fields.PublishedAt); raw != "" {
+				if parsed, err := time.Parse(f.dateLayout, raw); err == nil {
+					publishedAt = parsed
+				}
+			}
+		}
+		if publishedAt.Before(cutoff) {
+			continue
+		}
+
+		id := jsonPathString(item, f.fields.ID)
+		if id == "" {
+			id = strconv.Itoa(i)
+		}
+
+		jobs = append(jobs, model.Job{
+			ID:          fmt.Sprintf("%s-%s", f.source, id),
+			Title:       title,
+			Summary:     jsonPathString(item, f.fields.Summary),
+			PublishedAt: publishedAt,
+			Source:      f.source,
+			URL:         jsonPathString(item, f.fields.URL),
+			RawAttributes: datatypes.JSONMap{
+				"query": f.query,
+			},
+		})
+	}
+	return jobs, nil
+}
+
+// jsonPathList 按点号分隔的路径从解析后的 JSON 中取出一个数组，path 为空时 v 本身须已是数组。
+func jsonPathList(v any, path string) ([]any, error) {
+	resolved, err := jsonPathValue(v, path)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := resolved.([]any)
+	if !ok {
+		return nil, fmt.Errorf("value is not an array")
+	}
+	return list, nil
+}
+
+// jsonPathString 按点号分隔的路径取出一个字符串字段，取不到或类型不符时返回空字符串。
+func jsonPathString(v any, path string) string {
+	if path == "" {
+		return ""
+	}
+	resolved, err := jsonPathValue(v, path)
+	if err != nil {
+		return ""
+	}
+	switch val := resolved.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func jsonPathValue(v any, path string) (any, error) {
+	if path == "" {
+		return v, nil
+	}
+	cur := v
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("path segment %q: not an object", part)
+		}
+		next, ok := m[part]
+		if !ok {
+			return nil, fmt.Errorf("path segment %q: missing field", part)
+		}
+		cur = next
+	}
+	return cur, nil
+}
@@ -0,0 +1,89 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"remote-radar/internal/model"
+
+	"golang.org/x/sync/errgroup"
+	"gorm.io/datatypes"
+)
+
+// MultiFetcher 对多个 JobFetcher 并发执行 Fetch，并按 ID 跨来源去重后合并结果。
+// 实现 JobFetcher 接口，因此调度器无需感知背后有多少个来源。
+type MultiFetcher struct {
+	fetchers []JobFetcher
+}
+
+// NewMultiFetcher 创建 MultiFetcher，聚合给定的来源抓取器。
+func NewMultiFetcher(fetchers ...JobFetcher) *MultiFetcher {
+	return &MultiFetcher{fetchers: fetchers}
+}
+
+// Fetch 并发抓取所有来源，任一来源出错都会令整体失败，出现重复 ID 时保留 fetchers 列表中靠前来源的记录，
+// 与各来源返回结果的先后无关。
+func (m *MultiFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
+	results := make([][]model.Job, len(m.fetchers))
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i, f := range m.fetchers {
+		i, f := i, f
+		g.Go(func() error {
+			jobs, err := f.Fetch(ctx)
+			if err != nil {
+				return fmt.Errorf("fetch source %d: %w", i, err)
+			}
+			results[i] = jobs
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	merged := make([]model.Job, 0)
+	for _, jobs := range results {
+		for _, job := range jobs {
+			if _, ok := seen[job.ID]; ok {
+				continue
+			}
+			seen[job.ID] = struct{}{}
+			merged = append(merged, job)
+		}
+	}
+	return canonicalDedupe(merged), nil
+}
+
+// canonicalDedupe 按 canonicalKey（归一化标题 + URL host）折叠跨来源重复发布的同一职位：
+// 保留 jobs 中靠前的记录，后续重复项的来源记录进 Sources，不产生新的 model.Job。
+func canonicalDedupe(jobs []model.Job) []model.Job {
+	index := make(map[string]int, len(jobs))
+	deduped := make([]model.Job, 0, len(jobs))
+	for _, job := range jobs {
+		key := canonicalKey(job)
+		if i, ok := index[key]; ok {
+			if deduped[i].Sources == nil {
+				deduped[i].Sources = datatypes.JSONMap{deduped[i].Source: deduped[i].URL}
+			}
+			deduped[i].Sources[job.Source] = job.URL
+			continue
+		}
+		index[key] = len(deduped)
+		deduped = append(deduped, job)
+	}
+	return deduped
+}
+
+// canonicalKey 归一化标题（小写、折叠空白）与 URL host 拼接，作为跨来源去重的判定依据。
+func canonicalKey(job model.Job) string {
+	title := strings.Join(strings.Fields(strings.ToLower(job.Title)), " ")
+	host := ""
+	if u, err := url.Parse(job.URL); err == nil {
+		host = strings.ToLower(u.Host)
+	}
+	return title + "|" + host
+}
@@ -0,0 +1,178 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+)
+
+// ParsedPost 是 PostParser 从页面数据中解析出的单条职位，字段对所有站点通用。
+// PublishedAt 保留原始文本，由调用方统一按 RFC3339 解析并与 cutoff 比较。
+type ParsedPost struct {
+	ID          string
+	Title       string
+	Summary     string
+	PublishedAt string
+	URL         string
+	Tags        []string
+	Raw         datatypes.JSONMap
+}
+
+// PostParser 从页面的 __NEXT_DATA__ JSON 中解析出职位列表。
+// 新增一个基于 Next.js 的站点时，只需实现该接口即可复用 EleduckFetcher 的抓取/分页/去重骨架，
+// 无需复制整套抓取逻辑。
+type PostParser interface {
+	ParsePosts(nextJSON string) ([]ParsedPost, error)
+}
+
+// eleduckParser 实现 PostParser，解析电鸭 postList 结构，并按"远程"标签筛选候选职位。
+type eleduckParser struct{}
+
+func (eleduckParser) ParsePosts(nextJSON string) ([]ParsedPost, error) {
+	posts, err := parseEleduckPosts(nextJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]ParsedPost, 0, len(posts))
+	for _, p := range posts {
+		if !hasRemoteTag(p.Tags) {
+			continue
+		}
+
+		title := p.Title
+		if title == "" {
+			title = p.FullTitle
+		}
+
+		tagNames := make([]string, 0, len(p.Tags))
+		for _, t := range p.Tags {
+			tagNames = append(tagNames, t.Name)
+		}
+
+		out = append(out, ParsedPost{
+			ID:          normalizeID(p.ID),
+			Title:       title,
+			Summary:     pickSummary(p),
+			PublishedAt: pickPublishedAt(p),
+			URL:         p.URL,
+			Tags:        tagNames,
+			Raw:         toRawAttributes(p),
+		})
+	}
+	return out, nil
+}
+
+// nextData mirrors __NEXT_DATA__ 结构（精简字段）。
+type nextData struct {
+	Props struct {
+		PageProps    *pageProps    `json:"pageProps"`
+		InitialProps *initialProps `json:"initialProps"`
+	} `json:"props"`
+}
+
+type initialProps struct {
+	PageProps *pageProps `json:"pageProps"`
+}
+
+type pageProps struct {
+	PostList *struct {
+		Posts []eleduckPost `json:"posts"`
+	} `json:"postList"`
+}
+
+type eleduckTag struct {
+	Name string `json:"name"`
+}
+
+type eleduckPost struct {
+	ID             any          `json:"id"`
+	Title          string       `json:"title"`
+	FullTitle      string       `json:"full_title"`
+	Summary        string       `json:"summary"`
+	Excerpt        string       `json:"excerpt"`
+	PublishedAt    string       `json:"publishedAt"`
+	PublishedAtAlt string       `json:"published_at"`
+	Tags           []eleduckTag `json:"tags"`
+	URL            string       `json:"url"`
+}
+
+func parseEleduckPosts(jsonText string) ([]eleduckPost, error) {
+	var nd nextData
+	if err := json.Unmarshal([]byte(jsonText), &nd); err != nil {
+		return nil, fmt.Errorf("unmarshal next data: %w", err)
+	}
+
+	if nd.Props.PageProps != nil && nd.Props.PageProps.PostList != nil {
+		return nd.Props.PageProps.PostList.Posts, nil
+	}
+
+	if nd.Props.InitialProps != nil && nd.Props.InitialProps.PageProps != nil && nd.Props.InitialProps.PageProps.PostList != nil {
+		return nd.Props.InitialProps.PageProps.PostList.Posts, nil
+	}
+
+	return nil, fmt.Errorf("postList not found in __NEXT_DATA__")
+}
+
+func hasRemoteTag(tags []eleduckTag) bool {
+	for _, t := range tags {
+		if strings.Contains(t.Name, "远程") {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeID(id any) string {
+	switch v := id.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strings.TrimSuffix(strings.TrimSuffix(fmt.Sprintf("%.f", v), ".0"), ".00")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func pickPublishedAt(p eleduckPost) string {
+	if p.PublishedAt != "" {
+		return p.PublishedAt
+	}
+	return p.PublishedAtAlt
+}
+
+func pickSummary(p eleduckPost) string {
+	if p.Summary != "" {
+		return p.Summary
+	}
+	if p.Excerpt != "" {
+		return p.Excerpt
+	}
+	if p.FullTitle != "" {
+		return p.FullTitle
+	}
+	return p.Title
+}
+
+func toRawAttributes(p eleduckPost) datatypes.JSONMap {
+	tags := make([]map[string]any, 0, len(p.Tags))
+	for _, tag := range p.Tags {
+		tags = append(tags, map[string]any{"name": tag.Name})
+	}
+	return datatypes.JSONMap{
+		"id":               p.ID,
+		"title":            p.Title,
+		"full_title":       p.FullTitle,
+		"summary":          p.Summary,
+		"excerpt":          p.Excerpt,
+		"publishedAt":      p.PublishedAt,
+		"published_at":     p.PublishedAtAlt,
+		"tags":             tags,
+		"url":              p.URL,
+		"normalized_title": pickSummary(p),
+	}
+}
@@ -0,0 +1,119 @@
+package fetcher
+
+import (
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig 控制每个 host 的请求速率与重试行为。
+type RateLimitConfig struct {
+	QPSPerHost float64 `yaml:"qps_per_host" json:"qps_per_host"`
+	Burst      int     `yaml:"burst" json:"burst"`
+	MaxRetries int     `yaml:"max_retries" json:"max_retries"`
+}
+
+const (
+	defaultQPSPerHost = 1.0
+	defaultBurst      = 2
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 500 * time.Millisecond
+	maxJitter         = 250 * time.Millisecond
+)
+
+// RateLimitedClient 包装 http.Client，按目标 host 做令牌桶限流，并对 429/5xx 响应做指数退避重试。
+type RateLimitedClient struct {
+	*http.Client
+}
+
+// NewRateLimitedClient 基于 base 构建 RateLimitedClient，base 为 nil 时使用 http.DefaultClient。
+// cfg 的零值字段会被替换为合理默认值（1 QPS、突发 2、最多重试 3 次）。
+func NewRateLimitedClient(base *http.Client, cfg RateLimitConfig) *RateLimitedClient {
+	if base == nil {
+		base = http.DefaultClient
+	}
+	if cfg.QPSPerHost <= 0 {
+		cfg.QPSPerHost = defaultQPSPerHost
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = defaultBurst
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	wrapped := *base
+	wrapped.Transport = &rateLimitedTransport{
+		base:       transport,
+		limiters:   make(map[string]*rate.Limiter),
+		qps:        rate.Limit(cfg.QPSPerHost),
+		burst:      cfg.Burst,
+		maxRetries: cfg.MaxRetries,
+	}
+	return &RateLimitedClient{Client: &wrapped}
+}
+
+type rateLimitedTransport struct {
+	base       http.RoundTripper
+	mu         sync.Mutex
+	limiters   map[string]*rate.Limiter
+	qps        rate.Limit
+	burst      int
+	maxRetries int
+}
+
+// RoundTrip 在发出请求前等待所在 host 的令牌桶，对 429/5xx 响应按指数退避加随机抖动重试。
+// 仅对无请求体（GET）的请求安全重试，这也是各 fetcher 当前唯一的请求方式。
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limiterFor(req.URL.Host)
+
+	delay := defaultBaseDelay
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		if waitErr := limiter.Wait(req.Context()); waitErr != nil {
+			return nil, waitErr
+		}
+
+		resp, err = t.base.RoundTrip(req)
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if attempt >= t.maxRetries {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay + jitter()):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		delay *= 2
+	}
+}
+
+func (t *rateLimitedTransport) limiterFor(host string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := t.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(t.qps, t.burst)
+		t.limiters[host] = l
+	}
+	return l
+}
+
+func jitter() time.Duration {
+	return time.Duration(rand.Int63n(int64(maxJitter)))
+}
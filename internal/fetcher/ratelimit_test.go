@@ -0,0 +1,95 @@
+package fetcher
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type counterRoundTripper struct {
+	calls    atomic.Int32
+	statuses []int
+}
+
+func (rt *counterRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	idx := int(rt.calls.Add(1)) - 1
+	status := http.StatusOK
+	if idx < len(rt.statuses) {
+		status = rt.statuses[idx]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       http.NoBody,
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestRateLimitedClientRetriesOn5xx(t *testing.T) {
+	t.Parallel()
+
+	rt := &counterRoundTripper{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	client := NewRateLimitedClient(&http.Client{Transport: rt}, RateLimitConfig{QPSPerHost: 1000, Burst: 10, MaxRetries: 2})
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/jobs", nil)
+	if err != nil {
+		t.Fatalf("NewRequest error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if rt.calls.Load() != 2 {
+		t.Fatalf("expected 2 attempts, got %d", rt.calls.Load())
+	}
+}
+
+func TestRateLimitedClientGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	rt := &counterRoundTripper{statuses: []int{
+		http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests,
+	}}
+	client := NewRateLimitedClient(&http.Client{Transport: rt}, RateLimitConfig{QPSPerHost: 1000, Burst: 10, MaxRetries: 1})
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/jobs", nil)
+	if err != nil {
+		t.Fatalf("NewRequest error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected final status 429, got %d", resp.StatusCode)
+	}
+	if rt.calls.Load() != 2 {
+		t.Fatalf("expected maxRetries+1=2 attempts, got %d", rt.calls.Load())
+	}
+}
+
+func TestRateLimitedClientThrottlesPerHost(t *testing.T) {
+	t.Parallel()
+
+	rt := &counterRoundTripper{}
+	client := NewRateLimitedClient(&http.Client{Transport: rt}, RateLimitConfig{QPSPerHost: 5, Burst: 1, MaxRetries: 0})
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/jobs", nil)
+	if err != nil {
+		t.Fatalf("NewRequest error: %v", err)
+	}
+	start := time.Now()
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("first Do error: %v", err)
+	}
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("second Do error: %v", err)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 100*time.Millisecond {
+		t.Fatalf("expected second request to wait for a fresh token, elapsed=%v", elapsed)
+	}
+}
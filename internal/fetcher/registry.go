@@ -0,0 +1,91 @@
+package fetcher
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SourceConfig 描述一个抓取源的配置，驱动 Registry 构建对应的 JobFetcher。
+// Options 承载各适配器私有的参数（如 V2EX 的 node、Greenhouse 的 board），
+// 避免为每个来源单独扩展顶层配置结构。
+type SourceConfig struct {
+	Kind    string            `yaml:"kind" json:"kind"`
+	Name    string            `yaml:"name" json:"name"`
+	BaseURL string            `yaml:"base_url" json:"base_url"`
+	Enabled bool              `yaml:"enabled" json:"enabled"`
+	Options map[string]string `yaml:"options" json:"options"`
+}
+
+// Factory 依据 SourceConfig 与共享 http.Client 构建一个 JobFetcher。
+type Factory func(cfg SourceConfig, client *http.Client) (JobFetcher, error)
+
+// Registry 按 Kind 管理 JobFetcher 构造函数，用于从配置驱动多源抓取。
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry 创建已注册内置适配器（eleduck/v2ex/remoteok/greenhouse/jenkins_search）的 Registry。
+// jenkins_search 是通用 JSON 搜索接口适配器，通过 options 配置即可接入新来源而无需编写 Go 代码。
+func NewRegistry() *Registry {
+	r := &Registry{factories: make(map[string]Factory)}
+	r.Register("eleduck", newEleduckFromSource)
+	r.Register("v2ex", newV2EXFromSource)
+	r.Register("remoteok", newRemoteOKFromSource)
+	r.Register("greenhouse", newGreenhouseFromSource)
+	r.Register("jenkins_search", newJSONSearchFromSource)
+	return r
+}
+
+// Register 注册一个抓取源类型，重复注册同一 Kind 会覆盖之前的构造函数。
+func (r *Registry) Register(kind string, factory Factory) {
+	r.factories[strings.ToLower(strings.TrimSpace(kind))] = factory
+}
+
+// Build 依据配置列表构建已启用来源对应的 JobFetcher，遇到未注册的 Kind 返回错误。
+func (r *Registry) Build(cfgs []SourceConfig, client *http.Client) ([]JobFetcher, error) {
+	fetchers := make([]JobFetcher, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if !cfg.Enabled {
+			continue
+		}
+		factory, ok := r.factories[strings.ToLower(strings.TrimSpace(cfg.Kind))]
+		if !ok {
+			return nil, fmt.Errorf("unknown fetcher source kind %q", cfg.Kind)
+		}
+		f, err := factory(cfg, client)
+		if err != nil {
+			return nil, fmt.Errorf("build source %s: %w", cfg.Kind, err)
+		}
+		fetchers = append(fetchers, f)
+	}
+	return fetchers, nil
+}
+
+func newEleduckFromSource(cfg SourceConfig, client *http.Client) (JobFetcher, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://eleduck.com"
+	}
+	fetchCfg := Config{
+		MaxAgeDays: optionInt(cfg.Options, "max_age_days", 30),
+		MaxPages:   optionInt(cfg.Options, "max_pages", 1),
+	}
+	if paths := cfg.Options["category_paths"]; paths != "" {
+		fetchCfg.CategoryPaths = strings.Split(paths, ",")
+	}
+	return NewEleduckFetcher(baseURL, fetchCfg, client), nil
+}
+
+func optionInt(options map[string]string, key string, fallback int) int {
+	raw, ok := options[key]
+	if !ok {
+		return fallback
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
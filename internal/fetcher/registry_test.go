@@ -0,0 +1,84 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"remote-radar/internal/model"
+)
+
+func TestRegistryBuildSkipsDisabledAndRejectsUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+
+	_, err := r.Build([]SourceConfig{{Kind: "unknown", Enabled: true}}, nil)
+	if err == nil {
+		t.Fatalf("expected error for unknown kind")
+	}
+
+	fetchers, err := r.Build([]SourceConfig{{Kind: "eleduck", Enabled: false}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fetchers) != 0 {
+		t.Fatalf("expected disabled source to be skipped, got %d fetchers", len(fetchers))
+	}
+}
+
+func TestMultiFetcherMergesAndDedupsByID(t *testing.T) {
+	t.Parallel()
+
+	a := &stubJobFetcher{jobs: []model.Job{{ID: "1", Title: "A"}, {ID: "2", Title: "B"}}}
+	b := &stubJobFetcher{jobs: []model.Job{{ID: "2", Title: "B-dup"}, {ID: "3", Title: "C"}}}
+
+	multi := NewMultiFetcher(a, b)
+	jobs, err := multi.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 deduped jobs, got %d", len(jobs))
+	}
+}
+
+func TestMultiFetcherCollapsesCrossSourceDuplicatesIntoSources(t *testing.T) {
+	t.Parallel()
+
+	a := &stubJobFetcher{jobs: []model.Job{{ID: "eleduck-1", Title: "Remote Go Engineer", URL: "https://eleduck.com/posts/1", Source: "eleduck"}}}
+	b := &stubJobFetcher{jobs: []model.Job{{ID: "jenkins_search-9", Title: "  Remote  GO Engineer ", URL: "https://eleduck.com/posts/1?utm=x", Source: "jenkins_search"}}}
+
+	multi := NewMultiFetcher(a, b)
+	jobs, err := multi.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected duplicate posting to collapse into 1 job, got %d", len(jobs))
+	}
+	if jobs[0].Sources["jenkins_search"] != "https://eleduck.com/posts/1?utm=x" {
+		t.Fatalf("expected jenkins_search recorded in Sources, got %+v", jobs[0].Sources)
+	}
+}
+
+func TestMultiFetcherPropagatesError(t *testing.T) {
+	t.Parallel()
+
+	a := &stubJobFetcher{jobs: []model.Job{{ID: "1"}}}
+	b := &stubJobFetcher{err: errors.New("boom")}
+
+	multi := NewMultiFetcher(a, b)
+	if _, err := multi.Fetch(context.Background()); err == nil {
+		t.Fatalf("expected error when a source fails")
+	}
+}
+
+type stubJobFetcher struct {
+	jobs []model.Job
+	err  error
+}
+
+func (s *stubJobFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
+	return s.jobs, s.err
+}
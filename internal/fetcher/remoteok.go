@@ -0,0 +1,120 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+// RemoteOKFetcher 抓取 RemoteOK 公开 JSON API（/api）。
+type RemoteOKFetcher struct {
+	baseURL string
+	client  *http.Client
+	cfg     Config
+	now     func() time.Time
+}
+
+// NewRemoteOKFetcher 创建 RemoteOK 抓取器，baseURL 形如 https://remoteok.com。
+func NewRemoteOKFetcher(baseURL string, cfg Config, client *http.Client) *RemoteOKFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if cfg.MaxAgeDays <= 0 {
+		cfg.MaxAgeDays = 30
+	}
+	return &RemoteOKFetcher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+func newRemoteOKFromSource(cfg SourceConfig, client *http.Client) (JobFetcher, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://remoteok.com"
+	}
+	fetchCfg := Config{MaxAgeDays: optionInt(cfg.Options, "max_age_days", 30)}
+	return NewRemoteOKFetcher(baseURL, fetchCfg, client), nil
+}
+
+type remoteOKPosting struct {
+	ID          string   `json:"id"`
+	Position    string   `json:"position"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Date        string   `json:"date"` // RFC3339
+	Tags        []string `json:"tags"`
+}
+
+// Fetch 调用 RemoteOK /api，跳过首个法律声明元素，按 MaxAgeDays 过滤。
+func (f *RemoteOKFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
+	endpoint := f.baseURL + "/api"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("User-Agent", "remote-radar")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal postings: %w", err)
+	}
+
+	cutoff := f.now().AddDate(0, 0, -f.cfg.MaxAgeDays)
+	jobs := make([]model.Job, 0, len(raw))
+	for i, item := range raw {
+		if i == 0 {
+			continue // 首个元素是 legal 声明，不是职位
+		}
+		var posting remoteOKPosting
+		if err := json.Unmarshal(item, &posting); err != nil || posting.ID == "" {
+			continue
+		}
+
+		publishedAt, err := time.Parse(time.RFC3339, posting.Date)
+		if err != nil || publishedAt.Before(cutoff) {
+			continue
+		}
+
+		tags := datatypes.JSONMap{}
+		for _, tag := range posting.Tags {
+			tags[tag] = true
+		}
+
+		jobs = append(jobs, model.Job{
+			ID:            "remoteok-" + posting.ID,
+			Title:         posting.Position,
+			Summary:       posting.Description,
+			PublishedAt:   publishedAt,
+			Source:        "remoteok",
+			URL:           posting.URL,
+			Tags:          tags,
+			RawAttributes: datatypes.JSONMap{"id": posting.ID, "position": posting.Position, "tags": posting.Tags},
+		})
+	}
+	return jobs, nil
+}
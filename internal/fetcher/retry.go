@@ -0,0 +1,38 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// doWithRetry 对 429/5xx 响应与 transport 错误进行指数退避加抖动重试，供各 fetcher 复用。
+// 仅适用于无请求体的请求（如 GET），因为失败后会用同一个 *http.Request 重新发送。
+func doWithRetry(ctx context.Context, client *http.Client, req *http.Request, maxRetries int) (*http.Response, error) {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	delay := defaultBaseDelay
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		resp, err = client.Do(req)
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if attempt >= maxRetries {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay + jitter()):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		delay *= 2
+	}
+}
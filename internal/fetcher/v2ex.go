@@ -0,0 +1,123 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+// V2EXFetcher 抓取 V2EX 指定节点（默认 jobs）下的话题列表。
+type V2EXFetcher struct {
+	baseURL string
+	node    string
+	client  *http.Client
+	cfg     Config
+	now     func() time.Time
+}
+
+// NewV2EXFetcher 创建 V2EX 抓取器，baseURL 形如 https://www.v2ex.com。
+func NewV2EXFetcher(baseURL, node string, cfg Config, client *http.Client) *V2EXFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if cfg.MaxAgeDays <= 0 {
+		cfg.MaxAgeDays = 30
+	}
+	if node == "" {
+		node = "jobs"
+	}
+	return &V2EXFetcher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		node:    node,
+		client:  client,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+func newV2EXFromSource(cfg SourceConfig, client *http.Client) (JobFetcher, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://www.v2ex.com"
+	}
+	fetchCfg := Config{MaxAgeDays: optionInt(cfg.Options, "max_age_days", 30)}
+	return NewV2EXFetcher(baseURL, cfg.Options["node"], fetchCfg, client), nil
+}
+
+type v2exTopic struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	URL     string `json:"url"`
+	Node    struct {
+		Title string `json:"title"`
+	} `json:"node"`
+	Created string `json:"created"` // unix 秒，字符串形式
+}
+
+// Fetch 调用 V2EX 节点话题 JSON API，按 MaxAgeDays 过滤。
+func (f *V2EXFetcher) Fetch(ctx context.Context) ([]model.Job, error) {
+	endpoint := fmt.Sprintf("%s/api/topics/show.json?node_name=%s", f.baseURL, f.node)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var topics []v2exTopic
+	if err := json.Unmarshal(body, &topics); err != nil {
+		return nil, fmt.Errorf("unmarshal topics: %w", err)
+	}
+
+	cutoff := f.now().AddDate(0, 0, -f.cfg.MaxAgeDays)
+	jobs := make([]model.Job, 0, len(topics))
+	for _, t := range topics {
+		created, err := strconv.ParseInt(t.Created, 10, 64)
+		if err != nil {
+			continue
+		}
+		publishedAt := time.Unix(created, 0).UTC()
+		if publishedAt.Before(cutoff) {
+			continue
+		}
+
+		jobs = append(jobs, model.Job{
+			ID:          fmt.Sprintf("v2ex-%d", t.ID),
+			Title:       t.Title,
+			Summary:     t.Content,
+			PublishedAt: publishedAt,
+			Source:      "v2ex",
+			URL:         t.URL,
+			Tags:        datatypes.JSONMap{t.Node.Title: true},
+			RawAttributes: datatypes.JSONMap{
+				"id":    t.ID,
+				"node":  t.Node.Title,
+				"url":   t.URL,
+				"title": t.Title,
+			},
+		})
+	}
+	return jobs, nil
+}
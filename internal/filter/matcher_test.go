@@ -0,0 +1,49 @@
+package filter
+
+import "testing"
+
+func TestAhoCorasickMatcherFindsKeywordCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	m := NewAhoCorasickMatcher([]string{"crypto scam", "传销"})
+
+	matched, kw := m.Match("Join our CRYPTO SCAM today")
+	if !matched || kw != "crypto scam" {
+		t.Fatalf("expected match on crypto scam, got matched=%v kw=%q", matched, kw)
+	}
+
+	matched, kw = m.Match("加入我们的传销组织")
+	if !matched || kw != "传销" {
+		t.Fatalf("expected match on 传销, got matched=%v kw=%q", matched, kw)
+	}
+}
+
+func TestAhoCorasickMatcherNoMatch(t *testing.T) {
+	t.Parallel()
+
+	m := NewAhoCorasickMatcher([]string{"scam"})
+	if matched, _ := m.Match("Remote Go backend engineer"); matched {
+		t.Fatalf("expected no match for clean text")
+	}
+}
+
+func TestAhoCorasickMatcherEmptyKeywordsNeverMatches(t *testing.T) {
+	t.Parallel()
+
+	m := NewAhoCorasickMatcher(nil)
+	if matched, _ := m.Match("anything at all"); matched {
+		t.Fatalf("expected no match when no keywords configured")
+	}
+}
+
+func TestServiceNilSafe(t *testing.T) {
+	t.Parallel()
+
+	var s *Service
+	if matched, _ := s.MatchTitle("whatever"); matched {
+		t.Fatalf("expected nil Service to never match")
+	}
+	if matched, _ := s.MatchContent("whatever"); matched {
+		t.Fatalf("expected nil Service to never match")
+	}
+}
@@ -0,0 +1,37 @@
+package filter
+
+// Config 配置两类敏感词列表：标题类（职位标题）与内容类（摘要、订阅邮箱本地名、标签）。
+type Config struct {
+	TitleSensitives   []string `yaml:"title_sensitives" json:"title_sensitives"`
+	ContentSensitives []string `yaml:"content_sensitives" json:"content_sensitives"`
+}
+
+// Service 组合标题与内容两个 Matcher，供订阅创建与职位抓取复用。
+type Service struct {
+	title   Matcher
+	content Matcher
+}
+
+// NewService 根据配置构建 Service，列表为空时对应 Matcher 不会命中任何文本。
+func NewService(cfg Config) *Service {
+	return &Service{
+		title:   NewAhoCorasickMatcher(cfg.TitleSensitives),
+		content: NewAhoCorasickMatcher(cfg.ContentSensitives),
+	}
+}
+
+// MatchTitle 检查标题类文本（如职位标题）是否命中敏感词。
+func (s *Service) MatchTitle(text string) (bool, string) {
+	if s == nil || s.title == nil {
+		return false, ""
+	}
+	return s.title.Match(text)
+}
+
+// MatchContent 检查内容类文本（如职位摘要、订阅邮箱本地名、标签）是否命中敏感词。
+func (s *Service) MatchContent(text string) (bool, string) {
+	if s == nil || s.content == nil {
+		return false, ""
+	}
+	return s.content.Match(text)
+}
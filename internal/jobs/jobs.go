@@ -0,0 +1,264 @@
+// Package jobs 提供一套类似 Mattermost 的 Worker/Scheduler/JobServer 编排框架，
+// 用于让抓取、通知等后台任务以独立节奏运行，并支持通过管理接口手动触发。
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+// Worker 包装一个可被调度执行的工作单元，例如抓取某个来源或发送订阅通知。
+type Worker interface {
+	// Type 返回任务类型，需与注册、触发时使用的类型一致。
+	Type() string
+	// Run 执行一次任务，data 为创建任务时传入的参数。
+	Run(ctx context.Context, data map[string]any) error
+}
+
+// Scheduler 决定某类任务下一次应该在什么时间运行。
+type Scheduler interface {
+	// NextScheduledTime 根据上一次运行的结束时间与状态，返回下一次应该触发的时间。
+	NextScheduledTime(lastRun time.Time, lastStatus model.JobRunStatus) time.Time
+}
+
+// IntervalScheduler 按固定间隔触发，不关心上一次运行成功与否。
+type IntervalScheduler struct {
+	Interval time.Duration
+}
+
+// NextScheduledTime 实现 Scheduler。
+func (s IntervalScheduler) NextScheduledTime(lastRun time.Time, _ model.JobRunStatus) time.Time {
+	if lastRun.IsZero() {
+		return time.Now()
+	}
+	return lastRun.Add(s.Interval)
+}
+
+// Store 抽象 JobServer 依赖的持久化操作，便于测试替换。
+type Store interface {
+	CreateJobRun(ctx context.Context, run *model.JobRun) error
+	ClaimPendingJobRun(ctx context.Context, types []string) (*model.JobRun, error)
+	FinishJobRun(ctx context.Context, id uint, status model.JobRunStatus, errMsg string) error
+	LatestJobRun(ctx context.Context, jobType string) (*model.JobRun, error)
+	StalledJobRuns(ctx context.Context, before time.Time) ([]model.JobRun, error)
+	RequeueJobRun(ctx context.Context, id uint) error
+}
+
+// Config 控制 JobServer 的轮询节奏、卡死判定阈值与卡死任务的重试上限；DisableSchedulers 为 true 时
+// 该实例只认领、执行已有任务（claimAndRun/recoverStalled 仍运行），不再根据 Scheduler 创建新任务，
+// 配合 `jobs.disable_schedulers` 配置可让调度只在指定的 leader 实例上触发，worker 则可部署在任意实例。
+type Config struct {
+	PollInterval      time.Duration
+	RunTimeout        time.Duration
+	StallThreshold    time.Duration
+	MaxRetries        int
+	DisableSchedulers bool
+}
+
+// JobServer 持有任务表，负责认领待执行任务、驱动已注册的 Scheduler 并回收卡死任务。
+type JobServer struct {
+	store   Store
+	cfg     Config
+	mu      sync.Mutex
+	workers map[string]Worker
+	scheds  map[string]Scheduler
+}
+
+// NewJobServer 创建 JobServer，未设置的配置项使用合理默认值。
+func NewJobServer(store Store, cfg Config) *JobServer {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.RunTimeout <= 0 {
+		cfg.RunTimeout = 30 * time.Second
+	}
+	if cfg.StallThreshold <= 0 {
+		cfg.StallThreshold = 5 * time.Minute
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 3
+	}
+	return &JobServer{
+		store:   store,
+		cfg:     cfg,
+		workers: make(map[string]Worker),
+		scheds:  make(map[string]Scheduler),
+	}
+}
+
+// RegisterWorker 注册一个 Worker，sched 非空时会由 JobServer 自动按其节奏创建任务。
+func (js *JobServer) RegisterWorker(w Worker, sched Scheduler) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	js.workers[w.Type()] = w
+	if sched != nil {
+		js.scheds[w.Type()] = sched
+	}
+}
+
+// Enqueue 手动创建一条待执行任务，供管理接口触发。
+func (js *JobServer) Enqueue(ctx context.Context, jobType string, data map[string]any) (*model.JobRun, error) {
+	js.mu.Lock()
+	_, ok := js.workers[jobType]
+	js.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown job type %s", jobType)
+	}
+
+	run := &model.JobRun{
+		Type:           jobType,
+		Status:         model.JobRunStatusPending,
+		Data:           toJSONMap(data),
+		LastActivityAt: time.Now(),
+	}
+	if err := js.store.CreateJobRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("enqueue job run: %w", err)
+	}
+	return run, nil
+}
+
+// Start 启动调度、认领与卡死回收循环，直到上下文取消。
+func (js *JobServer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(js.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if !js.cfg.DisableSchedulers {
+				js.scheduleDue(ctx)
+			}
+			js.recoverStalled(ctx)
+			js.claimAndRun(ctx)
+		}
+	}
+}
+
+func (js *JobServer) scheduleDue(ctx context.Context) {
+	js.mu.Lock()
+	scheds := make(map[string]Scheduler, len(js.scheds))
+	for t, s := range js.scheds {
+		scheds[t] = s
+	}
+	js.mu.Unlock()
+
+	for jobType, sched := range scheds {
+		last, err := js.store.LatestJobRun(ctx, jobType)
+		if err != nil {
+			log.Printf("jobs: latest run for %s: %v", jobType, err)
+			continue
+		}
+
+		var lastRun time.Time
+		var lastStatus model.JobRunStatus
+		if last != nil {
+			if last.Status == model.JobRunStatusPending || last.Status == model.JobRunStatusInProgress {
+				continue // 上一次尚未结束，暂不重复调度
+			}
+			if last.FinishedAt != nil {
+				lastRun = *last.FinishedAt
+			}
+			lastStatus = last.Status
+		}
+
+		if !sched.NextScheduledTime(lastRun, lastStatus).After(time.Now()) {
+			if _, err := js.Enqueue(ctx, jobType, nil); err != nil {
+				log.Printf("jobs: schedule %s: %v", jobType, err)
+			}
+		}
+	}
+}
+
+func (js *JobServer) recoverStalled(ctx context.Context) {
+	stalled, err := js.store.StalledJobRuns(ctx, time.Now().Add(-js.cfg.StallThreshold))
+	if err != nil {
+		log.Printf("jobs: list stalled runs: %v", err)
+		return
+	}
+	for _, run := range stalled {
+		if run.RetryCount+1 > js.cfg.MaxRetries {
+			if err := js.store.FinishJobRun(ctx, run.ID, model.JobRunStatusError, fmt.Sprintf("stalled: exceeded max retries (%d)", js.cfg.MaxRetries)); err != nil {
+				log.Printf("jobs: finish stalled run %d: %v", run.ID, err)
+			}
+			continue
+		}
+		if err := js.store.RequeueJobRun(ctx, run.ID); err != nil {
+			log.Printf("jobs: requeue stalled run %d: %v", run.ID, err)
+		}
+	}
+}
+
+func (js *JobServer) claimAndRun(ctx context.Context) {
+	js.mu.Lock()
+	types := make([]string, 0, len(js.workers))
+	for t := range js.workers {
+		types = append(types, t)
+	}
+	js.mu.Unlock()
+	if len(types) == 0 {
+		return
+	}
+
+	for {
+		run, err := js.store.ClaimPendingJobRun(ctx, types)
+		if err != nil {
+			log.Printf("jobs: claim pending run: %v", err)
+			return
+		}
+		if run == nil {
+			return
+		}
+		js.execute(ctx, run)
+	}
+}
+
+func (js *JobServer) execute(ctx context.Context, run *model.JobRun) {
+	js.mu.Lock()
+	worker := js.workers[run.Type]
+	js.mu.Unlock()
+	if worker == nil {
+		js.finish(ctx, run.ID, fmt.Errorf("no worker registered for type %s", run.Type))
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, js.cfg.RunTimeout)
+	defer cancel()
+
+	err := worker.Run(runCtx, fromJSONMap(run.Data))
+	js.finish(ctx, run.ID, err)
+}
+
+func (js *JobServer) finish(ctx context.Context, id uint, runErr error) {
+	status := model.JobRunStatusSuccess
+	msg := ""
+	if runErr != nil {
+		status = model.JobRunStatusError
+		msg = runErr.Error()
+	}
+	if err := js.store.FinishJobRun(ctx, id, status, msg); err != nil {
+		log.Printf("jobs: finish run %d: %v", id, err)
+	}
+}
+
+func toJSONMap(data map[string]any) datatypes.JSONMap {
+	if data == nil {
+		return nil
+	}
+	return datatypes.JSONMap(data)
+}
+
+func fromJSONMap(m datatypes.JSONMap) map[string]any {
+	if m == nil {
+		return nil
+	}
+	return map[string]any(m)
+}
@@ -0,0 +1,249 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+type stubWorker struct {
+	typ   string
+	calls int
+	err   error
+	mu    sync.Mutex
+}
+
+func (w *stubWorker) Type() string { return w.typ }
+
+func (w *stubWorker) Run(ctx context.Context, data map[string]any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.calls++
+	return w.err
+}
+
+type stubStore struct {
+	mu       sync.Mutex
+	nextID   uint
+	runs     map[uint]*model.JobRun
+	claimErr error
+}
+
+func newStubStore() *stubStore {
+	return &stubStore{runs: make(map[uint]*model.JobRun)}
+}
+
+func (s *stubStore) CreateJobRun(ctx context.Context, run *model.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	run.ID = s.nextID
+	stored := *run
+	s.runs[run.ID] = &stored
+	return nil
+}
+
+func (s *stubStore) ClaimPendingJobRun(ctx context.Context, types []string) (*model.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	allowed := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		allowed[t] = struct{}{}
+	}
+	for _, run := range s.runs {
+		if run.Status != model.JobRunStatusPending {
+			continue
+		}
+		if _, ok := allowed[run.Type]; !ok {
+			continue
+		}
+		run.Status = model.JobRunStatusInProgress
+		claimed := *run
+		return &claimed, nil
+	}
+	return nil, nil
+}
+
+func (s *stubStore) FinishJobRun(ctx context.Context, id uint, status model.JobRunStatus, errMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.runs[id]
+	if !ok {
+		return fmt.Errorf("unknown run %d", id)
+	}
+	run.Status = status
+	run.Error = errMsg
+	now := time.Now()
+	run.FinishedAt = &now
+	return nil
+}
+
+func (s *stubStore) LatestJobRun(ctx context.Context, jobType string) (*model.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest *model.JobRun
+	for _, run := range s.runs {
+		if run.Type != jobType {
+			continue
+		}
+		if latest == nil || run.ID > latest.ID {
+			latest = run
+		}
+	}
+	return latest, nil
+}
+
+func (s *stubStore) StalledJobRuns(ctx context.Context, before time.Time) ([]model.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var stalled []model.JobRun
+	for _, run := range s.runs {
+		if run.Status == model.JobRunStatusInProgress && run.LastActivityAt.Before(before) {
+			stalled = append(stalled, *run)
+		}
+	}
+	return stalled, nil
+}
+
+func (s *stubStore) RequeueJobRun(ctx context.Context, id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.runs[id]
+	if !ok {
+		return fmt.Errorf("unknown run %d", id)
+	}
+	run.Status = model.JobRunStatusPending
+	run.RetryCount++
+	run.StartedAt = nil
+	run.LastActivityAt = time.Now()
+	return nil
+}
+
+func TestJobServerEnqueueRejectsUnknownType(t *testing.T) {
+	t.Parallel()
+
+	js := NewJobServer(newStubStore(), Config{})
+	if _, err := js.Enqueue(context.Background(), "fetch", nil); err == nil {
+		t.Fatalf("expected error for unregistered worker type")
+	}
+}
+
+func TestJobServerClaimAndRunExecutesWorker(t *testing.T) {
+	t.Parallel()
+
+	store := newStubStore()
+	js := NewJobServer(store, Config{})
+	worker := &stubWorker{typ: "fetch"}
+	js.RegisterWorker(worker, nil)
+
+	run, err := js.Enqueue(context.Background(), "fetch", nil)
+	if err != nil {
+		t.Fatalf("Enqueue error: %v", err)
+	}
+
+	js.claimAndRun(context.Background())
+
+	worker.mu.Lock()
+	calls := worker.calls
+	worker.mu.Unlock()
+	if calls != 1 {
+		t.Fatalf("expected worker run once, got %d", calls)
+	}
+
+	stored := store.runs[run.ID]
+	if stored.Status != model.JobRunStatusSuccess {
+		t.Fatalf("expected run marked success, got %s", stored.Status)
+	}
+}
+
+func TestJobServerClaimAndRunRecordsWorkerError(t *testing.T) {
+	t.Parallel()
+
+	store := newStubStore()
+	js := NewJobServer(store, Config{})
+	worker := &stubWorker{typ: "notify", err: fmt.Errorf("boom")}
+	js.RegisterWorker(worker, nil)
+
+	run, err := js.Enqueue(context.Background(), "notify", nil)
+	if err != nil {
+		t.Fatalf("Enqueue error: %v", err)
+	}
+
+	js.claimAndRun(context.Background())
+
+	stored := store.runs[run.ID]
+	if stored.Status != model.JobRunStatusError || stored.Error == "" {
+		t.Fatalf("expected run marked error with message, got %+v", stored)
+	}
+}
+
+func TestJobServerScheduleDueCreatesRunWhenDue(t *testing.T) {
+	t.Parallel()
+
+	store := newStubStore()
+	js := NewJobServer(store, Config{})
+	worker := &stubWorker{typ: "fetch"}
+	js.RegisterWorker(worker, IntervalScheduler{Interval: time.Hour})
+
+	js.scheduleDue(context.Background())
+
+	if len(store.runs) != 1 {
+		t.Fatalf("expected one scheduled run, got %d", len(store.runs))
+	}
+}
+
+func TestJobServerScheduleDueSkipsWhilePreviousRunPending(t *testing.T) {
+	t.Parallel()
+
+	store := newStubStore()
+	js := NewJobServer(store, Config{})
+	worker := &stubWorker{typ: "fetch"}
+	js.RegisterWorker(worker, IntervalScheduler{Interval: time.Hour})
+
+	js.scheduleDue(context.Background())
+	js.scheduleDue(context.Background())
+
+	if len(store.runs) != 1 {
+		t.Fatalf("expected schedule to skip while previous run is pending, got %d runs", len(store.runs))
+	}
+}
+
+func TestJobServerRecoverStalledRequeuesWithinRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	store := newStubStore()
+	store.nextID = 1
+	store.runs[1] = &model.JobRun{ID: 1, Type: "fetch", Status: model.JobRunStatusInProgress, LastActivityAt: time.Now().Add(-time.Hour)}
+
+	js := NewJobServer(store, Config{StallThreshold: time.Minute, MaxRetries: 3})
+	js.recoverStalled(context.Background())
+
+	if store.runs[1].Status != model.JobRunStatusPending {
+		t.Fatalf("expected stalled run requeued as pending, got %s", store.runs[1].Status)
+	}
+	if store.runs[1].RetryCount != 1 {
+		t.Fatalf("expected retry count incremented to 1, got %d", store.runs[1].RetryCount)
+	}
+}
+
+func TestJobServerRecoverStalledMarksErrorAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	store := newStubStore()
+	store.nextID = 1
+	store.runs[1] = &model.JobRun{ID: 1, Type: "fetch", Status: model.JobRunStatusInProgress, RetryCount: 3, LastActivityAt: time.Now().Add(-time.Hour)}
+
+	js := NewJobServer(store, Config{StallThreshold: time.Minute, MaxRetries: 3})
+	js.recoverStalled(context.Background())
+
+	if store.runs[1].Status != model.JobRunStatusError {
+		t.Fatalf("expected stalled run marked error after exceeding max retries, got %s", store.runs[1].Status)
+	}
+}
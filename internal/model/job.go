@@ -1,16 +1,29 @@
 package model
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/datatypes"
 )
 
+// JobStatus 描述职位在源站的存续状态。
+const (
+	JobStatusOpen     = "open"
+	JobStatusArchived = "archived"
+)
+
 // Job 表示清洗后的最终职位数据
 // - NormalizedTags/SkillTags: LLM 归一化标签集合
 // - EmploymentType 等字段为结构化结果
 // - Score/Verdict: HR 评估得分与结论
 // - RawAttributes: 保留原始明细方便回溯
+// - Sources: 同一职位在多个来源站点重复发布时，fetcher.MultiFetcher 按标题+URL host canonical 化后
+//   collapse 成一条记录，这里记录各来源名到原始链接的映射
+// - Status/FirstSeenAt/LastSeenAt/DisappearedAt: 由 scheduler 在每次抓取后维护的存续状态，
+//   源站不再返回该职位达到宽限期后置为 archived，重新出现时自动回到 open（FirstSeenAt 不变）
+// - StaleWarnedAt: 记录是否已就长时间 open 发出过一次预警，避免重复打扰订阅者
+// - Age/DurationOpen: 不落库，由 Store.ListJobs 按 FirstSeenAt（及 DisappearedAt）计算得到的 H:MM 展示字段
 // - CreatedAt/UpdatedAt: 由 GORM 自动维护
 // 中文注释便于快速了解字段用途。
 type Job struct {
@@ -30,44 +43,248 @@ type Job struct {
 	LanguageRequirement string            `json:"language_requirement"`
 	Score               int               `json:"score"`
 	Verdict             string            `json:"verdict"`
+	Sources             datatypes.JSONMap `json:"sources,omitempty"`
+	Status              string            `gorm:"index;default:open" json:"status"`
+	FirstSeenAt         time.Time         `json:"first_seen_at"`
+	LastSeenAt          time.Time         `json:"last_seen_at"`
+	DisappearedAt       *time.Time        `json:"disappeared_at,omitempty"`
+	StaleWarnedAt       *time.Time        `json:"-"`
+	Age                 string            `gorm:"-" json:"age,omitempty"`
+	DurationOpen        string            `gorm:"-" json:"duration_open,omitempty"`
 	CreatedAt           time.Time         `json:"created_at"`
 	UpdatedAt           time.Time         `json:"updated_at"`
 }
 
+// FormatDuration 将耗时格式化为 H:MM 字符串（如 14 天 3 小时 -> "339:00"），供职位存续时长展示与
+// 到期预警通知复用同一种格式。
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	totalMinutes := int64(d / time.Minute)
+	return fmt.Sprintf("%d:%02d", totalMinutes/60, totalMinutes%60)
+}
+
 // RawJobStatus 描述原始数据的处理状态。
 type RawJobStatus string
 
 const (
-	RawJobStatusPending   RawJobStatus = "pending"
-	RawJobStatusProcessed RawJobStatus = "processed"
-	RawJobStatusRejected  RawJobStatus = "rejected"
+	RawJobStatusPending    RawJobStatus = "pending"
+	RawJobStatusProcessed  RawJobStatus = "processed"
+	RawJobStatusRejected   RawJobStatus = "rejected"
+	RawJobStatusDeadLetter RawJobStatus = "dead_letter"
 )
 
 // RawJob 存储抓取的原始职位内容，支持重新清洗回溯。
+// LeaseOwner/LeaseExpiresAt/AttemptCount 供 scheduler.Acquirer 实现基于数据库的分布式租约认领：
+// 多个 remote-radar 实例共享同一 Store 时，同一条 pending 记录同一时刻只会被一个 worker 持有租约。
 type RawJob struct {
-	ID          uint              `gorm:"primaryKey" json:"id"`
-	Source      string            `gorm:"uniqueIndex:idx_raw_source_external" json:"source"`
-	ExternalID  string            `gorm:"uniqueIndex:idx_raw_source_external" json:"external_id"`
-	Title       string            `json:"title"`
-	Summary     string            `json:"summary"`
-	Content     string            `json:"content"`
-	URL         string            `json:"url"`
-	Tags        datatypes.JSONMap `json:"tags"`
-	RawPayload  datatypes.JSONMap `json:"raw_payload"`
-	PublishedAt time.Time         `json:"published_at"`
-	Status      RawJobStatus      `json:"status"`
-	Reason      string            `json:"reason"`
-	LLMResponse datatypes.JSONMap `json:"llm_response"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	Source         string            `gorm:"uniqueIndex:idx_raw_source_external" json:"source"`
+	ExternalID     string            `gorm:"uniqueIndex:idx_raw_source_external" json:"external_id"`
+	Title          string            `json:"title"`
+	Summary        string            `json:"summary"`
+	Content        string            `json:"content"`
+	URL            string            `json:"url"`
+	Tags           datatypes.JSONMap `json:"tags"`
+	RawPayload     datatypes.JSONMap `json:"raw_payload"`
+	PublishedAt    time.Time         `json:"published_at"`
+	Status         RawJobStatus      `json:"status"`
+	Reason         string            `json:"reason"`
+	LLMResponse    datatypes.JSONMap `json:"llm_response"`
+	LeaseOwner     string            `gorm:"index" json:"lease_owner,omitempty"`
+	LeaseExpiresAt time.Time         `json:"lease_expires_at,omitempty"`
+	AttemptCount   int               `json:"attempt_count"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// RawJobEmbedding 持久化语义去重用的 Title+Summary 向量：Vector 为小端序 float32 blob，Norm 为其
+// L2 范数的预计算值，比较相似度时退化为点积扫描，避免每次重复开方。
+type RawJobEmbedding struct {
+	RawJobID  uint      `gorm:"primaryKey" json:"raw_job_id"`
+	Vector    []byte    `json:"-"`
+	Norm      float64   `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
+// SimilarRawJob 描述一次语义去重相似度查询命中的候选 RawJob 及其与查询向量的余弦相似度（0~1）。
+type SimilarRawJob struct {
+	RawJobID uint    `json:"raw_job_id"`
+	Score    float64 `json:"score"`
+}
+
+// SubscriptionStatus 描述订阅的生效状态。
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive SubscriptionStatus = "active"
+	SubscriptionStatusFrozen SubscriptionStatus = "frozen"
+)
+
 // Subscription 记录用户订阅偏好。
+// Target 为非邮件渠道（webhook/bark/server酱等）的投递目标，如 webhook URL 或推送 key。
+// FilterExpr 为可选的布尔表达式 DSL（如 "tag:backend AND NOT source:acme"），设置时优先于 Tags 生效；
+// 留空则回退到 Tags 做简单的与匹配，兼容历史订阅数据。
 type Subscription struct {
-	ID        uint              `gorm:"primaryKey" json:"id"`
-	Email     string            `json:"email"`
-	Channel   string            `json:"channel"`
-	Tags      datatypes.JSONMap `json:"tags"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID         uint               `gorm:"primaryKey" json:"id"`
+	Email      string             `json:"email"`
+	Channel    string             `json:"channel"`
+	Target     string             `json:"target"`
+	Tags       datatypes.JSONMap  `json:"tags"`
+	FilterExpr string             `json:"filter_expr"`
+	Status     SubscriptionStatus `json:"status"`
+	// FeedToken 用于个人化 RSS/Atom feed 的免鉴权访问令牌（/feeds/{token}.xml），创建时随机生成。
+	FeedToken string    `gorm:"uniqueIndex" json:"feed_token,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FilterViolation 记录一次敏感词命中，按邮箱统计用于触发自动冻结。
+type FilterViolation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Email     string    `gorm:"index" json:"email"`
+	Keyword   string    `json:"keyword"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NotificationOutboxStatus 描述通知投递记录的状态。
+type NotificationOutboxStatus string
+
+const (
+	NotificationOutboxStatusPending   NotificationOutboxStatus = "pending"
+	NotificationOutboxStatusDelivered NotificationOutboxStatus = "delivered"
+	NotificationOutboxStatusAbandoned NotificationOutboxStatus = "abandoned"
+)
+
+// NotificationOutbox 记录投递失败的通知，按退避策略等待重试，超过最大次数后放弃。
+type NotificationOutbox struct {
+	ID             uint                     `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint                     `gorm:"index" json:"subscription_id"`
+	Channel        string                   `json:"channel"`
+	Target         string                   `json:"target"`
+	Payload        datatypes.JSON           `json:"payload"`
+	Attempts       int                      `json:"attempts"`
+	LastError      string                   `json:"last_error"`
+	Status         NotificationOutboxStatus `gorm:"index" json:"status"`
+	NextAttemptAt  time.Time                `json:"next_attempt_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// PendingNotificationStatus 描述顶层通知批次的投递状态。
+type PendingNotificationStatus string
+
+const (
+	PendingNotificationStatusPending   PendingNotificationStatus = "pending"
+	PendingNotificationStatusDelivered PendingNotificationStatus = "delivered"
+	PendingNotificationStatusAbandoned PendingNotificationStatus = "abandoned"
+)
+
+// PendingNotification 记录 notifier.RetryingNotifier 投递失败的整批新增职位，
+// 供进程重启后恢复重试；JobIDs 便于排障定位，Payload 保留完整职位数据用于重新投递。
+// 与 NotificationOutbox 按订阅渠道粒度重试不同，这里的粒度是一次 Notify 调用整体成败。
+type PendingNotification struct {
+	ID        uint                      `gorm:"primaryKey" json:"id"`
+	JobIDs    datatypes.JSON            `json:"job_ids"`
+	Payload   datatypes.JSON            `json:"-"`
+	Attempts  int                       `json:"attempts"`
+	LastError string                    `json:"last_error"`
+	Status    PendingNotificationStatus `gorm:"index" json:"status"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+// JobRunStatus 描述 internal/jobs 中一次任务运行的生命周期状态。
+type JobRunStatus string
+
+const (
+	JobRunStatusPending    JobRunStatus = "pending"
+	JobRunStatusInProgress JobRunStatus = "in_progress"
+	JobRunStatusSuccess    JobRunStatus = "success"
+	JobRunStatusError      JobRunStatus = "error"
+	JobRunStatusCanceled   JobRunStatus = "canceled"
+)
+
+// JobRun 持久化一次后台任务的执行记录，供 jobs.JobServer 认领、执行与卡死检测。
+type JobRun struct {
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	Type           string            `gorm:"index" json:"type"`
+	Status         JobRunStatus      `gorm:"index" json:"status"`
+	Data           datatypes.JSONMap `json:"data"`
+	Progress       int               `json:"progress"`
+	Error          string            `json:"error"`
+	RetryCount     int               `json:"retry_count"`
+	StartedAt      *time.Time        `json:"started_at"`
+	FinishedAt     *time.Time        `json:"finished_at"`
+	LastActivityAt time.Time         `json:"last_activity_at"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// ScheduleRunStatus 描述 scheduler.Scheduler 一次 runOnce 执行的生命周期状态。
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunStatusRunning  ScheduleRunStatus = "running"
+	ScheduleRunStatusSuccess  ScheduleRunStatus = "success"
+	ScheduleRunStatusFailed   ScheduleRunStatus = "failed"
+	ScheduleRunStatusCanceled ScheduleRunStatus = "canceled"
+)
+
+// ScheduleRunTrigger 描述触发一次调度运行的来源。
+type ScheduleRunTrigger string
+
+const (
+	ScheduleRunTriggerCron   ScheduleRunTrigger = "cron"
+	ScheduleRunTriggerManual ScheduleRunTrigger = "manual"
+	ScheduleRunTriggerAPI    ScheduleRunTrigger = "api"
+)
+
+// ScheduleRun 持久化一次调度抓取/处理的执行记录，供 /api/jobs/runs 查询与排障。
+type ScheduleRun struct {
+	ID            uint               `gorm:"primaryKey" json:"id"`
+	JobName       string             `gorm:"index" json:"job_name"`
+	Status        ScheduleRunStatus  `gorm:"index" json:"status"`
+	TriggeredBy   ScheduleRunTrigger `json:"triggered_by"`
+	FetchedCount  int                `json:"fetched_count"`
+	AcceptedCount int                `json:"accepted_count"`
+	RejectedCount int                `json:"rejected_count"`
+	CreatedCount  int                `json:"created_count"`
+	Error         string             `json:"error"`
+	StartedAt     time.Time          `gorm:"index" json:"started_at"`
+	FinishedAt    *time.Time         `json:"finished_at"`
+}
+
+// ScheduleRunOutcome 记录一次调度运行中单条 RawJob 的处理结果，原因字符串来自 processor.Result。
+type ScheduleRunOutcome struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	ScheduleRunID uint         `gorm:"index" json:"schedule_run_id"`
+	RawJobID      uint         `json:"raw_job_id"`
+	Status        RawJobStatus `json:"status"`
+	Reason        string       `json:"reason"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// LLMCacheEntry 按 prompt 的 sha256 缓存一次 LLM 响应，重新处理同一条 RawJob（prompt 不变）时
+// processor.CachingClient 可直接命中而无需再次调用 LLM，Hash 为主键。
+type LLMCacheEntry struct {
+	Hash      string    `gorm:"primaryKey" json:"hash"`
+	Prompt    string    `json:"prompt"`
+	Response  string    `json:"response"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LLMUsageRecord 记录一次 LLM 调用的 token 用量、耗时与结果，供 /api/llm/usage 聚合展示，
+// 以及 processor.CachingClient 据此判断是否超出每日花费上限。
+type LLMUsageRecord struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	Provider         string    `gorm:"index" json:"provider"`
+	Model            string    `json:"model"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+	LatencyMS        int64     `json:"latency_ms"`
+	Outcome          string    `json:"outcome"`
+	CreatedAt        time.Time `gorm:"index" json:"created_at"`
 }
@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"remote-radar/internal/model"
+)
+
+// BarkConfig 配置 Bark 推送服务端地址，DeviceKey 由具体订阅提供。
+type BarkConfig struct {
+	ServerURL string `yaml:"server_url" json:"server_url"`
+	DeviceKey string `yaml:"device_key" json:"device_key"`
+}
+
+// BarkNotifier 通过 Bark 服务向 iOS 设备推送新增职位摘要。
+type BarkNotifier struct {
+	cfg    BarkConfig
+	client HTTPDoer
+}
+
+// NewBarkNotifier 创建 BarkNotifier，ServerURL 为空时使用官方默认服务器，client 为 nil 时使用 http.DefaultClient。
+func NewBarkNotifier(cfg BarkConfig, client HTTPDoer) *BarkNotifier {
+	if cfg.ServerURL == "" {
+		cfg.ServerURL = "https://api.day.app"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &BarkNotifier{cfg: cfg, client: client}
+}
+
+// Notify 将新增职位汇总为一条推送发送给 DeviceKey 对应的设备。
+func (n *BarkNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.cfg.DeviceKey == "" {
+		return fmt.Errorf("bark device key is empty")
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"title": fmt.Sprintf("发现 %d 个新职位", len(jobs)),
+		"body":  buildBody(jobs),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal bark payload: %w", err)
+	}
+
+	url := strings.TrimSuffix(n.cfg.ServerURL, "/") + "/" + n.cfg.DeviceKey
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post bark: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("bark responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBarkNotifierPostsToDeviceKey(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewBarkNotifier(BarkConfig{DeviceKey: "abc123"}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq == nil {
+		t.Fatal("expected request to be sent")
+	}
+	if !strings.HasSuffix(doer.lastReq.URL.String(), "/abc123") {
+		t.Fatalf("expected url to end with device key, got %s", doer.lastReq.URL.String())
+	}
+}
+
+func TestBarkNotifierRequiresDeviceKey(t *testing.T) {
+	t.Parallel()
+
+	n := NewBarkNotifier(BarkConfig{}, &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when device key is missing")
+	}
+}
+
+func TestBarkNotifierErrorsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusBadRequest}
+	n := NewBarkNotifier(BarkConfig{DeviceKey: "abc123"}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+}
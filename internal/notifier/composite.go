@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+
+	"remote-radar/internal/model"
+)
+
+// CompositeNotifier 按顺序调用多个 Notifier，任一返回错误即中断并向上传播。
+// 用于在同一次新增职位广播中同时触发多种通知/索引方式（如邮件 + ElasticSearch）。
+type CompositeNotifier struct {
+	notifiers []jobNotifier
+}
+
+// NewCompositeNotifier 创建 CompositeNotifier，nil 元素会被忽略。
+func NewCompositeNotifier(notifiers ...jobNotifier) *CompositeNotifier {
+	clean := make([]jobNotifier, 0, len(notifiers))
+	for _, n := range notifiers {
+		if n != nil {
+			clean = append(clean, n)
+		}
+	}
+	return &CompositeNotifier{notifiers: clean}
+}
+
+// Notify 依次调用每个子 Notifier。
+func (c *CompositeNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	for _, n := range c.notifiers {
+		if err := n.Notify(ctx, jobs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// Package dispatch 按订阅渠道路由新增职位通知，投递失败的记录写入 outbox 等待退避重试。
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"golang.org/x/time/rate"
+)
+
+// Notifier 是具体投递渠道（邮件/webhook/bark/server酱等）的最小实现接口。
+type Notifier interface {
+	Notify(ctx context.Context, jobs []model.Job) error
+}
+
+// Factory 根据订阅构造对应渠道的 Notifier，目标地址（URL/设备 key 等）来自订阅自身。
+type Factory func(sub model.Subscription) (Notifier, error)
+
+// OutboxStore 持久化投递失败的任务，供退避重试使用。
+type OutboxStore interface {
+	EnqueueOutbox(ctx context.Context, entry model.NotificationOutbox) error
+	DueOutboxEntries(ctx context.Context, before time.Time) ([]model.NotificationOutbox, error)
+	MarkOutboxDelivered(ctx context.Context, id uint) error
+	MarkOutboxFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastErr string) error
+	MarkOutboxAbandoned(ctx context.Context, id uint, lastErr string) error
+}
+
+const (
+	maxAttempts = 5
+	baseBackoff = time.Minute
+)
+
+// Dispatcher 按订阅渠道路由新增职位，投递失败时写入 outbox 等待重试。
+type Dispatcher struct {
+	factories map[string]Factory
+	outbox    OutboxStore
+	now       func() time.Time
+	limiters  map[string]*rate.Limiter
+}
+
+// NewDispatcher 创建 Dispatcher，outbox 为 nil 时投递失败将直接返回错误，不做持久化重试。
+func NewDispatcher(outbox OutboxStore) *Dispatcher {
+	return &Dispatcher{factories: make(map[string]Factory), outbox: outbox, now: time.Now}
+}
+
+// Register 注册渠道名（大小写不敏感）对应的 Notifier 工厂。
+func (d *Dispatcher) Register(channel string, factory Factory) {
+	d.factories[normalizeChannel(channel)] = factory
+}
+
+// SetChannelRateLimit 为指定渠道设置令牌桶限流（每秒请求数 qps 与突发量 burst），
+// 避免一次广播同时命中大量订阅时打满外部服务的速率限制；未设置的渠道不限流。
+func (d *Dispatcher) SetChannelRateLimit(channel string, qps float64, burst int) {
+	if d.limiters == nil {
+		d.limiters = make(map[string]*rate.Limiter)
+	}
+	d.limiters[normalizeChannel(channel)] = rate.NewLimiter(rate.Limit(qps), burst)
+}
+
+// Dispatch 按订阅 Tags 过滤职位后投递到各自渠道，未注册的渠道直接忽略。
+func (d *Dispatcher) Dispatch(ctx context.Context, subs []model.Subscription, jobs []model.Job) error {
+	for _, sub := range subs {
+		matches := FilterJobsBySubscription(sub, jobs)
+		if len(matches) == 0 {
+			continue
+		}
+		if err := d.deliver(ctx, sub, matches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DispatchOne 直接向单个订阅投递 jobs，跳过 Tags/FilterExpr 匹配，供测试通知等场景使用，
+// 此时调用方希望无视过滤条件也能确认渠道可达。
+func (d *Dispatcher) DispatchOne(ctx context.Context, sub model.Subscription, jobs []model.Job) error {
+	return d.deliver(ctx, sub, jobs)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sub model.Subscription, jobs []model.Job) error {
+	channel := normalizeChannel(sub.Channel)
+	factory, ok := d.factories[channel]
+	if !ok {
+		return nil
+	}
+
+	if limiter, ok := d.limiters[channel]; ok {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("rate limit wait for channel %s: %w", channel, err)
+		}
+	}
+
+	notifier, err := factory(sub)
+	if err != nil {
+		return fmt.Errorf("build %s notifier: %w", channel, err)
+	}
+
+	if err := notifier.Notify(ctx, jobs); err != nil {
+		return d.enqueue(ctx, sub, channel, jobs, err)
+	}
+	return nil
+}
+
+func (d *Dispatcher) enqueue(ctx context.Context, sub model.Subscription, channel string, jobs []model.Job, deliverErr error) error {
+	if d.outbox == nil {
+		return deliverErr
+	}
+
+	payload, err := json.Marshal(jobs)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+
+	entry := model.NotificationOutbox{
+		SubscriptionID: sub.ID,
+		Channel:        channel,
+		Target:         subscriptionTarget(sub),
+		Payload:        payload,
+		Attempts:       1,
+		LastError:      deliverErr.Error(),
+		Status:         model.NotificationOutboxStatusPending,
+		NextAttemptAt:  d.now().Add(baseBackoff),
+	}
+	if err := d.outbox.EnqueueOutbox(ctx, entry); err != nil {
+		return fmt.Errorf("enqueue outbox: %w", err)
+	}
+	return nil
+}
+
+// RetryDue 重新投递所有到期的 outbox 记录，超过最大重试次数的记录会被放弃且不再重试。
+func (d *Dispatcher) RetryDue(ctx context.Context) error {
+	if d.outbox == nil {
+		return nil
+	}
+
+	entries, err := d.outbox.DueOutboxEntries(ctx, d.now())
+	if err != nil {
+		return fmt.Errorf("list due outbox entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		var jobs []model.Job
+		if err := json.Unmarshal(entry.Payload, &jobs); err != nil {
+			_ = d.outbox.MarkOutboxAbandoned(ctx, entry.ID, fmt.Sprintf("corrupt payload: %v", err))
+			continue
+		}
+
+		factory, ok := d.factories[entry.Channel]
+		if !ok {
+			_ = d.outbox.MarkOutboxAbandoned(ctx, entry.ID, "channel not registered")
+			continue
+		}
+
+		sub := model.Subscription{ID: entry.SubscriptionID, Channel: entry.Channel, Email: entry.Target, Target: entry.Target}
+		notifier, err := factory(sub)
+		if err != nil {
+			attempts := entry.Attempts + 1
+			if attempts >= maxAttempts {
+				_ = d.outbox.MarkOutboxAbandoned(ctx, entry.ID, err.Error())
+				continue
+			}
+			_ = d.outbox.MarkOutboxFailed(ctx, entry.ID, attempts, d.now().Add(backoffFor(attempts)), err.Error())
+			continue
+		}
+
+		if err := notifier.Notify(ctx, jobs); err != nil {
+			attempts := entry.Attempts + 1
+			if attempts >= maxAttempts {
+				_ = d.outbox.MarkOutboxAbandoned(ctx, entry.ID, err.Error())
+				continue
+			}
+			_ = d.outbox.MarkOutboxFailed(ctx, entry.ID, attempts, d.now().Add(backoffFor(attempts)), err.Error())
+			continue
+		}
+
+		_ = d.outbox.MarkOutboxDelivered(ctx, entry.ID)
+	}
+	return nil
+}
+
+func backoffFor(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+func subscriptionTarget(sub model.Subscription) string {
+	if sub.Target != "" {
+		return sub.Target
+	}
+	return sub.Email
+}
+
+func normalizeChannel(channel string) string {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if channel == "" {
+		return "email"
+	}
+	return channel
+}
+
+// FilterJobsBySubscription 返回职位列表中满足订阅过滤条件的子集：FilterExpr 非空时按表达式树求值，
+// 否则回退到旧版 Tags 做简单的与匹配（未设置 Tags 时视为全部匹配），以兼容历史订阅数据。
+func FilterJobsBySubscription(sub model.Subscription, jobs []model.Job) []model.Job {
+	expr, err := ParseFilterExpr(sub.FilterExpr)
+	if err != nil {
+		// 表达式非法时保持稳健：忽略它并回退到 Tags，避免一次配置错误导致整条订阅完全失效。
+		expr = nil
+	}
+	if expr == nil && len(sub.Tags) == 0 {
+		return jobs
+	}
+
+	now := time.Now()
+	filtered := make([]model.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if expr != nil {
+			if expr.Eval(job, now) {
+				filtered = append(filtered, job)
+			}
+			continue
+		}
+		if jobMatches(job, sub.Tags) {
+			filtered = append(filtered, job)
+		}
+	}
+	return filtered
+}
+
+func jobMatches(job model.Job, tags map[string]any) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	if job.NormalizedTags == nil {
+		return false
+	}
+	for k, v := range tags {
+		if !isTruthy(v) {
+			continue
+		}
+		if !isTruthy(job.NormalizedTags[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTruthy(v any) bool {
+	switch val := v.(type) {
+	case bool:
+		return val
+	case string:
+		return strings.TrimSpace(strings.ToLower(val)) == "true"
+	case float64:
+		return val != 0
+	default:
+		return val != nil
+	}
+}
@@ -0,0 +1,261 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+var errBoom = errors.New("boom")
+
+type stubNotifier struct {
+	calls int
+	jobs  []model.Job
+	err   error
+}
+
+func (s *stubNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	s.calls++
+	s.jobs = jobs
+	return s.err
+}
+
+type stubOutboxStore struct {
+	entries   []model.NotificationOutbox
+	delivered []uint
+	failed    []uint
+	abandoned []uint
+	nextID    uint
+}
+
+func (s *stubOutboxStore) EnqueueOutbox(ctx context.Context, entry model.NotificationOutbox) error {
+	s.nextID++
+	entry.ID = s.nextID
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *stubOutboxStore) DueOutboxEntries(ctx context.Context, before time.Time) ([]model.NotificationOutbox, error) {
+	var due []model.NotificationOutbox
+	for _, e := range s.entries {
+		if e.Status == model.NotificationOutboxStatusPending && !e.NextAttemptAt.After(before) {
+			due = append(due, e)
+		}
+	}
+	return due, nil
+}
+
+func (s *stubOutboxStore) MarkOutboxDelivered(ctx context.Context, id uint) error {
+	s.delivered = append(s.delivered, id)
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries[i].Status = model.NotificationOutboxStatusDelivered
+		}
+	}
+	return nil
+}
+
+func (s *stubOutboxStore) MarkOutboxFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	s.failed = append(s.failed, id)
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries[i].Attempts = attempts
+			s.entries[i].NextAttemptAt = nextAttemptAt
+			s.entries[i].LastError = lastErr
+		}
+	}
+	return nil
+}
+
+func (s *stubOutboxStore) MarkOutboxAbandoned(ctx context.Context, id uint, lastErr string) error {
+	s.abandoned = append(s.abandoned, id)
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries[i].Status = model.NotificationOutboxStatusAbandoned
+			s.entries[i].LastError = lastErr
+		}
+	}
+	return nil
+}
+
+func TestDispatchRoutesByChannel(t *testing.T) {
+	t.Parallel()
+
+	emailN := &stubNotifier{}
+	webhookN := &stubNotifier{}
+	d := NewDispatcher(nil)
+	d.Register("email", func(sub model.Subscription) (Notifier, error) { return emailN, nil })
+	d.Register("webhook", func(sub model.Subscription) (Notifier, error) { return webhookN, nil })
+
+	subs := []model.Subscription{
+		{ID: 1, Channel: "email"},
+		{ID: 2, Channel: "webhook", Target: "https://example.com/hook"},
+	}
+	jobs := []model.Job{{ID: "1", Title: "Go Engineer"}}
+
+	if err := d.Dispatch(context.Background(), subs, jobs); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if emailN.calls != 1 || webhookN.calls != 1 {
+		t.Fatalf("expected both channels to be notified once, email=%d webhook=%d", emailN.calls, webhookN.calls)
+	}
+}
+
+func TestDispatchIgnoresUnregisteredChannel(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher(nil)
+	subs := []model.Subscription{{ID: 1, Channel: "slack"}}
+	jobs := []model.Job{{ID: "1", Title: "Go Engineer"}}
+
+	if err := d.Dispatch(context.Background(), subs, jobs); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+}
+
+func TestDispatchFiltersByTags(t *testing.T) {
+	t.Parallel()
+
+	n := &stubNotifier{}
+	d := NewDispatcher(nil)
+	d.Register("email", func(sub model.Subscription) (Notifier, error) { return n, nil })
+
+	subs := []model.Subscription{{ID: 1, Channel: "email", Tags: datatypes.JSONMap{"backend": true}}}
+	jobs := []model.Job{
+		{ID: "1", Title: "Backend job", NormalizedTags: datatypes.JSONMap{"backend": true}},
+		{ID: "2", Title: "Frontend job", NormalizedTags: datatypes.JSONMap{"frontend": true}},
+	}
+
+	if err := d.Dispatch(context.Background(), subs, jobs); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if len(n.jobs) != 1 || n.jobs[0].ID != "1" {
+		t.Fatalf("expected only matching job to be delivered, got %+v", n.jobs)
+	}
+}
+
+func TestDispatchAppliesChannelRateLimit(t *testing.T) {
+	t.Parallel()
+
+	n := &stubNotifier{}
+	d := NewDispatcher(nil)
+	d.Register("webhook", func(sub model.Subscription) (Notifier, error) { return n, nil })
+	d.SetChannelRateLimit("webhook", 0, 1)
+
+	subs := []model.Subscription{{ID: 1, Channel: "webhook", Target: "https://example.com/hook"}}
+	jobs := []model.Job{{ID: "1", Title: "Go Engineer"}}
+
+	if err := d.Dispatch(context.Background(), subs, jobs); err != nil {
+		t.Fatalf("first dispatch should consume the burst token without blocking: %v", err)
+	}
+	if n.calls != 1 {
+		t.Fatalf("expected notifier called once, got %d", n.calls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := d.Dispatch(ctx, subs, jobs); err == nil {
+		t.Fatal("expected second dispatch to fail once the rate limiter's burst is exhausted and refill rate is zero")
+	}
+	if n.calls != 1 {
+		t.Fatalf("expected notifier to not be called again while rate-limited, got %d", n.calls)
+	}
+}
+
+func TestDispatchEnqueuesOutboxOnFailure(t *testing.T) {
+	t.Parallel()
+
+	outbox := &stubOutboxStore{}
+	d := NewDispatcher(outbox)
+	d.Register("webhook", func(sub model.Subscription) (Notifier, error) {
+		return &stubNotifier{err: errBoom}, nil
+	})
+
+	subs := []model.Subscription{{ID: 5, Channel: "webhook", Target: "https://example.com/hook"}}
+	jobs := []model.Job{{ID: "1", Title: "Go Engineer"}}
+
+	if err := d.Dispatch(context.Background(), subs, jobs); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if len(outbox.entries) != 1 {
+		t.Fatalf("expected one outbox entry, got %d", len(outbox.entries))
+	}
+	if outbox.entries[0].SubscriptionID != 5 || outbox.entries[0].Channel != "webhook" {
+		t.Fatalf("unexpected outbox entry: %+v", outbox.entries[0])
+	}
+}
+
+func TestDispatchWithoutOutboxReturnsError(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher(nil)
+	d.Register("webhook", func(sub model.Subscription) (Notifier, error) {
+		return &stubNotifier{err: errBoom}, nil
+	})
+
+	subs := []model.Subscription{{ID: 5, Channel: "webhook", Target: "https://example.com/hook"}}
+	jobs := []model.Job{{ID: "1", Title: "Go Engineer"}}
+
+	if err := d.Dispatch(context.Background(), subs, jobs); err == nil {
+		t.Fatal("expected error when no outbox is configured")
+	}
+}
+
+func TestRetryDueRedeliversAndMarksDelivered(t *testing.T) {
+	t.Parallel()
+
+	n := &stubNotifier{}
+	outbox := &stubOutboxStore{}
+	d := NewDispatcher(outbox)
+	d.Register("webhook", func(sub model.Subscription) (Notifier, error) { return n, nil })
+
+	failing := &stubNotifier{err: errBoom}
+	d.factories["webhook"] = func(sub model.Subscription) (Notifier, error) { return failing, nil }
+	subs := []model.Subscription{{ID: 5, Channel: "webhook", Target: "https://example.com/hook"}}
+	jobs := []model.Job{{ID: "1", Title: "Go Engineer"}}
+	if err := d.Dispatch(context.Background(), subs, jobs); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+
+	// 重新注册为成功的 notifier，模拟下一次重试时目标服务恢复；同时把 now 拨到 baseBackoff 之后，
+	// 让 enqueue 写入的 NextAttemptAt 进入 DueOutboxEntries 的可重试窗口。
+	d.factories["webhook"] = func(sub model.Subscription) (Notifier, error) { return n, nil }
+	d.now = func() time.Time { return time.Now().Add(baseBackoff + time.Second) }
+	if err := d.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue returned error: %v", err)
+	}
+	if n.calls != 1 {
+		t.Fatalf("expected redelivery to call notifier once, got %d", n.calls)
+	}
+	if len(outbox.delivered) != 1 {
+		t.Fatalf("expected outbox entry to be marked delivered, got %d", len(outbox.delivered))
+	}
+}
+
+func TestRetryDueAbandonsAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	outbox := &stubOutboxStore{entries: []model.NotificationOutbox{{
+		ID:            1,
+		Channel:       "webhook",
+		Payload:       []byte(`[{"id":"1","title":"Go Engineer"}]`),
+		Attempts:      maxAttempts - 1,
+		Status:        model.NotificationOutboxStatusPending,
+		NextAttemptAt: time.Now().Add(-time.Minute),
+	}}}
+	d := NewDispatcher(outbox)
+	d.Register("webhook", func(sub model.Subscription) (Notifier, error) { return &stubNotifier{err: errBoom}, nil })
+
+	if err := d.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue returned error: %v", err)
+	}
+	if len(outbox.abandoned) != 1 {
+		t.Fatalf("expected entry to be abandoned after max attempts, got %d", len(outbox.abandoned))
+	}
+}
+
@@ -0,0 +1,384 @@
+package dispatch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"remote-radar/internal/model"
+)
+
+// ExprKind 标识过滤表达式节点的类型。
+type ExprKind string
+
+const (
+	ExprAnd             ExprKind = "and"
+	ExprOr              ExprKind = "or"
+	ExprNot             ExprKind = "not"
+	ExprTagEq           ExprKind = "tag_eq"
+	ExprTagIn           ExprKind = "tag_in"
+	ExprTitleRegex      ExprKind = "title_regex"
+	ExprSummaryRegex    ExprKind = "summary_regex"
+	ExprSourceEq        ExprKind = "source_eq"
+	ExprPublishedWithin ExprKind = "published_within"
+)
+
+// Expr 是订阅过滤表达式树的一个节点：内部节点（and/or/not）组合子节点，叶子节点携带匹配参数。
+type Expr struct {
+	Kind     ExprKind
+	Tag      string
+	Tags     []string
+	Source   string
+	Pattern  string
+	Within   time.Duration
+	Children []*Expr
+
+	regex *regexp.Regexp
+}
+
+// Eval 判断 job 是否满足该表达式，now 用于计算 published_within。nil 表达式视为恒真。
+func (e *Expr) Eval(job model.Job, now time.Time) bool {
+	if e == nil {
+		return true
+	}
+	switch e.Kind {
+	case ExprAnd:
+		for _, c := range e.Children {
+			if !c.Eval(job, now) {
+				return false
+			}
+		}
+		return true
+	case ExprOr:
+		for _, c := range e.Children {
+			if c.Eval(job, now) {
+				return true
+			}
+		}
+		return false
+	case ExprNot:
+		if len(e.Children) == 0 {
+			return false
+		}
+		return !e.Children[0].Eval(job, now)
+	case ExprTagEq:
+		return tagPresent(job, e.Tag)
+	case ExprTagIn:
+		for _, tag := range e.Tags {
+			if tagPresent(job, tag) {
+				return true
+			}
+		}
+		return false
+	case ExprSourceEq:
+		return strings.EqualFold(job.Source, e.Source)
+	case ExprTitleRegex:
+		return e.regex != nil && e.regex.MatchString(job.Title)
+	case ExprSummaryRegex:
+		return e.regex != nil && e.regex.MatchString(job.Summary)
+	case ExprPublishedWithin:
+		if job.PublishedAt.IsZero() {
+			return false
+		}
+		return now.Sub(job.PublishedAt) <= e.Within
+	default:
+		return false
+	}
+}
+
+// tagPresent 判断 job 是否命中 tag：优先查 NormalizedTags，兼容性地再查一遍 RawAttributes。
+func tagPresent(job model.Job, tag string) bool {
+	if isTruthy(job.NormalizedTags[tag]) {
+		return true
+	}
+	return isTruthy(job.RawAttributes[tag])
+}
+
+// ParseFilterExpr 将 DSL 字符串解析为过滤表达式树，叶子语法：
+//
+//	tag:backend                 单个标签命中（tag_eq）
+//	tag_in:(backend,devops)     任一标签命中（tag_in）
+//	title:/golang/i             标题正则，支持 i 忽略大小写标志，按子串匹配，整串匹配需自行写 ^...$
+//	summary:/golang/i           摘要正则，规则同上
+//	source:acme                 来源精确匹配（大小写不敏感）
+//	published_within:72h        发布时间在 now 之前的时长窗口内
+//
+// 叶子之间可用 AND/OR/NOT 与括号组合，例如：
+//
+//	tag:backend AND (title:/golang/i OR title:/go/i) AND NOT source:acme
+//
+// 空字符串返回 (nil, nil)，表示未设置表达式，调用方应回退到旧版 Tags 匹配。
+func ParseFilterExpr(dsl string) (*Expr, error) {
+	dsl = strings.TrimSpace(dsl)
+	if dsl == "" {
+		return nil, nil
+	}
+	p := &exprParser{tokens: tokenizeFilterExpr(dsl)}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected trailing token %q", p.peek())
+	}
+	return expr, nil
+}
+
+type exprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *exprParser) parseOr() (*Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []*Expr{left}
+	for strings.EqualFold(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &Expr{Kind: ExprOr, Children: children}, nil
+}
+
+func (p *exprParser) parseAnd() (*Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	children := []*Expr{left}
+	for strings.EqualFold(p.peek(), "AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &Expr{Kind: ExprAnd, Children: children}, nil
+}
+
+func (p *exprParser) parseNot() (*Expr, error) {
+	if strings.EqualFold(p.peek(), "NOT") {
+		p.next()
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Expr{Kind: ExprNot, Children: []*Expr{child}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (*Expr, error) {
+	tok := p.peek()
+	if tok == "" {
+		return nil, fmt.Errorf("unexpected end of filter expression")
+	}
+	if tok == "(" {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("expected ) in filter expression")
+		}
+		p.next()
+		return inner, nil
+	}
+	p.next()
+	return parseLeaf(tok)
+}
+
+func parseLeaf(tok string) (*Expr, error) {
+	idx := strings.Index(tok, ":")
+	if idx < 0 {
+		return nil, fmt.Errorf("invalid filter expression %q: missing ':'", tok)
+	}
+	key := strings.ToLower(tok[:idx])
+	value := tok[idx+1:]
+	switch key {
+	case "tag":
+		if value == "" {
+			return nil, fmt.Errorf("tag expression requires a value")
+		}
+		return &Expr{Kind: ExprTagEq, Tag: value}, nil
+	case "tag_in":
+		values, err := parseTagList(value)
+		if err != nil {
+			return nil, fmt.Errorf("tag_in expression: %w", err)
+		}
+		return &Expr{Kind: ExprTagIn, Tags: values}, nil
+	case "source":
+		if value == "" {
+			return nil, fmt.Errorf("source expression requires a value")
+		}
+		return &Expr{Kind: ExprSourceEq, Source: value}, nil
+	case "title":
+		return compileRegexLeaf(ExprTitleRegex, value)
+	case "summary":
+		return compileRegexLeaf(ExprSummaryRegex, value)
+	case "published_within":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("published_within expression: %w", err)
+		}
+		return &Expr{Kind: ExprPublishedWithin, Within: d}, nil
+	default:
+		return nil, fmt.Errorf("unknown filter key %q", key)
+	}
+}
+
+func parseTagList(value string) ([]string, error) {
+	if len(value) < 2 || value[0] != '(' || value[len(value)-1] != ')' {
+		return nil, fmt.Errorf("expected a (tag,tag,...) list, got %q", value)
+	}
+	inner := value[1 : len(value)-1]
+	parts := strings.Split(inner, ",")
+	tags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tags = append(tags, part)
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("list must contain at least one tag")
+	}
+	return tags, nil
+}
+
+// compileRegexLeaf 解析 /pattern/flags 正则字面量，按标准 regexp.MatchString 语义做子串匹配
+// （与 grep 一致），调用方想要整串匹配时自行在 pattern 里写 ^...$ 锚定。
+func compileRegexLeaf(kind ExprKind, value string) (*Expr, error) {
+	if len(value) < 2 || value[0] != '/' {
+		return nil, fmt.Errorf("expected a /regex/ literal, got %q", value)
+	}
+	closing := strings.LastIndex(value, "/")
+	if closing <= 0 {
+		return nil, fmt.Errorf("unterminated regex literal %q", value)
+	}
+	pattern := value[1:closing]
+	flags := value[closing+1:]
+	for _, f := range flags {
+		if f != 'i' {
+			return nil, fmt.Errorf("unsupported regex flag %q", string(f))
+		}
+	}
+	if strings.Contains(flags, "i") {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile regex %q: %w", pattern, err)
+	}
+	return &Expr{Kind: kind, Pattern: pattern, regex: re}, nil
+}
+
+// tokenizeFilterExpr 将 DSL 切分为 token：括号各自独立成 token，/regex/flags 字面量与
+// tag_in 的 (a,b,c) 列表作为叶子 token 的一部分整体保留。
+func tokenizeFilterExpr(input string) []string {
+	runes := []rune(input)
+	n := len(runes)
+	var tokens []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	i := 0
+	for i < n {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+			i++
+		case r == '(' && cur.Len() == 0:
+			tokens = append(tokens, "(")
+			i++
+		case r == ')' && cur.Len() == 0:
+			tokens = append(tokens, ")")
+			i++
+		case r == '(':
+			depth := 0
+			for i < n {
+				cur.WriteRune(runes[i])
+				if runes[i] == '(' {
+					depth++
+				} else if runes[i] == ')' {
+					depth--
+					if depth == 0 {
+						i++
+						break
+					}
+				}
+				i++
+			}
+		case r == '/':
+			cur.WriteRune(r)
+			i++
+			for i < n {
+				c := runes[i]
+				if c == '\\' && i+1 < n {
+					cur.WriteRune(c)
+					i++
+					cur.WriteRune(runes[i])
+					i++
+					continue
+				}
+				cur.WriteRune(c)
+				i++
+				if c == '/' {
+					break
+				}
+			}
+			for i < n && unicode.IsLetter(runes[i]) {
+				cur.WriteRune(runes[i])
+				i++
+			}
+			// 正则字面量后紧跟右括号时（如 "(title:/go/i)"）没有空格可触发 flush，
+			// 这里显式把 ")" 拆成独立 token，否则它会被 default 分支并入正则 token 本身。
+			if i < n && runes[i] == ')' {
+				flush()
+				tokens = append(tokens, ")")
+				i++
+			}
+		default:
+			cur.WriteRune(r)
+			i++
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,232 @@
+package dispatch
+
+import (
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+func TestParseFilterExprEmptyReturnsNil(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("  ")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+	if expr != nil {
+		t.Fatalf("expected nil expression for empty DSL, got %+v", expr)
+	}
+}
+
+func TestParseFilterExprRejectsUnknownKey(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseFilterExpr("role:backend"); err == nil {
+		t.Fatal("expected error for unknown filter key")
+	}
+}
+
+func TestParseFilterExprRejectsUnbalancedParens(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseFilterExpr("(tag:backend"); err == nil {
+		t.Fatal("expected error for unbalanced parentheses")
+	}
+}
+
+func TestExprEvalOperatorPrecedence(t *testing.T) {
+	t.Parallel()
+
+	// AND 的优先级高于 OR：等价于 (tag:backend) OR (tag:devops AND source:acme)。
+	expr, err := ParseFilterExpr("tag:backend OR tag:devops AND source:acme")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Now()
+	backendOnly := model.Job{Source: "other", NormalizedTags: datatypes.JSONMap{"backend": true}}
+	if !expr.Eval(backendOnly, now) {
+		t.Fatal("expected backend-tagged job to match regardless of source")
+	}
+
+	devopsWrongSource := model.Job{Source: "other", NormalizedTags: datatypes.JSONMap{"devops": true}}
+	if expr.Eval(devopsWrongSource, now) {
+		t.Fatal("expected devops job from a non-acme source to not match")
+	}
+
+	devopsAcme := model.Job{Source: "acme", NormalizedTags: datatypes.JSONMap{"devops": true}}
+	if !expr.Eval(devopsAcme, now) {
+		t.Fatal("expected devops job from acme to match")
+	}
+}
+
+func TestExprEvalGroupingAndNot(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("tag:backend AND (title:/golang/i OR title:/go/i) AND NOT source:acme")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Now()
+	matches := model.Job{Source: "other", Title: "Senior Golang Engineer", NormalizedTags: datatypes.JSONMap{"backend": true}}
+	if !expr.Eval(matches, now) {
+		t.Fatal("expected job to match tag+title+source combination")
+	}
+
+	wrongSource := matches
+	wrongSource.Source = "acme"
+	if expr.Eval(wrongSource, now) {
+		t.Fatal("expected NOT source:acme to exclude jobs sourced from acme")
+	}
+
+	noTitleMatch := matches
+	noTitleMatch.Title = "Senior Rust Engineer"
+	if expr.Eval(noTitleMatch, now) {
+		t.Fatal("expected job whose title matches neither regex to be excluded")
+	}
+}
+
+func TestExprEvalRegexMatchesSubstringByDefault(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("title:/golang/")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Now()
+	exact := model.Job{Title: "golang"}
+	if !expr.Eval(exact, now) {
+		t.Fatal("expected exact match to satisfy the regex")
+	}
+
+	substring := model.Job{Title: "Senior golang Engineer"}
+	if !expr.Eval(substring, now) {
+		t.Fatal("expected the regex to match a job title containing the pattern as a substring")
+	}
+
+	noMatch := model.Job{Title: "Senior Rust Engineer"}
+	if expr.Eval(noMatch, now) {
+		t.Fatal("expected a title without the pattern to not match")
+	}
+}
+
+func TestExprEvalRegexCanBeExplicitlyAnchored(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("title:/^golang$/i")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Now()
+	exact := model.Job{Title: "Golang"}
+	if !expr.Eval(exact, now) {
+		t.Fatal("expected exact match to satisfy an explicitly anchored regex")
+	}
+
+	substring := model.Job{Title: "Senior Golang Engineer"}
+	if expr.Eval(substring, now) {
+		t.Fatal("expected an explicitly anchored regex to reject a title containing the pattern only as a substring")
+	}
+}
+
+func TestExprEvalTagInMatchesAnyListedTag(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("tag_in:(backend,devops)")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Now()
+	devops := model.Job{NormalizedTags: datatypes.JSONMap{"devops": true}}
+	if !expr.Eval(devops, now) {
+		t.Fatal("expected tag_in to match a job carrying any of the listed tags")
+	}
+
+	frontend := model.Job{NormalizedTags: datatypes.JSONMap{"frontend": true}}
+	if expr.Eval(frontend, now) {
+		t.Fatal("expected tag_in to reject a job with none of the listed tags")
+	}
+}
+
+func TestExprEvalSupportsUnicodeTagNames(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("tag:远程工作")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Now()
+	remote := model.Job{NormalizedTags: datatypes.JSONMap{"远程工作": true}}
+	if !expr.Eval(remote, now) {
+		t.Fatal("expected unicode tag name to match")
+	}
+
+	other := model.Job{NormalizedTags: datatypes.JSONMap{"驻场": true}}
+	if expr.Eval(other, now) {
+		t.Fatal("expected a job without the unicode tag to not match")
+	}
+}
+
+func TestExprEvalPublishedWithin(t *testing.T) {
+	t.Parallel()
+
+	expr, err := ParseFilterExpr("published_within:24h")
+	if err != nil {
+		t.Fatalf("ParseFilterExpr error: %v", err)
+	}
+
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	fresh := model.Job{PublishedAt: now.Add(-time.Hour)}
+	if !expr.Eval(fresh, now) {
+		t.Fatal("expected a job published within the window to match")
+	}
+
+	stale := model.Job{PublishedAt: now.Add(-48 * time.Hour)}
+	if expr.Eval(stale, now) {
+		t.Fatal("expected a job published outside the window to not match")
+	}
+}
+
+func TestFilterJobsBySubscriptionPrefersFilterExprOverTags(t *testing.T) {
+	t.Parallel()
+
+	sub := model.Subscription{
+		FilterExpr: "tag:devops",
+		Tags:       datatypes.JSONMap{"backend": true},
+	}
+	jobs := []model.Job{
+		{ID: "1", NormalizedTags: datatypes.JSONMap{"backend": true}},
+		{ID: "2", NormalizedTags: datatypes.JSONMap{"devops": true}},
+	}
+
+	got := FilterJobsBySubscription(sub, jobs)
+	if len(got) != 1 || got[0].ID != "2" {
+		t.Fatalf("expected FilterExpr to take precedence over Tags, got %+v", got)
+	}
+}
+
+func TestFilterJobsBySubscriptionFallsBackToTagsOnInvalidExpr(t *testing.T) {
+	t.Parallel()
+
+	sub := model.Subscription{
+		FilterExpr: "tag:",
+		Tags:       datatypes.JSONMap{"backend": true},
+	}
+	jobs := []model.Job{
+		{ID: "1", NormalizedTags: datatypes.JSONMap{"backend": true}},
+		{ID: "2", NormalizedTags: datatypes.JSONMap{"frontend": true}},
+	}
+
+	got := FilterJobsBySubscription(sub, jobs)
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("expected invalid FilterExpr to fall back to Tags matching, got %+v", got)
+	}
+}
@@ -2,6 +2,7 @@ package notifier
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/smtp"
 	"strings"
@@ -20,12 +21,20 @@ type EmailConfig struct {
 	Subject  string   `yaml:"subject" json:"subject"`
 }
 
+// EmailAttachment 表示邮件附件。
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
 // EmailMessage 表示一封邮件。
 type EmailMessage struct {
-	From    string
-	To      []string
-	Subject string
-	Body    string
+	From       string
+	To         []string
+	Subject    string
+	Body       string
+	Attachment *EmailAttachment
 }
 
 // EmailSender 抽象发送接口，便于测试替换。
@@ -100,7 +109,32 @@ func buildEmailData(msg EmailMessage) string {
 	b.WriteString(fmt.Sprintf("From: %s\r\n", msg.From))
 	b.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(msg.To, ",")))
 	b.WriteString(fmt.Sprintf("Subject: %s\r\n", msg.Subject))
-	b.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.Attachment == nil {
+		b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+		b.WriteString(msg.Body)
+		return b.String()
+	}
+
+	const boundary = "remote-radar-boundary"
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%s\r\n\r\n", boundary))
+
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
 	b.WriteString(msg.Body)
+	b.WriteString("\r\n")
+
+	contentType := msg.Attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	b.WriteString(fmt.Sprintf("Content-Type: %s\r\n", contentType))
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString(fmt.Sprintf("Content-Disposition: attachment; filename=%q\r\n\r\n", msg.Attachment.Filename))
+	b.WriteString(base64.StdEncoding.EncodeToString(msg.Attachment.Content))
+	b.WriteString(fmt.Sprintf("\r\n--%s--\r\n", boundary))
+
 	return b.String()
 }
@@ -0,0 +1,102 @@
+package notifier
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// FeedConfig 配置 RSS/Atom 订阅文件的生成参数。
+type FeedConfig struct {
+	Path    string `yaml:"path" json:"path"`
+	Title   string `yaml:"title" json:"title"`
+	BaseURL string `yaml:"base_url" json:"base_url"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Link    atomLink `xml:"link"`
+	Updated string   `xml:"updated"`
+	Summary string   `xml:"summary"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+// FeedNotifier 将新增职位写入本地 Atom feed 文件，每次 Notify 都会用最新一批职位重写整个文件，
+// 供不方便接收推送的用户通过 RSS 阅读器订阅。
+type FeedNotifier struct {
+	cfg FeedConfig
+	now func() time.Time
+}
+
+// NewFeedNotifier 创建 FeedNotifier，Title 为空时使用默认站点名。
+func NewFeedNotifier(cfg FeedConfig) *FeedNotifier {
+	if cfg.Title == "" {
+		cfg.Title = "Remote Radar"
+	}
+	return &FeedNotifier{cfg: cfg, now: time.Now}
+}
+
+// Notify 将 jobs 渲染为 Atom feed 并覆盖写入 cfg.Path。
+func (n *FeedNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.cfg.Path == "" {
+		return fmt.Errorf("feed path is empty")
+	}
+
+	data, err := RenderAtomFeed(n.cfg.Title, n.cfg.BaseURL, jobs, n.now())
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(n.cfg.Path, data, 0o644); err != nil {
+		return fmt.Errorf("write atom feed: %w", err)
+	}
+	return nil
+}
+
+// RenderAtomFeed 将 jobs 渲染为一份完整的 Atom feed 文档（含 XML 头），title/id 分别对应 feed
+// 的标题与全局 ID。供 FeedNotifier 的站点级 feed 与订阅级个人化 feed 复用同一套渲染逻辑。
+func RenderAtomFeed(title, id string, jobs []model.Job, now time.Time) ([]byte, error) {
+	updated := now.UTC().Format(time.RFC3339)
+	feed := atomFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		Title:   title,
+		ID:      id,
+		Updated: updated,
+		Entries: make([]atomEntry, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   job.Title,
+			ID:      job.URL,
+			Link:    atomLink{Href: job.URL},
+			Updated: updated,
+			Summary: fmt.Sprintf("%s · %s", job.Source, job.Title),
+		})
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal atom feed: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFeedNotifierWritesAtomFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	n := NewFeedNotifier(FeedConfig{Path: path, Title: "Test Feed"})
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read feed file: %v", err)
+	}
+	if !strings.Contains(string(data), "Test Feed") {
+		t.Fatalf("expected feed title in output, got %s", data)
+	}
+	for _, job := range sampleJobs() {
+		if !strings.Contains(string(data), job.Title) {
+			t.Fatalf("expected job %q in feed output", job.Title)
+		}
+	}
+}
+
+func TestFeedNotifierRequiresPath(t *testing.T) {
+	t.Parallel()
+
+	n := NewFeedNotifier(FeedConfig{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when path is missing")
+	}
+}
+
+func TestFeedNotifierSkipsWhenNoJobs(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	n := NewFeedNotifier(FeedConfig{Path: path})
+
+	if err := n.Notify(context.Background(), nil); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("expected no feed file to be written for empty job list")
+	}
+}
@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"remote-radar/internal/model"
+)
+
+// MastodonConfig 配置 Mastodon 实例地址与默认发帖参数，AccessToken 由具体订阅提供（sub.Target）。
+type MastodonConfig struct {
+	BaseURL    string `yaml:"base_url" json:"base_url"`
+	Visibility string `yaml:"visibility" json:"visibility"`
+	Language   string `yaml:"language" json:"language"`
+	DryRun     bool   `yaml:"dry_run" json:"dry_run"`
+}
+
+// MastodonNotifier 通过 Mastodon API 将新增职位摘要发布为一条嘟文（status）。
+type MastodonNotifier struct {
+	cfg         MastodonConfig
+	accessToken string
+	client      HTTPDoer
+}
+
+// NewMastodonNotifier 创建 MastodonNotifier，accessToken 来自订阅自身，client 为 nil 时使用 http.DefaultClient。
+func NewMastodonNotifier(cfg MastodonConfig, accessToken string, client HTTPDoer) *MastodonNotifier {
+	if cfg.Visibility == "" {
+		cfg.Visibility = "public"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &MastodonNotifier{cfg: cfg, accessToken: accessToken, client: client}
+}
+
+// Notify 将新增职位汇总为一条嘟文，通过 PostStatus 发布到配置的 Mastodon 账号。
+func (n *MastodonNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.cfg.BaseURL == "" {
+		return fmt.Errorf("mastodon base url is empty")
+	}
+	if n.accessToken == "" {
+		return fmt.Errorf("mastodon access token is empty")
+	}
+	if n.cfg.DryRun {
+		return nil
+	}
+
+	return n.PostStatus(ctx, buildBody(jobs))
+}
+
+// PostStatus 调用 Mastodon 的 POST /api/v1/statuses 接口发布一条嘟文。
+func (n *MastodonNotifier) PostStatus(ctx context.Context, status string) error {
+	form := url.Values{"status": {status}, "visibility": {n.cfg.Visibility}}
+	if n.cfg.Language != "" {
+		form.Set("language", n.cfg.Language)
+	}
+
+	endpoint := strings.TrimSuffix(n.cfg.BaseURL, "/") + "/api/v1/statuses"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+n.accessToken)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post mastodon status: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("mastodon responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestMastodonNotifierPostsStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewMastodonNotifier(MastodonConfig{BaseURL: "https://mastodon.example", Language: "zh"}, "token123", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq == nil {
+		t.Fatal("expected request to be sent")
+	}
+	if doer.lastReq.URL.String() != "https://mastodon.example/api/v1/statuses" {
+		t.Fatalf("unexpected endpoint: %s", doer.lastReq.URL.String())
+	}
+	if auth := doer.lastReq.Header.Get("Authorization"); auth != "Bearer token123" {
+		t.Fatalf("expected bearer token header, got %q", auth)
+	}
+
+	form, err := url.ParseQuery(string(doer.body))
+	if err != nil {
+		t.Fatalf("parse form body: %v", err)
+	}
+	if form.Get("visibility") != "public" {
+		t.Fatalf("expected default visibility public, got %q", form.Get("visibility"))
+	}
+	if form.Get("language") != "zh" {
+		t.Fatalf("expected language zh, got %q", form.Get("language"))
+	}
+}
+
+func TestMastodonNotifierRequiresAccessToken(t *testing.T) {
+	t.Parallel()
+
+	n := NewMastodonNotifier(MastodonConfig{BaseURL: "https://mastodon.example"}, "", &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when access token is missing")
+	}
+}
+
+func TestMastodonNotifierRequiresBaseURL(t *testing.T) {
+	t.Parallel()
+
+	n := NewMastodonNotifier(MastodonConfig{}, "token123", &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when base url is missing")
+	}
+}
+
+func TestMastodonNotifierErrorsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusUnprocessableEntity}
+	n := NewMastodonNotifier(MastodonConfig{BaseURL: "https://mastodon.example"}, "token123", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+}
+
+func TestMastodonNotifierDryRunSkipsRequest(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewMastodonNotifier(MastodonConfig{BaseURL: "https://mastodon.example", DryRun: true}, "token123", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq != nil {
+		t.Fatal("expected dry-run to skip sending the request")
+	}
+}
@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"remote-radar/internal/model"
+)
+
+// Multi 并发调用多个 Notifier，彼此隔离：某个后端失败不会阻止其余后端投递，
+// 全部执行完毕后才把失败的后端合并为一个错误返回。对照 CompositeNotifier 的串行、
+// 遇错即停语义，Multi 适合用在多个渠道互不依赖、希望尽量都投递成功的场景。
+type Multi struct {
+	notifiers []jobNotifier
+}
+
+// NewMulti 创建 Multi，nil 元素会被忽略。
+func NewMulti(notifiers ...jobNotifier) *Multi {
+	clean := make([]jobNotifier, 0, len(notifiers))
+	for _, n := range notifiers {
+		if n != nil {
+			clean = append(clean, n)
+		}
+	}
+	return &Multi{notifiers: clean}
+}
+
+// Notify 并发调用每个后端并收集各自的错误，单个后端出错不影响其余后端继续投递。
+func (m *Multi) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(m.notifiers) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(m.notifiers))
+	var wg sync.WaitGroup
+	for i, n := range m.notifiers {
+		wg.Add(1)
+		go func(i int, n jobNotifier) {
+			defer wg.Done()
+			if err := n.Notify(ctx, jobs); err != nil {
+				errs[i] = fmt.Errorf("notifier %d: %w", i, err)
+			}
+		}(i, n)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d notifiers failed: %s", len(failed), len(m.notifiers), strings.Join(failed, "; "))
+}
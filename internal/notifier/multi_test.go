@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMultiCallsAllBackendsEvenWhenOneFails(t *testing.T) {
+	t.Parallel()
+
+	ok := &stubJobNotifier{}
+	failing := &stubFailingNotifier{err: errors.New("boom"), failN: 100}
+	m := NewMulti(ok, failing)
+
+	err := m.Notify(context.Background(), sampleJobs())
+	if err == nil {
+		t.Fatal("expected error when one backend fails")
+	}
+	if ok.calls != 1 {
+		t.Fatalf("expected healthy backend to still be called, got %d calls", ok.calls)
+	}
+	if failing.calls != 1 {
+		t.Fatalf("expected failing backend to be called, got %d calls", failing.calls)
+	}
+}
+
+func TestMultiReturnsNilWhenAllSucceed(t *testing.T) {
+	t.Parallel()
+
+	a := &stubJobNotifier{}
+	b := &stubJobNotifier{}
+	m := NewMulti(a, b)
+
+	if err := m.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+}
+
+func TestMultiIgnoresNilNotifiers(t *testing.T) {
+	t.Parallel()
+
+	m := NewMulti(nil, nil)
+	if err := m.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// ErrNotifierPaused 在熔断器处于 paused 状态时立即返回，调用方应将其视为暂时性失败而非致命错误。
+var ErrNotifierPaused = errors.New("notifier: circuit breaker paused")
+
+// BackoffConfig 描述单次 Notify 调用内部的指数退避重试参数。
+type BackoffConfig struct {
+	Initial     time.Duration `yaml:"initial" json:"initial"`
+	Max         time.Duration `yaml:"max" json:"max"`
+	Jitter      time.Duration `yaml:"jitter" json:"jitter"`
+	MaxAttempts int           `yaml:"max_attempts" json:"max_attempts"`
+}
+
+func (c BackoffConfig) withDefaults() BackoffConfig {
+	if c.Initial <= 0 {
+		c.Initial = time.Second
+	}
+	if c.Max <= 0 {
+		c.Max = 30 * time.Second
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 3
+	}
+	return c
+}
+
+func (c BackoffConfig) delay(attempt int) time.Duration {
+	d := c.Initial
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d > c.Max {
+			d = c.Max
+			break
+		}
+	}
+	if c.Jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(c.Jitter)))
+	}
+	return d
+}
+
+// CircuitBreakerConfig 描述熔断器在连续失败多少次后暂停投递，以及暂停多久后再次尝试。
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold" json:"failure_threshold"`
+	Window           time.Duration `yaml:"window" json:"window"`
+	Cooldown         time.Duration `yaml:"cooldown" json:"cooldown"`
+}
+
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.Window <= 0 {
+		c.Window = 5 * time.Minute
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = time.Minute
+	}
+	return c
+}
+
+// NotifierState 描述熔断器当前所处的状态。
+type NotifierState string
+
+const (
+	NotifierStateClosed NotifierState = "closed"
+	NotifierStatePaused NotifierState = "paused"
+)
+
+// NotifierStatus 描述 RetryingNotifier 当前的运行状态，供 /api/notifier/status 暴露。
+type NotifierStatus struct {
+	State       NotifierState
+	Failures    int
+	LastError   string
+	PausedUntil time.Time
+	NextRetryAt time.Time
+}
+
+// PendingNotificationStore 持久化投递失败的整批新增职位，供 RetryingNotifier 在下次成功 Notify 时补发。
+type PendingNotificationStore interface {
+	EnqueuePendingNotification(ctx context.Context, entry model.PendingNotification) error
+	ListPendingNotifications(ctx context.Context) ([]model.PendingNotification, error)
+	MarkPendingNotificationDelivered(ctx context.Context, id uint) error
+	MarkPendingNotificationFailed(ctx context.Context, id uint, attempts int, lastErr string) error
+	MarkPendingNotificationAbandoned(ctx context.Context, id uint, lastErr string) error
+}
+
+const pendingMaxAttempts = 5
+
+// RetryingNotifier 包装任意 jobNotifier，为其加上指数退避重试与熔断器：
+// 连续失败达到阈值后进入 paused 状态并在 Notify 中直接返回 ErrNotifierPaused，
+// 冷却期结束后自动尝试半开恢复；投递失败的整批职位写入 pending_notifications，
+// 在下一次 Notify 成功时一并补发。store 为 nil 时不持久化，仅保留退避与熔断行为。
+type RetryingNotifier struct {
+	next    jobNotifier
+	backoff BackoffConfig
+	breaker CircuitBreakerConfig
+	store   PendingNotificationStore
+	now     func() time.Time
+	sleep   func(context.Context, time.Duration) error
+
+	mu          sync.Mutex
+	state       NotifierState
+	failures    int
+	windowStart time.Time
+	lastErr     string
+	pausedUntil time.Time
+}
+
+// NewRetryingNotifier 创建 RetryingNotifier，store 为 nil 时跳过持久化补发。
+func NewRetryingNotifier(next jobNotifier, backoff BackoffConfig, breaker CircuitBreakerConfig, store PendingNotificationStore) *RetryingNotifier {
+	return &RetryingNotifier{
+		next:    next,
+		backoff: backoff.withDefaults(),
+		breaker: breaker.withDefaults(),
+		store:   store,
+		now:     time.Now,
+		sleep:   sleepContext,
+		state:   NotifierStateClosed,
+	}
+}
+
+// Status 返回熔断器当前状态快照，供 /api/notifier/status 暴露。
+func (n *RetryingNotifier) Status() NotifierStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	status := NotifierStatus{State: n.state, Failures: n.failures, LastError: n.lastErr}
+	if n.state == NotifierStatePaused {
+		status.PausedUntil = n.pausedUntil
+		status.NextRetryAt = n.pausedUntil
+	}
+	return status
+}
+
+// Resume 强制关闭熔断器，立即恢复投递，供 /api/notifier/resume 调用。
+func (n *RetryingNotifier) Resume() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.state = NotifierStateClosed
+	n.failures = 0
+	n.pausedUntil = time.Time{}
+}
+
+// Notify 在熔断器关闭时投递新增职位，失败时按 BackoffConfig 重试，
+// 连续失败触发熔断后直接返回 ErrNotifierPaused；成功时顺带补发此前积压的通知。
+func (n *RetryingNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if n.isPaused() {
+		return ErrNotifierPaused
+	}
+
+	err := n.deliverWithRetry(ctx, jobs)
+	if err != nil {
+		n.recordFailure(err)
+		if n.store != nil {
+			n.enqueuePending(ctx, jobs, err)
+		}
+		return err
+	}
+
+	n.recordSuccess()
+	if n.store != nil {
+		n.flushPending(ctx)
+	}
+	return nil
+}
+
+func (n *RetryingNotifier) isPaused() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.state != NotifierStatePaused {
+		return false
+	}
+	if n.now().Before(n.pausedUntil) {
+		return true
+	}
+	// 冷却期已过，半开放行下一次调用，由其结果决定是否重新关闭熔断器。
+	n.state = NotifierStateClosed
+	return false
+}
+
+func (n *RetryingNotifier) deliverWithRetry(ctx context.Context, jobs []model.Job) error {
+	var lastErr error
+	for attempt := 1; attempt <= n.backoff.MaxAttempts; attempt++ {
+		if err := n.next.Notify(ctx, jobs); err != nil {
+			lastErr = err
+			if attempt == n.backoff.MaxAttempts {
+				break
+			}
+			if sleepErr := n.sleep(ctx, n.backoff.delay(attempt)); sleepErr != nil {
+				return sleepErr
+			}
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("notify failed after %d attempts: %w", n.backoff.MaxAttempts, lastErr)
+}
+
+func (n *RetryingNotifier) recordFailure(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.now()
+	if n.windowStart.IsZero() || now.Sub(n.windowStart) > n.breaker.Window {
+		n.windowStart = now
+		n.failures = 0
+	}
+	n.failures++
+	n.lastErr = err.Error()
+
+	if n.failures >= n.breaker.FailureThreshold {
+		n.state = NotifierStatePaused
+		n.pausedUntil = now.Add(n.breaker.Cooldown)
+	}
+}
+
+func (n *RetryingNotifier) recordSuccess() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.state = NotifierStateClosed
+	n.failures = 0
+	n.windowStart = time.Time{}
+	n.lastErr = ""
+}
+
+func (n *RetryingNotifier) enqueuePending(ctx context.Context, jobs []model.Job, deliverErr error) {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	idPayload, err := json.Marshal(ids)
+	if err != nil {
+		return
+	}
+	payload, err := json.Marshal(jobs)
+	if err != nil {
+		return
+	}
+	_ = n.store.EnqueuePendingNotification(ctx, model.PendingNotification{
+		JobIDs:    idPayload,
+		Payload:   payload,
+		Attempts:  1,
+		LastError: deliverErr.Error(),
+	})
+}
+
+func (n *RetryingNotifier) flushPending(ctx context.Context) {
+	entries, err := n.store.ListPendingNotifications(ctx)
+	if err != nil || len(entries) == 0 {
+		return
+	}
+
+	for _, entry := range entries {
+		var jobs []model.Job
+		if err := json.Unmarshal(entry.Payload, &jobs); err != nil {
+			_ = n.store.MarkPendingNotificationAbandoned(ctx, entry.ID, fmt.Sprintf("corrupt payload: %v", err))
+			continue
+		}
+		if err := n.next.Notify(ctx, jobs); err != nil {
+			attempts := entry.Attempts + 1
+			if attempts >= pendingMaxAttempts {
+				_ = n.store.MarkPendingNotificationAbandoned(ctx, entry.ID, err.Error())
+				continue
+			}
+			_ = n.store.MarkPendingNotificationFailed(ctx, entry.ID, attempts, err.Error())
+			continue
+		}
+		_ = n.store.MarkPendingNotificationDelivered(ctx, entry.ID)
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
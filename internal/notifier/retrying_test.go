@@ -0,0 +1,169 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+type stubFailingNotifier struct {
+	mu      sync.Mutex
+	err     error
+	failN   int
+	calls   int
+	lastLen int
+}
+
+func (s *stubFailingNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	s.lastLen = len(jobs)
+	if s.calls <= s.failN {
+		return s.err
+	}
+	return nil
+}
+
+type stubPendingStore struct {
+	mu      sync.Mutex
+	entries []model.PendingNotification
+	nextID  uint
+}
+
+func (s *stubPendingStore) EnqueuePendingNotification(ctx context.Context, entry model.PendingNotification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	entry.ID = s.nextID
+	entry.Status = model.PendingNotificationStatusPending
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *stubPendingStore) ListPendingNotifications(ctx context.Context) ([]model.PendingNotification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.PendingNotification
+	for _, e := range s.entries {
+		if e.Status == model.PendingNotificationStatusPending {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubPendingStore) MarkPendingNotificationDelivered(ctx context.Context, id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries[i].Status = model.PendingNotificationStatusDelivered
+		}
+	}
+	return nil
+}
+
+func (s *stubPendingStore) MarkPendingNotificationFailed(ctx context.Context, id uint, attempts int, lastErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries[i].Attempts = attempts
+			s.entries[i].LastError = lastErr
+		}
+	}
+	return nil
+}
+
+func (s *stubPendingStore) MarkPendingNotificationAbandoned(ctx context.Context, id uint, lastErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries[i].Status = model.PendingNotificationStatusAbandoned
+			s.entries[i].LastError = lastErr
+		}
+	}
+	return nil
+}
+
+func TestRetryingNotifierRetriesThenSucceeds(t *testing.T) {
+	t.Parallel()
+
+	next := &stubFailingNotifier{err: errors.New("transient"), failN: 1}
+	n := NewRetryingNotifier(next, BackoffConfig{Initial: time.Millisecond, MaxAttempts: 3}, CircuitBreakerConfig{}, nil)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected 2 calls (1 failure + 1 success), got %d", next.calls)
+	}
+}
+
+func TestRetryingNotifierTripsBreakerAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	next := &stubFailingNotifier{err: errors.New("down"), failN: 100}
+	n := NewRetryingNotifier(next, BackoffConfig{Initial: time.Millisecond, MaxAttempts: 1}, CircuitBreakerConfig{FailureThreshold: 2, Window: time.Minute, Cooldown: time.Hour}, nil)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected first failure to return error")
+	}
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected second failure to return error")
+	}
+
+	if err := n.Notify(context.Background(), sampleJobs()); !errors.Is(err, ErrNotifierPaused) {
+		t.Fatalf("expected ErrNotifierPaused once breaker trips, got %v", err)
+	}
+	if n.Status().State != NotifierStatePaused {
+		t.Fatalf("expected state paused, got %s", n.Status().State)
+	}
+}
+
+func TestRetryingNotifierResumeClosesBreaker(t *testing.T) {
+	t.Parallel()
+
+	next := &stubFailingNotifier{err: errors.New("down"), failN: 100}
+	n := NewRetryingNotifier(next, BackoffConfig{Initial: time.Millisecond, MaxAttempts: 1}, CircuitBreakerConfig{FailureThreshold: 1, Window: time.Minute, Cooldown: time.Hour}, nil)
+
+	_ = n.Notify(context.Background(), sampleJobs())
+	if n.Status().State != NotifierStatePaused {
+		t.Fatalf("expected breaker paused, got %s", n.Status().State)
+	}
+
+	n.Resume()
+	if n.Status().State != NotifierStateClosed {
+		t.Fatalf("expected breaker closed after Resume, got %s", n.Status().State)
+	}
+}
+
+func TestRetryingNotifierPersistsAndFlushesPending(t *testing.T) {
+	t.Parallel()
+
+	next := &stubFailingNotifier{err: errors.New("down"), failN: 1}
+	store := &stubPendingStore{}
+	n := NewRetryingNotifier(next, BackoffConfig{Initial: time.Millisecond, MaxAttempts: 1}, CircuitBreakerConfig{FailureThreshold: 10}, store)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected first Notify to fail and persist a pending entry")
+	}
+	pending, _ := store.ListPendingNotifications(context.Background())
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending notification, got %d", len(pending))
+	}
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("expected second Notify to succeed, got %v", err)
+	}
+	pending, _ = store.ListPendingNotifications(context.Background())
+	if len(pending) != 0 {
+		t.Fatalf("expected pending notification flushed after success, got %d", len(pending))
+	}
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"remote-radar/internal/model"
+)
+
+// ServerChanConfig 配置 Server 酱推送，SendKey 由具体订阅提供。
+type ServerChanConfig struct {
+	SendKey string `yaml:"send_key" json:"send_key"`
+}
+
+// ServerChanNotifier 通过 Server 酱（sctapi.ftqq.com）向微信推送新增职位摘要。
+type ServerChanNotifier struct {
+	cfg    ServerChanConfig
+	client HTTPDoer
+}
+
+// NewServerChanNotifier 创建 ServerChanNotifier，client 为 nil 时使用 http.DefaultClient。
+func NewServerChanNotifier(cfg ServerChanConfig, client HTTPDoer) *ServerChanNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ServerChanNotifier{cfg: cfg, client: client}
+}
+
+// Notify 将新增职位汇总为一条 Server 酱推送消息。
+func (n *ServerChanNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.cfg.SendKey == "" {
+		return fmt.Errorf("server酱 send key is empty")
+	}
+
+	form := url.Values{
+		"title": {fmt.Sprintf("发现 %d 个新职位", len(jobs))},
+		"desp":  {buildBody(jobs)},
+	}
+
+	endpoint := fmt.Sprintf("https://sctapi.ftqq.com/%s.send", n.cfg.SendKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post server酱: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("server酱 responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServerChanNotifierPostsToSendKey(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewServerChanNotifier(ServerChanConfig{SendKey: "SCT123"}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq == nil {
+		t.Fatal("expected request to be sent")
+	}
+	if !strings.Contains(doer.lastReq.URL.String(), "SCT123.send") {
+		t.Fatalf("expected url to reference send key, got %s", doer.lastReq.URL.String())
+	}
+}
+
+func TestServerChanNotifierRequiresSendKey(t *testing.T) {
+	t.Parallel()
+
+	n := NewServerChanNotifier(ServerChanConfig{}, &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when send key is missing")
+	}
+}
+
+func TestServerChanNotifierErrorsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusBadRequest}
+	n := NewServerChanNotifier(ServerChanConfig{SendKey: "SCT123"}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+}
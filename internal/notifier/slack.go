@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"remote-radar/internal/model"
+)
+
+// SlackConfig 配置 Slack Incoming Webhook 的发送参数，WebhookURL 由具体订阅提供（sub.Target）。
+type SlackConfig struct {
+	Username  string `yaml:"username" json:"username"`
+	IconEmoji string `yaml:"icon_emoji" json:"icon_emoji"`
+	DryRun    bool   `yaml:"dry_run" json:"dry_run"`
+}
+
+// SlackNotifier 通过 Slack Incoming Webhook 将新增职位渲染为 Block Kit 消息发送到频道。
+type SlackNotifier struct {
+	cfg        SlackConfig
+	webhookURL string
+	client     HTTPDoer
+}
+
+// NewSlackNotifier 创建 SlackNotifier，webhookURL 来自订阅自身，client 为 nil 时使用 http.DefaultClient。
+func NewSlackNotifier(cfg SlackConfig, webhookURL string, client HTTPDoer) *SlackNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &SlackNotifier{cfg: cfg, webhookURL: webhookURL, client: client}
+}
+
+// Notify 将新增职位渲染为 Slack Block Kit 消息并 POST 到配置的 Incoming Webhook。
+func (n *SlackNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.webhookURL == "" {
+		return fmt.Errorf("slack webhook url is empty")
+	}
+	if n.cfg.DryRun {
+		return nil
+	}
+
+	payload, err := json.Marshal(n.buildMessage(jobs))
+	if err != nil {
+		return fmt.Errorf("marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("slack responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildMessage 将新增职位渲染为 Slack Block Kit 的 section 列表。
+func (n *SlackNotifier) buildMessage(jobs []model.Job) map[string]any {
+	blocks := []map[string]any{
+		{
+			"type": "section",
+			"text": map[string]string{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*发现 %d 个新职位*", len(jobs)),
+			},
+		},
+	}
+	for _, job := range jobs {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]string{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("<%s|%s> · %s", job.URL, job.Title, job.Source),
+			},
+		})
+	}
+
+	msg := map[string]any{"blocks": blocks}
+	if n.cfg.Username != "" {
+		msg["username"] = n.cfg.Username
+	}
+	if n.cfg.IconEmoji != "" {
+		msg["icon_emoji"] = n.cfg.IconEmoji
+	}
+	return msg
+}
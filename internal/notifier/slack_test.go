@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSlackNotifierPostsBlocksToWebhook(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewSlackNotifier(SlackConfig{Username: "remote-radar"}, "https://hooks.slack.test/abc", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq == nil {
+		t.Fatal("expected request to be sent")
+	}
+	if !strings.Contains(string(doer.body), "blocks") {
+		t.Fatalf("expected payload to contain block kit blocks, got %s", doer.body)
+	}
+}
+
+func TestSlackNotifierRequiresWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	n := NewSlackNotifier(SlackConfig{}, "", &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when webhook url is missing")
+	}
+}
+
+func TestSlackNotifierErrorsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusBadRequest}
+	n := NewSlackNotifier(SlackConfig{}, "https://hooks.slack.test/abc", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+}
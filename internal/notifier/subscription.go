@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"remote-radar/internal/model"
+	"remote-radar/internal/notifier/dispatch"
 )
 
 // SubscriptionStore 定义订阅读取接口。
@@ -20,10 +21,11 @@ type jobNotifier interface {
 
 // SubscriptionNotifier 会按订阅偏好推送通知。
 type SubscriptionNotifier struct {
-	store    SubscriptionStore
-	emailCfg EmailConfig
-	sender   EmailSender
-	fallback jobNotifier
+	store      SubscriptionStore
+	emailCfg   EmailConfig
+	sender     EmailSender
+	fallback   jobNotifier
+	dispatcher *dispatch.Dispatcher
 }
 
 // NewSubscriptionNotifier 创建实例。
@@ -36,6 +38,72 @@ func NewSubscriptionNotifier(store SubscriptionStore, cfg EmailConfig, sender Em
 	}
 }
 
+// WithChannels 启用 webhook/bark/server酱/mastodon/slack/telegram 等渠道，并将投递失败的记录
+// 写入 outbox 等待重试。调用后 Notify 会改由 internal/notifier/dispatch 统一路由，不再局限于邮件单渠道。
+func (n *SubscriptionNotifier) WithChannels(webhook WebhookConfig, bark BarkConfig, mastodon MastodonConfig, slack SlackConfig, telegram TelegramConfig, outbox dispatch.OutboxStore) *SubscriptionNotifier {
+	d := dispatch.NewDispatcher(outbox)
+	d.Register("email", func(sub model.Subscription) (dispatch.Notifier, error) {
+		cfg := n.emailCfg
+		cfg.To = []string{sub.Email}
+		return NewEmailNotifier(cfg, n.sender), nil
+	})
+	d.Register("webhook", func(sub model.Subscription) (dispatch.Notifier, error) {
+		cfg := webhook
+		cfg.URL = sub.Target
+		return NewWebhookNotifier(cfg, nil), nil
+	})
+	d.Register("bark", func(sub model.Subscription) (dispatch.Notifier, error) {
+		cfg := bark
+		cfg.DeviceKey = sub.Target
+		return NewBarkNotifier(cfg, nil), nil
+	})
+	d.Register("serverchan", func(sub model.Subscription) (dispatch.Notifier, error) {
+		return NewServerChanNotifier(ServerChanConfig{SendKey: sub.Target}, nil), nil
+	})
+	d.Register("mastodon", func(sub model.Subscription) (dispatch.Notifier, error) {
+		return NewMastodonNotifier(mastodon, sub.Target, nil), nil
+	})
+	d.Register("slack", func(sub model.Subscription) (dispatch.Notifier, error) {
+		return NewSlackNotifier(slack, sub.Target, nil), nil
+	})
+	d.Register("telegram", func(sub model.Subscription) (dispatch.Notifier, error) {
+		return NewTelegramNotifier(telegram, sub.Target, nil), nil
+	})
+	n.dispatcher = d
+	return n
+}
+
+// testJob 是测试通知使用的占位职位，不对应任何真实数据。
+var testJob = model.Job{
+	ID:      "test-notification",
+	Title:   "测试通知",
+	Summary: "这是一条测试通知，用于验证订阅渠道配置是否正确。",
+	Source:  "remote-radar",
+	URL:     "https://remote-radar.local/test-notification",
+}
+
+// SendTest 向指定订阅发送一条测试通知，忽略其 Tags/FilterExpr，用于创建后验证渠道可达性。
+func (n *SubscriptionNotifier) SendTest(ctx context.Context, sub model.Subscription) error {
+	if n.dispatcher != nil {
+		return n.dispatcher.DispatchOne(ctx, sub, []model.Job{testJob})
+	}
+	channel := strings.ToLower(strings.TrimSpace(sub.Channel))
+	if channel != "" && channel != "email" {
+		return fmt.Errorf("channel %s requires WithChannels to be configured", sub.Channel)
+	}
+	cfg := n.emailCfg
+	cfg.To = []string{sub.Email}
+	return NewEmailNotifier(cfg, n.sender).Notify(ctx, []model.Job{testJob})
+}
+
+// RetryOutbox 重新投递所有到期的失败通知，供管理员手动触发。
+func (n *SubscriptionNotifier) RetryOutbox(ctx context.Context) error {
+	if n.dispatcher == nil {
+		return nil
+	}
+	return n.dispatcher.RetryDue(ctx)
+}
+
 // Notify 根据订阅过滤并发送消息。
 func (n *SubscriptionNotifier) Notify(ctx context.Context, jobs []model.Job) error {
 	if len(jobs) == 0 || n.store == nil {
@@ -53,6 +121,10 @@ func (n *SubscriptionNotifier) Notify(ctx context.Context, jobs []model.Job) err
 		return nil
 	}
 
+	if n.dispatcher != nil {
+		return n.dispatcher.Dispatch(ctx, subs, jobs)
+	}
+
 	for _, sub := range subs {
 		matches := filterJobsBySubscription(sub, jobs)
 		if len(matches) == 0 {
@@ -74,46 +146,8 @@ func (n *SubscriptionNotifier) Notify(ctx context.Context, jobs []model.Job) err
 	return nil
 }
 
+// filterJobsBySubscription 委托给 dispatch.FilterJobsBySubscription，确保邮件单渠道回退路径
+// 与 dispatcher 路由路径共用同一套 FilterExpr/Tags 匹配逻辑，不再维护第二份实现。
 func filterJobsBySubscription(sub model.Subscription, jobs []model.Job) []model.Job {
-	if len(sub.Tags) == 0 {
-		return jobs
-	}
-	filtered := make([]model.Job, 0, len(jobs))
-	for _, job := range jobs {
-		if jobMatches(job, sub.Tags) {
-			filtered = append(filtered, job)
-		}
-	}
-	return filtered
-}
-
-func jobMatches(job model.Job, tags map[string]any) bool {
-	if len(tags) == 0 {
-		return true
-	}
-	if job.NormalizedTags == nil {
-		return false
-	}
-	for k, v := range tags {
-		if !isTruthy(v) {
-			continue
-		}
-		if !isTruthy(job.NormalizedTags[k]) {
-			return false
-		}
-	}
-	return true
-}
-
-func isTruthy(v any) bool {
-	switch val := v.(type) {
-	case bool:
-		return val
-	case string:
-		return strings.TrimSpace(strings.ToLower(val)) == "true"
-	case float64:
-		return val != 0
-	default:
-		return val != nil
-	}
+	return dispatch.FilterJobsBySubscription(sub, jobs)
 }
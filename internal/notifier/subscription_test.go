@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"remote-radar/internal/model"
 
@@ -84,6 +85,104 @@ func TestSubscriptionNotifierFallsBackWhenNoSubscriptions(t *testing.T) {
 	}
 }
 
+func TestSubscriptionNotifierWithChannelsDelegatesToDispatcher(t *testing.T) {
+	t.Parallel()
+
+	store := &stubSubscriptionStore{
+		subs: []model.Subscription{
+			{ID: 1, Email: "be@example.com", Channel: "email", Tags: datatypes.JSONMap{"backend": true}},
+		},
+	}
+
+	emailSender := &stubSender{}
+	cfg := EmailConfig{From: "from@example.com", Host: "smtp"}
+	subNotifier := NewSubscriptionNotifier(store, cfg, emailSender, nil)
+	subNotifier.WithChannels(WebhookConfig{}, BarkConfig{}, MastodonConfig{}, SlackConfig{}, TelegramConfig{}, &stubOutbox{})
+
+	jobs := []model.Job{{ID: "be", Title: "Backend", NormalizedTags: datatypes.JSONMap{"backend": true}}}
+	if err := subNotifier.Notify(context.Background(), jobs); err != nil {
+		t.Fatalf("Notify error: %v", err)
+	}
+	if emailSender.calls != 1 {
+		t.Fatalf("expected dispatcher to route to email channel, got %d calls", emailSender.calls)
+	}
+}
+
+func TestSubscriptionNotifierSendTestIgnoresFilterAndUsesDispatcher(t *testing.T) {
+	t.Parallel()
+
+	emailSender := &stubSender{}
+	cfg := EmailConfig{From: "from@example.com", Host: "smtp"}
+	subNotifier := NewSubscriptionNotifier(&stubSubscriptionStore{}, cfg, emailSender, nil)
+	subNotifier.WithChannels(WebhookConfig{}, BarkConfig{}, MastodonConfig{}, SlackConfig{}, TelegramConfig{}, &stubOutbox{})
+
+	sub := model.Subscription{ID: 1, Email: "be@example.com", Channel: "email", Tags: datatypes.JSONMap{"backend": true}}
+	if err := subNotifier.SendTest(context.Background(), sub); err != nil {
+		t.Fatalf("SendTest error: %v", err)
+	}
+	if emailSender.calls != 1 {
+		t.Fatalf("expected test notification delivered via dispatcher, got %d calls", emailSender.calls)
+	}
+}
+
+func TestSubscriptionNotifierSendTestFallsBackToEmailWithoutDispatcher(t *testing.T) {
+	t.Parallel()
+
+	emailSender := &stubSender{}
+	cfg := EmailConfig{From: "from@example.com", Host: "smtp"}
+	subNotifier := NewSubscriptionNotifier(&stubSubscriptionStore{}, cfg, emailSender, nil)
+
+	sub := model.Subscription{ID: 1, Email: "be@example.com", Channel: "email"}
+	if err := subNotifier.SendTest(context.Background(), sub); err != nil {
+		t.Fatalf("SendTest error: %v", err)
+	}
+	if emailSender.calls != 1 {
+		t.Fatalf("expected email fallback to be used, got %d calls", emailSender.calls)
+	}
+}
+
+func TestSubscriptionNotifierSendTestRejectsUnconfiguredChannel(t *testing.T) {
+	t.Parallel()
+
+	subNotifier := NewSubscriptionNotifier(&stubSubscriptionStore{}, EmailConfig{}, &stubSender{}, nil)
+	sub := model.Subscription{ID: 1, Channel: "webhook", Target: "https://example.com/hook"}
+	if err := subNotifier.SendTest(context.Background(), sub); err == nil {
+		t.Fatalf("expected error when dispatcher is not configured for non-email channel")
+	}
+}
+
+func TestSubscriptionNotifierRetryOutboxNoopWithoutChannels(t *testing.T) {
+	t.Parallel()
+
+	subNotifier := NewSubscriptionNotifier(&stubSubscriptionStore{}, EmailConfig{}, nil, nil)
+	if err := subNotifier.RetryOutbox(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+type stubOutbox struct {
+	entries []model.NotificationOutbox
+}
+
+func (s *stubOutbox) EnqueueOutbox(ctx context.Context, entry model.NotificationOutbox) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *stubOutbox) DueOutboxEntries(ctx context.Context, before time.Time) ([]model.NotificationOutbox, error) {
+	return s.entries, nil
+}
+
+func (s *stubOutbox) MarkOutboxDelivered(ctx context.Context, id uint) error { return nil }
+
+func (s *stubOutbox) MarkOutboxFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	return nil
+}
+
+func (s *stubOutbox) MarkOutboxAbandoned(ctx context.Context, id uint, lastErr string) error {
+	return nil
+}
+
 type stubSubscriptionStore struct {
 	subs []model.Subscription
 }
@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"remote-radar/internal/model"
+)
+
+// TelegramConfig 配置 Telegram Bot，BotToken 为共享配置，ChatID 由具体订阅提供（sub.Target）。
+type TelegramConfig struct {
+	BotToken string `yaml:"bot_token" json:"bot_token"`
+	BaseURL  string `yaml:"base_url" json:"base_url"`
+}
+
+// TelegramNotifier 通过 Telegram Bot API 的 sendMessage 接口推送新增职位摘要。
+type TelegramNotifier struct {
+	cfg    TelegramConfig
+	chatID string
+	client HTTPDoer
+}
+
+// NewTelegramNotifier 创建 TelegramNotifier，chatID 来自订阅自身，client 为 nil 时使用 http.DefaultClient。
+func NewTelegramNotifier(cfg TelegramConfig, chatID string, client HTTPDoer) *TelegramNotifier {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.telegram.org"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &TelegramNotifier{cfg: cfg, chatID: chatID, client: client}
+}
+
+// Notify 将新增职位汇总为一条文本消息，通过 sendMessage 推送给 ChatID。
+func (n *TelegramNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.cfg.BotToken == "" {
+		return fmt.Errorf("telegram bot token is empty")
+	}
+	if n.chatID == "" {
+		return fmt.Errorf("telegram chat id is empty")
+	}
+
+	form := url.Values{
+		"chat_id": {n.chatID},
+		"text":    {buildBody(jobs)},
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(n.cfg.BaseURL, "/"), n.cfg.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post telegram: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("telegram responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
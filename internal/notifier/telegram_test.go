@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTelegramNotifierPostsToChatID(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewTelegramNotifier(TelegramConfig{BotToken: "123:abc"}, "42", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq == nil {
+		t.Fatal("expected request to be sent")
+	}
+	if !strings.Contains(doer.lastReq.URL.String(), "bot123:abc/sendMessage") {
+		t.Fatalf("expected url to reference bot token and sendMessage, got %s", doer.lastReq.URL.String())
+	}
+}
+
+func TestTelegramNotifierRequiresBotTokenAndChatID(t *testing.T) {
+	t.Parallel()
+
+	n := NewTelegramNotifier(TelegramConfig{}, "", &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when bot token is missing")
+	}
+
+	n = NewTelegramNotifier(TelegramConfig{BotToken: "123:abc"}, "", &stubHTTPDoer{})
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error when chat id is missing")
+	}
+}
+
+func TestTelegramNotifierErrorsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusBadRequest}
+	n := NewTelegramNotifier(TelegramConfig{BotToken: "123:abc"}, "42", doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+}
@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// TargetValidators 按渠道名称提供目标格式校验函数，供 subscription.Service.Create 在写库前
+// 复用，避免接受格式明显错误的 webhook URL / bot chat id，把失败推迟到投递时才发现。
+// 渠道未出现在该表中时（如 email）不做格式校验。
+var TargetValidators = map[string]func(target string) error{
+	"webhook":    ValidateWebhookTarget,
+	"slack":      ValidateSlackTarget,
+	"telegram":   ValidateTelegramTarget,
+	"bark":       ValidateBarkTarget,
+	"mastodon":   ValidateMastodonTarget,
+	"serverchan": ValidateServerChanTarget,
+}
+
+// ValidateTarget 按 channel 校验 target 格式；channel 不在 TargetValidators 中时视为通过。
+func ValidateTarget(channel, target string) error {
+	validate, ok := TargetValidators[strings.ToLower(strings.TrimSpace(channel))]
+	if !ok {
+		return nil
+	}
+	return validate(target)
+}
+
+// ValidateWebhookTarget 要求 target 为带 host 的 http(s) URL。
+func ValidateWebhookTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook target must be a valid http(s) URL")
+	}
+	return nil
+}
+
+// ValidateSlackTarget 要求 target 为 Slack Incoming Webhook 的 https URL。
+func ValidateSlackTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("slack target must be a valid https incoming webhook URL")
+	}
+	return nil
+}
+
+// ValidateTelegramTarget 要求 target 为数字形式的 chat id。
+func ValidateTelegramTarget(target string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("telegram target (chat id) required")
+	}
+	if _, err := strconv.ParseInt(target, 10, 64); err != nil {
+		return fmt.Errorf("telegram target must be a numeric chat id")
+	}
+	return nil
+}
+
+// ValidateBarkTarget 要求 target（device key）非空。
+func ValidateBarkTarget(target string) error {
+	if strings.TrimSpace(target) == "" {
+		return fmt.Errorf("bark target (device key) required")
+	}
+	return nil
+}
+
+// ValidateMastodonTarget 要求 target（access token）非空。
+func ValidateMastodonTarget(target string) error {
+	if strings.TrimSpace(target) == "" {
+		return fmt.Errorf("mastodon target (access token) required")
+	}
+	return nil
+}
+
+// ValidateServerChanTarget 要求 target（send key）非空。
+func ValidateServerChanTarget(target string) error {
+	if strings.TrimSpace(target) == "" {
+		return fmt.Errorf("serverchan target (send key) required")
+	}
+	return nil
+}
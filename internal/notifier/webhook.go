@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+const defaultWebhookRetryBaseDelay = 500 * time.Millisecond
+
+// WebhookConfig 配置 Webhook 通知目标与签名密钥。
+// MaxRetries 为 0（默认）时不重试，非 2xx 响应直接返回错误；大于 0 时按指数退避重试。
+type WebhookConfig struct {
+	URL            string        `yaml:"url" json:"url"`
+	Secret         string        `yaml:"secret" json:"secret"`
+	MaxRetries     int           `yaml:"max_retries" json:"max_retries"`
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" json:"retry_base_delay"`
+	DryRun         bool          `yaml:"dry_run" json:"dry_run"`
+}
+
+// HTTPDoer 抽象 HTTP 客户端，便于测试替换。
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookNotifier 将新增职位以 JSON 形式 POST 到用户配置的 URL，并附带 HMAC-SHA256 签名。
+type WebhookNotifier struct {
+	cfg    WebhookConfig
+	client HTTPDoer
+}
+
+// NewWebhookNotifier 创建 WebhookNotifier，client 为 nil 时使用 http.DefaultClient。
+func NewWebhookNotifier(cfg WebhookConfig, client HTTPDoer) *WebhookNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookNotifier{cfg: cfg, client: client}
+}
+
+// Notify 将新增职位序列化为 JSON 并 POST 到配置的 URL，非 2xx 响应按 MaxRetries 指数退避重试。
+func (n *WebhookNotifier) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if n.cfg.URL == "" {
+		return fmt.Errorf("webhook url is empty")
+	}
+	if n.cfg.DryRun {
+		return nil
+	}
+
+	payload, err := json.Marshal(jobs)
+	if err != nil {
+		return fmt.Errorf("marshal jobs: %w", err)
+	}
+
+	delay := n.cfg.RetryBaseDelay
+	if delay <= 0 {
+		delay = defaultWebhookRetryBaseDelay
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= n.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			delay *= 2
+		}
+
+		lastErr = n.send(ctx, payload)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+func (n *WebhookNotifier) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.cfg.Secret != "" {
+		req.Header.Set("X-Signature-256", signPayload(n.cfg.Secret, payload))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signPayload 返回 payload 的 HMAC-SHA256 十六进制签名，格式为 sha256=<hex>。
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
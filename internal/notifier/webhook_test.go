@@ -0,0 +1,130 @@
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+type stubHTTPDoer struct {
+	lastReq   *http.Request
+	body      []byte
+	status    int
+	err       error
+	failCount int
+	calls     int
+}
+
+func (d *stubHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	d.calls++
+	d.lastReq = req
+	if d.err != nil {
+		return nil, d.err
+	}
+	if req.Body != nil {
+		d.body, _ = io.ReadAll(req.Body)
+	}
+	status := d.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if d.calls <= d.failCount {
+		status = http.StatusInternalServerError
+	}
+	return &http.Response{StatusCode: status, Body: io.NopCloser(nil)}, nil
+}
+
+func sampleJobs() []model.Job {
+	return []model.Job{{ID: "1", Title: "Go Engineer", Source: "eleduck", PublishedAt: time.Now()}}
+}
+
+func TestWebhookNotifierSignsPayload(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewWebhookNotifier(WebhookConfig{URL: "https://example.com/hook", Secret: "s3cr3t"}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq == nil {
+		t.Fatal("expected request to be sent")
+	}
+	if sig := doer.lastReq.Header.Get("X-Signature-256"); sig == "" {
+		t.Fatal("expected X-Signature-256 header to be set")
+	}
+}
+
+func TestWebhookNotifierSkipsEmptyJobs(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewWebhookNotifier(WebhookConfig{URL: "https://example.com/hook"}, doer)
+
+	if err := n.Notify(context.Background(), nil); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq != nil {
+		t.Fatal("expected no request for empty job list")
+	}
+}
+
+func TestWebhookNotifierErrorsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusInternalServerError}
+	n := NewWebhookNotifier(WebhookConfig{URL: "https://example.com/hook"}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+	if doer.calls != 1 {
+		t.Fatalf("expected no retries when MaxRetries is zero, got %d calls", doer.calls)
+	}
+}
+
+func TestWebhookNotifierRetriesOnFailureThenSucceeds(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{failCount: 2}
+	n := NewWebhookNotifier(WebhookConfig{URL: "https://example.com/hook", MaxRetries: 2, RetryBaseDelay: time.Millisecond}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.calls != 3 {
+		t.Fatalf("expected 3 attempts (2 failures + success), got %d", doer.calls)
+	}
+}
+
+func TestWebhookNotifierGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{status: http.StatusInternalServerError}
+	n := NewWebhookNotifier(WebhookConfig{URL: "https://example.com/hook", MaxRetries: 2, RetryBaseDelay: time.Millisecond}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if doer.calls != 3 {
+		t.Fatalf("expected 3 attempts (initial + 2 retries), got %d", doer.calls)
+	}
+}
+
+func TestWebhookNotifierDryRunSkipsRequest(t *testing.T) {
+	t.Parallel()
+
+	doer := &stubHTTPDoer{}
+	n := NewWebhookNotifier(WebhookConfig{URL: "https://example.com/hook", DryRun: true}, doer)
+
+	if err := n.Notify(context.Background(), sampleJobs()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if doer.lastReq != nil {
+		t.Fatal("expected dry-run to skip sending the request")
+	}
+}
@@ -0,0 +1,118 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AnthropicConfig 定义 Anthropic Messages API 配置。
+type AnthropicConfig struct {
+	APIBase string `yaml:"api_base" json:"api_base"`
+	APIKey  string `yaml:"api_key" json:"api_key"`
+	Model   string `yaml:"model" json:"model"`
+}
+
+const anthropicAPIVersion = "2023-06-01"
+
+// AnthropicClient 实现 UsageClient，调用 Anthropic 的 /v1/messages 接口。
+type AnthropicClient struct {
+	cfg    AnthropicConfig
+	client *http.Client
+}
+
+// NewAnthropicClient 创建客户端，APIBase 为空时使用官方地址。
+func NewAnthropicClient(cfg AnthropicConfig, httpClient *http.Client) *AnthropicClient {
+	base := strings.TrimSpace(cfg.APIBase)
+	if base == "" {
+		base = "https://api.anthropic.com/v1"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "claude-3-5-sonnet-latest"
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &AnthropicClient{cfg: AnthropicConfig{APIBase: base, APIKey: cfg.APIKey, Model: model}, client: httpClient}
+}
+
+func (c *AnthropicClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 同 Complete，同时返回响应 usage 字段携带的 input/output token 数。
+func (c *AnthropicClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if strings.TrimSpace(c.cfg.APIKey) == "" {
+		return "", LLMUsage{}, fmt.Errorf("anthropic api key missing")
+	}
+
+	payload := anthropicRequest{
+		Model:     c.cfg.Model,
+		MaxTokens: 4096,
+		Messages: []anthropicMessage{
+			{Role: "user", Content: prompt},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.cfg.APIBase, "/")+"/messages", bytes.NewReader(data))
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("x-api-key", c.cfg.APIKey)
+	req.Header.Set("anthropic-version", anthropicAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("anthropic request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", LLMUsage{}, &HTTPStatusError{Provider: "anthropic", StatusCode: resp.StatusCode}
+	}
+
+	var body anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", LLMUsage{}, fmt.Errorf("decode anthropic response: %w", err)
+	}
+
+	if len(body.Content) == 0 || body.Content[0].Text == "" {
+		return "", LLMUsage{}, fmt.Errorf("anthropic response empty")
+	}
+
+	usage := LLMUsage{PromptTokens: body.Usage.InputTokens, CompletionTokens: body.Usage.OutputTokens}
+	return strings.TrimSpace(body.Content[0].Text), usage, nil
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
@@ -0,0 +1,126 @@
+package processor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// ErrSpendCapExceeded 在当日 LLM 预估花费超过 SpendLimiterConfig.DailyCapUSD 时返回，
+// 调用方（Processor.Process）应将其视为暂时性失败。
+var ErrSpendCapExceeded = errors.New("processor: daily llm spend cap exceeded")
+
+// CacheStore 抽象 CachingClient 所需的缓存与用量持久化操作，由 *storage.Store 实现。
+type CacheStore interface {
+	GetLLMCache(ctx context.Context, hash string) (string, bool, error)
+	SaveLLMCache(ctx context.Context, hash, prompt, response string) error
+	RecordLLMUsage(ctx context.Context, record model.LLMUsageRecord) error
+	DailyLLMSpend(ctx context.Context, since time.Time) (float64, error)
+}
+
+// ModelPrice 描述每百万 token 的价格（USD），用于从 token 用量估算花费。
+type ModelPrice struct {
+	PromptPerMillion     float64 `yaml:"prompt_per_million" json:"prompt_per_million"`
+	CompletionPerMillion float64 `yaml:"completion_per_million" json:"completion_per_million"`
+}
+
+// PriceTable 按 model 名称查价，未配置的 model 估算花费为 0。
+type PriceTable map[string]ModelPrice
+
+// Estimate 按 usage 估算一次调用的花费（USD）。
+func (t PriceTable) Estimate(modelName string, usage LLMUsage) float64 {
+	price, ok := t[modelName]
+	if !ok {
+		return 0
+	}
+	return float64(usage.PromptTokens)/1_000_000*price.PromptPerMillion +
+		float64(usage.CompletionTokens)/1_000_000*price.CompletionPerMillion
+}
+
+// SpendLimiterConfig 控制 LLM 调用每日花费上限，DailyCapUSD <= 0 表示不限制。
+type SpendLimiterConfig struct {
+	DailyCapUSD float64 `yaml:"daily_cap_usd" json:"daily_cap_usd"`
+}
+
+// CachingClient 包装任意 LLMClient：先查 CacheStore 命中则免调用，未命中时按 model 估算花费，
+// 超过 SpendLimiterConfig.DailyCapUSD 时拒绝新调用（ErrSpendCapExceeded），否则调用底层 LLMClient
+// 并把响应与用量写回 CacheStore。实现 UsageClient，便于 Processor 把用量记入 Result.Trace。
+type CachingClient struct {
+	next     LLMClient
+	store    CacheStore
+	provider string
+	model    string
+	prices   PriceTable
+	limit    SpendLimiterConfig
+}
+
+// NewCachingClient 创建 CachingClient，provider/model 用于按 PriceTable 查价与记录 llm_usage。
+func NewCachingClient(next LLMClient, store CacheStore, provider, model string, prices PriceTable, limit SpendLimiterConfig) *CachingClient {
+	return &CachingClient{next: next, store: store, provider: provider, model: model, prices: prices, limit: limit}
+}
+
+func (c *CachingClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 先查缓存，未命中时检查花费上限、调用底层 LLMClient，并把响应/用量写回 CacheStore。
+func (c *CachingClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	hash := hashPrompt(prompt)
+
+	if cached, ok, err := c.store.GetLLMCache(ctx, hash); err == nil && ok {
+		return cached, LLMUsage{}, nil
+	}
+
+	if c.limit.DailyCapUSD > 0 {
+		since := time.Now().Truncate(24 * time.Hour)
+		spent, err := c.store.DailyLLMSpend(ctx, since)
+		if err == nil && spent >= c.limit.DailyCapUSD {
+			return "", LLMUsage{}, ErrSpendCapExceeded
+		}
+	}
+
+	start := time.Now()
+	text, usage, err := c.completeNext(ctx, prompt)
+	latency := time.Since(start)
+	if err != nil {
+		_ = c.store.RecordLLMUsage(ctx, model.LLMUsageRecord{
+			Provider:  c.provider,
+			Model:     c.model,
+			LatencyMS: latency.Milliseconds(),
+			Outcome:   "error",
+		})
+		return "", LLMUsage{}, err
+	}
+
+	cost := c.prices.Estimate(c.model, usage)
+	_ = c.store.RecordLLMUsage(ctx, model.LLMUsageRecord{
+		Provider:         c.provider,
+		Model:            c.model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		CostUSD:          cost,
+		LatencyMS:        latency.Milliseconds(),
+		Outcome:          "success",
+	})
+	_ = c.store.SaveLLMCache(ctx, hash, prompt, text)
+
+	return text, usage, nil
+}
+
+func (c *CachingClient) completeNext(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if uc, ok := c.next.(UsageClient); ok {
+		return uc.CompleteWithUsage(ctx, prompt)
+	}
+	text, err := c.next.Complete(ctx, prompt)
+	return text, LLMUsage{}, err
+}
+
+func hashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
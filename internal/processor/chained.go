@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// namedClient 为 ChainedClient 的一个候选项，Name 为供应商名称，在所有候选均失败时出现在最终错误信息里。
+type namedClient struct {
+	Name   string
+	Client LLMClient
+}
+
+// ChainedClient 依次尝试一组供应商：每个供应商失败且 isRetryableLLMError 判定可重试时，按指数退避
+// +抖动重试最多 MaxAttempts 次，仍失败则换下一个供应商；非重试性错误立即换下一个供应商。
+// 全部供应商均失败时返回汇总了各供应商最后一次错误的错误。实现 UsageClient，返回命中供应商自身的 token 用量。
+type ChainedClient struct {
+	clients     []namedClient
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// NewChainedClient 创建 ChainedClient，maxAttempts/baseDelay 非正时分别取默认值 3 次、200ms。
+func NewChainedClient(clients []namedClient, maxAttempts int, baseDelay time.Duration) *ChainedClient {
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if baseDelay <= 0 {
+		baseDelay = 200 * time.Millisecond
+	}
+	return &ChainedClient{clients: clients, maxAttempts: maxAttempts, baseDelay: baseDelay}
+}
+
+func (c *ChainedClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 按顺序尝试每个供应商，返回第一个成功结果；全部失败时返回各供应商最后一次错误的汇总。
+func (c *ChainedClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if len(c.clients) == 0 {
+		return "", LLMUsage{}, fmt.Errorf("chained client: no providers configured")
+	}
+
+	var errs []string
+	for _, nc := range c.clients {
+		text, usage, err := c.completeWithRetry(ctx, nc, prompt)
+		if err == nil {
+			return text, usage, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", nc.Name, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", LLMUsage{}, fmt.Errorf("all llm providers failed: %s", strings.Join(errs, "; "))
+}
+
+func (c *ChainedClient) completeWithRetry(ctx context.Context, nc namedClient, prompt string) (string, LLMUsage, error) {
+	var lastErr error
+	for attempt := 0; attempt < c.maxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := c.wait(ctx, attempt); err != nil {
+				return "", LLMUsage{}, err
+			}
+		}
+
+		text, usage, err := c.completeOne(ctx, nc.Client, prompt)
+		if err == nil {
+			return text, usage, nil
+		}
+		lastErr = err
+		if !isRetryableLLMError(err) {
+			return "", LLMUsage{}, err
+		}
+	}
+	return "", LLMUsage{}, lastErr
+}
+
+func (c *ChainedClient) completeOne(ctx context.Context, client LLMClient, prompt string) (string, LLMUsage, error) {
+	if uc, ok := client.(UsageClient); ok {
+		return uc.CompleteWithUsage(ctx, prompt)
+	}
+	text, err := client.Complete(ctx, prompt)
+	return text, LLMUsage{}, err
+}
+
+// BuildChain 依次用 registry 按 providers 列出的供应商名称构建 LLMClient，每个供应商各自包一层
+// CircuitBreaker 后组装为 ChainedClient；providers 通常为 [主供应商, ...LLMConfig.Fallback]。
+func BuildChain(registry *ProviderRegistry, cfg LLMConfig, providers []string, httpClient *http.Client, maxAttempts int, baseDelay time.Duration) (*ChainedClient, error) {
+	clients := make([]namedClient, 0, len(providers))
+	for _, name := range providers {
+		providerCfg := cfg
+		providerCfg.Provider = name
+		built, err := registry.Build(providerCfg, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		wrapped := NewCircuitBreaker(built, name, cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown)
+		clients = append(clients, namedClient{Name: name, Client: wrapped})
+	}
+	return NewChainedClient(clients, maxAttempts, baseDelay), nil
+}
+
+// wait 按指数退避+抖动休眠，退避时长为 baseDelay * 2^(attempt-1) 的基础上叠加最多 50% 的随机抖动。
+func (c *ChainedClient) wait(ctx context.Context, attempt int) error {
+	delay := c.baseDelay << (attempt - 1)
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	timer := time.NewTimer(delay + jitter)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
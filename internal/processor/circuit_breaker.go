@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker 包装一个 LLMClient：连续失败达到 Threshold 次后跳闸，在 Cooldown 内直接拒绝
+// 调用而不再打到下游供应商，Cooldown 过后自动恢复尝试（半开），调用成功则复位失败计数。
+type CircuitBreaker struct {
+	next      LLMClient
+	provider  string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker 创建 CircuitBreaker，threshold/cooldown 非正时分别取默认值 5 次、30 秒。
+func NewCircuitBreaker(next LLMClient, provider string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &CircuitBreaker{next: next, provider: provider, threshold: threshold, cooldown: cooldown}
+}
+
+func (b *CircuitBreaker) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := b.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 在断路器关闭（或半开）时调用底层 LLMClient，断路器打开期间直接返回错误。
+func (b *CircuitBreaker) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if tripped, remaining := b.tripped(); tripped {
+		return "", LLMUsage{}, fmt.Errorf("circuit breaker open for %s, retry in %s", b.provider, remaining)
+	}
+
+	text, usage, err := b.completeNext(ctx, prompt)
+	if err != nil {
+		b.recordFailure()
+		return "", LLMUsage{}, err
+	}
+	b.recordSuccess()
+	return text, usage, nil
+}
+
+func (b *CircuitBreaker) completeNext(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if uc, ok := b.next.(UsageClient); ok {
+		return uc.CompleteWithUsage(ctx, prompt)
+	}
+	text, err := b.next.Complete(ctx, prompt)
+	return text, LLMUsage{}, err
+}
+
+func (b *CircuitBreaker) tripped() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() || time.Now().After(b.openUntil) {
+		return false, 0
+	}
+	return true, time.Until(b.openUntil)
+}
+
+func (b *CircuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+func (b *CircuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
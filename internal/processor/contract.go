@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxRepairAttempts 限制 Processor 在 LLM 响应解析失败或违反 Schema 时的再次提示次数。
+const maxRepairAttempts = 2
+
+// JSONClient 由支持结构化输出（如 OpenAI 的 response_format: json_schema）的 LLMClient 实现，
+// Processor 优先调用 CompleteJSON 以绕过修复循环：返回内容已由供应商按 schema 校验过。
+type JSONClient interface {
+	LLMClient
+	CompleteJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error)
+}
+
+// PromptContract 依据 Config 的枚举候选项（EmploymentTypes/SalaryRanges/RoleCategories/
+// LanguageOptions）生成注入提示词的 JSON Schema，并在收到 LLM 响应后据此校验，
+// 使这些候选项真正约束 LLM 输出的枚举取值，而不是事后过滤。TagCandidates 不进入 Schema 也不参与
+// 校验：tags 中不在候选表内的取值由 Processor.buildJob 丢弃。
+type PromptContract struct {
+	schemaText      string
+	employmentTypes map[string]bool
+	salaryRanges    map[string]bool
+	roleCategories  map[string]bool
+	languageOptions map[string]bool
+}
+
+// NewPromptContract 依据 cfg 构建 Schema；某一枚举候选列表为空时不约束该字段的取值。
+func NewPromptContract(cfg Config) *PromptContract {
+	contract := &PromptContract{
+		employmentTypes: toSet(cfg.EmploymentTypes),
+		salaryRanges:    toSet(cfg.SalaryRanges),
+		roleCategories:  toSet(cfg.RoleCategories),
+		languageOptions: toSet(cfg.LanguageOptions),
+	}
+
+	properties := map[string]interface{}{
+		"is_remote":  map[string]interface{}{"type": "boolean"},
+		"summary":    map[string]interface{}{"type": "string"},
+		"verdict":    map[string]interface{}{"type": "string"},
+		"score":      map[string]interface{}{"type": "integer"},
+		"tags":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		"skill_tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+	}
+	addEnumProperty(properties, "employment_type", cfg.EmploymentTypes)
+	addEnumProperty(properties, "salary_range", cfg.SalaryRanges)
+	addEnumProperty(properties, "role_category", cfg.RoleCategories)
+	addEnumProperty(properties, "language_requirement", cfg.LanguageOptions)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"is_remote"},
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		data = []byte("{}")
+	}
+	contract.schemaText = string(data)
+	return contract
+}
+
+func addEnumProperty(properties map[string]interface{}, name string, candidates []string) {
+	prop := map[string]interface{}{"type": "string"}
+	if len(candidates) > 0 {
+		prop["enum"] = candidates
+	}
+	properties[name] = prop
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if trimmed := strings.TrimSpace(v); trimmed != "" {
+			set[trimmed] = true
+		}
+	}
+	return set
+}
+
+// Schema 返回注入提示词、描述期望响应结构的 JSON Schema 文本。
+func (c *PromptContract) Schema() string {
+	return c.schemaText
+}
+
+// Validate 将 raw 解析为 llmClassification，并校验配置了候选列表的枚举字段是否落在其中；
+// 返回的 error 同时作为修复循环再次提示 LLM 的依据。
+func (c *PromptContract) Validate(raw string) (llmClassification, error) {
+	var payload llmClassification
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return llmClassification{}, fmt.Errorf("invalid json: %w", err)
+	}
+	if err := validateEnum("employment_type", payload.EmploymentType, c.employmentTypes); err != nil {
+		return llmClassification{}, err
+	}
+	if err := validateEnum("salary_range", payload.SalaryRange, c.salaryRanges); err != nil {
+		return llmClassification{}, err
+	}
+	if err := validateEnum("role_category", payload.RoleCategory, c.roleCategories); err != nil {
+		return llmClassification{}, err
+	}
+	if err := validateEnum("language_requirement", payload.LanguageRequirement, c.languageOptions); err != nil {
+		return llmClassification{}, err
+	}
+	return payload, nil
+}
+
+func validateEnum(field, value string, allowed map[string]bool) error {
+	if len(allowed) == 0 || value == "" {
+		return nil
+	}
+	if !allowed[value] {
+		return fmt.Errorf("field %q value %q is not one of the configured candidates", field, value)
+	}
+	return nil
+}
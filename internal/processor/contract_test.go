@@ -0,0 +1,54 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptContractSchemaIncludesConfiguredEnums(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{EmploymentTypes: []string{"全职", "兼职"}, RoleCategories: []string{"后端开发工程师"}}
+	contract := NewPromptContract(cfg)
+
+	schema := contract.Schema()
+	if !strings.Contains(schema, "全职") || !strings.Contains(schema, "后端开发工程师") {
+		t.Fatalf("expected schema to embed configured enum candidates, got %s", schema)
+	}
+}
+
+func TestPromptContractValidateRejectsUnknownEnum(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{EmploymentTypes: []string{"全职", "兼职"}}
+	contract := NewPromptContract(cfg)
+
+	_, err := contract.Validate(`{"is_remote":true,"employment_type":"外包"}`)
+	if err == nil {
+		t.Fatalf("expected validation error for employment_type outside configured candidates")
+	}
+}
+
+func TestPromptContractValidateAcceptsConfiguredEnum(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{EmploymentTypes: []string{"全职", "兼职"}}
+	contract := NewPromptContract(cfg)
+
+	payload, err := contract.Validate(`{"is_remote":true,"employment_type":"全职"}`)
+	if err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if !payload.IsRemote || payload.EmploymentType != "全职" {
+		t.Fatalf("expected parsed payload to retain fields, got %#v", payload)
+	}
+}
+
+func TestPromptContractValidateRejectsMalformedJSON(t *testing.T) {
+	t.Parallel()
+
+	contract := NewPromptContract(Config{})
+	if _, err := contract.Validate("not json"); err == nil {
+		t.Fatalf("expected error for malformed json")
+	}
+}
@@ -0,0 +1,93 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// defaultDedupThreshold 为余弦相似度阈值，超过则认为是同一岗位的重复发布。
+// defaultDedupWindowDays 限定相似度比对只回看最近 N 天的 RawJob，避免全表扫描。
+// dedupCandidates 为 FindSimilarRaw 返回的候选数量上限。
+const (
+	defaultDedupThreshold  = 0.92
+	defaultDedupWindowDays = 30
+	dedupCandidates        = 5
+)
+
+// EmbeddingClient 抽象文本向量化调用，由 OpenAIClient 等实现，供 Processor 做语义去重。
+type EmbeddingClient interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// EmbeddingStore 抽象语义去重所需的向量持久化与近似查询操作，由 *storage.Store 实现。
+type EmbeddingStore interface {
+	SaveRawJobEmbedding(ctx context.Context, rawJobID uint, vector []float32) error
+	FindSimilarRaw(ctx context.Context, vector []float32, k int, since time.Time) ([]model.SimilarRawJob, error)
+}
+
+// DedupConfig 控制语义去重的启用与阈值，Enabled 为 false 时 WithDedup 调用方应跳过注入；
+// Threshold <= 0 时使用 defaultDedupThreshold，WindowDays <= 0 时使用 defaultDedupWindowDays。
+type DedupConfig struct {
+	Enabled    bool    `yaml:"enabled" json:"enabled"`
+	Threshold  float64 `yaml:"threshold" json:"threshold"`
+	WindowDays int     `yaml:"window_days" json:"window_days"`
+}
+
+// WithDedup 启用基于 embedding 相似度的语义去重：Process 在关键词初筛通过后、调用 LLM 前，
+// 会将岗位文本向量化并与近期 RawJob 比对，相似度超过阈值的视为重复而直接拒绝，从而跳过一次
+// LLM 调用。未调用 WithDedup 时 Processor 行为不变。
+func (p *Processor) WithDedup(embedder EmbeddingClient, store EmbeddingStore, cfg DedupConfig) *Processor {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultDedupThreshold
+	}
+	if cfg.WindowDays <= 0 {
+		cfg.WindowDays = defaultDedupWindowDays
+	}
+	p.embedder = embedder
+	p.embedStore = store
+	p.dedupCfg = cfg
+	return p
+}
+
+// checkDuplicate 向量化 text 并与近期 RawJob 比对，返回命中的 RawJobID（0 表示未命中）。
+// 向量化或查询失败时按仓库约定 fail-open：记录日志后放行，交由后续 LLM 判断。
+func (p *Processor) checkDuplicate(ctx context.Context, raw model.RawJob, text string) uint {
+	if p.embedder == nil || p.embedStore == nil {
+		return 0
+	}
+
+	vector, err := p.embedder.Embed(ctx, text)
+	if err != nil {
+		log.Printf("dedup: embed raw job %d failed: %v", raw.ID, err)
+		return 0
+	}
+
+	since := time.Now().Add(-time.Duration(p.dedupCfg.WindowDays) * 24 * time.Hour)
+	similar, err := p.embedStore.FindSimilarRaw(ctx, vector, dedupCandidates, since)
+	if err != nil {
+		log.Printf("dedup: find similar raw jobs for %d failed: %v", raw.ID, err)
+		return 0
+	}
+
+	if err := p.embedStore.SaveRawJobEmbedding(ctx, raw.ID, vector); err != nil {
+		log.Printf("dedup: save embedding for raw job %d failed: %v", raw.ID, err)
+	}
+
+	for _, candidate := range similar {
+		if candidate.RawJobID == raw.ID {
+			continue
+		}
+		if candidate.Score >= p.dedupCfg.Threshold {
+			return candidate.RawJobID
+		}
+	}
+	return 0
+}
+
+func duplicateReason(rawJobID uint) string {
+	return fmt.Sprintf("duplicate_of:%d", rawJobID)
+}
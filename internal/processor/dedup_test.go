@@ -0,0 +1,114 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestProcessorRejectsDuplicateAboveThreshold(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Keywords: []string{"remote"}}
+	llm := &stubLLM{response: `{"is_remote":true}`}
+	p := New(cfg, llm)
+
+	embedder := &stubEmbedder{vector: []float32{1, 0}}
+	store := &stubEmbedStore{similar: []model.SimilarRawJob{{RawJobID: 7, Score: 0.95}}}
+	p.WithDedup(embedder, store, DedupConfig{Threshold: 0.9})
+
+	raw := model.RawJob{ID: 1, Source: "eleduck", ExternalID: "ext-1", Title: "Remote Go Dev"}
+	res, err := p.Process(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	if res.Outcome != ResultRejected {
+		t.Fatalf("expected rejection for near-duplicate, got %v", res.Outcome)
+	}
+	if res.Reason != "duplicate_of:7" {
+		t.Fatalf("expected duplicate reason to reference raw job id, got %q", res.Reason)
+	}
+	if llm.calls != 0 {
+		t.Fatalf("expected no llm call once duplicate detected, got %d", llm.calls)
+	}
+	if !store.saved {
+		t.Fatalf("expected embedding to be persisted even when duplicate detected")
+	}
+}
+
+func TestProcessorAcceptsBelowThreshold(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Keywords: []string{"remote"}}
+	llm := &stubLLM{response: `{"is_remote":true}`}
+	p := New(cfg, llm)
+
+	embedder := &stubEmbedder{vector: []float32{1, 0}}
+	store := &stubEmbedStore{similar: []model.SimilarRawJob{{RawJobID: 7, Score: 0.5}}}
+	p.WithDedup(embedder, store, DedupConfig{Threshold: 0.9})
+
+	raw := model.RawJob{ID: 1, Source: "eleduck", ExternalID: "ext-1", Title: "Remote Go Dev"}
+	res, err := p.Process(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	if res.Outcome != ResultAccepted {
+		t.Fatalf("expected acceptance when similarity below threshold, got %v", res.Outcome)
+	}
+	if llm.calls != 1 {
+		t.Fatalf("expected llm call once no duplicate found, got %d", llm.calls)
+	}
+}
+
+func TestProcessorFailsOpenWhenEmbedErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Keywords: []string{"remote"}}
+	llm := &stubLLM{response: `{"is_remote":true}`}
+	p := New(cfg, llm)
+
+	embedder := &stubEmbedder{err: context.DeadlineExceeded}
+	store := &stubEmbedStore{}
+	p.WithDedup(embedder, store, DedupConfig{})
+
+	raw := model.RawJob{ID: 1, Source: "eleduck", ExternalID: "ext-1", Title: "Remote Go Dev"}
+	res, err := p.Process(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	if res.Outcome != ResultAccepted {
+		t.Fatalf("expected fail-open to proceed to llm when embedding fails, got %v", res.Outcome)
+	}
+}
+
+type stubEmbedder struct {
+	vector []float32
+	err    error
+}
+
+func (s *stubEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.vector, nil
+}
+
+type stubEmbedStore struct {
+	similar []model.SimilarRawJob
+	err     error
+	saved   bool
+}
+
+func (s *stubEmbedStore) SaveRawJobEmbedding(ctx context.Context, rawJobID uint, vector []float32) error {
+	s.saved = true
+	return nil
+}
+
+func (s *stubEmbedStore) FindSimilarRaw(ctx context.Context, vector []float32, k int, since time.Time) ([]model.SimilarRawJob, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.similar, nil
+}
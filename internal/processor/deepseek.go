@@ -40,8 +40,14 @@ func NewDeepseekClient(cfg DeepseekConfig, httpClient *http.Client) *DeepseekCli
 }
 
 func (c *DeepseekClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 同 Complete，同时返回响应中携带的 prompt/completion token 数，实现 UsageClient。
+func (c *DeepseekClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
 	if strings.TrimSpace(c.cfg.APIKey) == "" {
-		return "", fmt.Errorf("deepseek api key missing")
+		return "", LLMUsage{}, fmt.Errorf("deepseek api key missing")
 	}
 
 	payload := deepseekRequest{
@@ -54,36 +60,37 @@ func (c *DeepseekClient) Complete(ctx context.Context, prompt string) (string, e
 
 	data, err := json.Marshal(payload)
 	if err != nil {
-		return "", fmt.Errorf("marshal payload: %w", err)
+		return "", LLMUsage{}, fmt.Errorf("marshal payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.cfg.APIBase, "/")+"/chat/completions", bytes.NewReader(data))
 	if err != nil {
-		return "", fmt.Errorf("new request: %w", err)
+		return "", LLMUsage{}, fmt.Errorf("new request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("deepseek request: %w", err)
+		return "", LLMUsage{}, fmt.Errorf("deepseek request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		return "", fmt.Errorf("deepseek http %d", resp.StatusCode)
+		return "", LLMUsage{}, &HTTPStatusError{Provider: "deepseek", StatusCode: resp.StatusCode}
 	}
 
 	var body deepseekResponse
 	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-		return "", fmt.Errorf("decode deepseek response: %w", err)
+		return "", LLMUsage{}, fmt.Errorf("decode deepseek response: %w", err)
 	}
 
 	if len(body.Choices) == 0 || body.Choices[0].Message.Content == "" {
-		return "", fmt.Errorf("deepseek response empty")
+		return "", LLMUsage{}, fmt.Errorf("deepseek response empty")
 	}
 
-	return strings.TrimSpace(body.Choices[0].Message.Content), nil
+	usage := LLMUsage{PromptTokens: body.Usage.PromptTokens, CompletionTokens: body.Usage.CompletionTokens}
+	return strings.TrimSpace(body.Choices[0].Message.Content), usage, nil
 }
 
 type deepseekRequest struct {
@@ -102,4 +109,8 @@ type deepseekResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
 }
@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GeminiConfig 定义 Google Gemini generateContent 接口的配置。
+type GeminiConfig struct {
+	APIBase string `yaml:"api_base" json:"api_base"`
+	APIKey  string `yaml:"api_key" json:"api_key"`
+	Model   string `yaml:"model" json:"model"`
+}
+
+// GeminiClient 实现 UsageClient，调用 Gemini 的 v1beta generateContent 接口。
+type GeminiClient struct {
+	cfg    GeminiConfig
+	client *http.Client
+}
+
+// NewGeminiClient 创建客户端，APIBase 为空时使用官方地址。
+func NewGeminiClient(cfg GeminiConfig, httpClient *http.Client) *GeminiClient {
+	base := strings.TrimSpace(cfg.APIBase)
+	if base == "" {
+		base = "https://generativelanguage.googleapis.com/v1beta"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "gemini-1.5-flash"
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &GeminiClient{cfg: GeminiConfig{APIBase: base, APIKey: cfg.APIKey, Model: model}, client: httpClient}
+}
+
+func (c *GeminiClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 同 Complete，同时返回响应 usageMetadata 携带的 prompt/completion token 数。
+func (c *GeminiClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if strings.TrimSpace(c.cfg.APIKey) == "" {
+		return "", LLMUsage{}, fmt.Errorf("gemini api key missing")
+	}
+
+	payload := geminiRequest{
+		Contents: []geminiContent{
+			{Parts: []geminiPart{{Text: prompt}}},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent?key=%s",
+		strings.TrimRight(c.cfg.APIBase, "/"), c.cfg.Model, url.QueryEscape(c.cfg.APIKey))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("gemini request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", LLMUsage{}, &HTTPStatusError{Provider: "gemini", StatusCode: resp.StatusCode}
+	}
+
+	var body geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", LLMUsage{}, fmt.Errorf("decode gemini response: %w", err)
+	}
+
+	if len(body.Candidates) == 0 || len(body.Candidates[0].Content.Parts) == 0 {
+		return "", LLMUsage{}, fmt.Errorf("gemini response empty")
+	}
+
+	usage := LLMUsage{PromptTokens: body.UsageMetadata.PromptTokenCount, CompletionTokens: body.UsageMetadata.CandidatesTokenCount}
+	return strings.TrimSpace(body.Candidates[0].Content.Parts[0].Text), usage, nil
+}
+
+type geminiRequest struct {
+	Contents []geminiContent `json:"contents"`
+}
+
+type geminiContent struct {
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content geminiContent `json:"content"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
+}
@@ -0,0 +1,45 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// HTTPStatusError 包装一次 LLM 供应商 HTTP 调用的非 2xx 响应，ChainedClient 据此判断该次失败
+// 是否值得重试或切换到下一个供应商（429/5xx 视为临时性故障）。
+type HTTPStatusError struct {
+	Provider   string
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s http %d", e.Provider, e.StatusCode)
+}
+
+// Retryable 报告该状态码是否值得退避重试或切换供应商：429（限流）与 5xx（服务端故障）可重试，
+// 其余 4xx（如鉴权失败、参数错误）重试无意义。
+func (e *HTTPStatusError) Retryable() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
+
+// isRetryableLLMError 判断一次 LLMClient 调用失败是否值得重试：HTTPStatusError 的 429/5xx、
+// 上下文超时与网络层超时均视为临时性故障。
+func isRetryableLLMError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return false
+}
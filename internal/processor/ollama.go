@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OllamaConfig 定义本地 Ollama 服务的配置，不需要 APIKey。
+type OllamaConfig struct {
+	APIBase string `yaml:"api_base" json:"api_base"`
+	Model   string `yaml:"model" json:"model"`
+}
+
+// OllamaClient 实现 UsageClient，调用本地 Ollama 的 /api/generate 接口。
+type OllamaClient struct {
+	cfg    OllamaConfig
+	client *http.Client
+}
+
+// NewOllamaClient 创建客户端，APIBase 为空时使用本机默认地址。
+func NewOllamaClient(cfg OllamaConfig, httpClient *http.Client) *OllamaClient {
+	base := strings.TrimSpace(cfg.APIBase)
+	if base == "" {
+		base = "http://localhost:11434"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "llama3"
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &OllamaClient{cfg: OllamaConfig{APIBase: base, Model: model}, client: httpClient}
+}
+
+func (c *OllamaClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 同 Complete，同时返回 prompt_eval_count/eval_count 换算出的 token 用量。
+func (c *OllamaClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	payload := ollamaRequest{Model: c.cfg.Model, Prompt: prompt, Stream: false}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.cfg.APIBase, "/")+"/api/generate", bytes.NewReader(data))
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("ollama request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", LLMUsage{}, &HTTPStatusError{Provider: "ollama", StatusCode: resp.StatusCode}
+	}
+
+	var body ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", LLMUsage{}, fmt.Errorf("decode ollama response: %w", err)
+	}
+
+	if body.Response == "" {
+		return "", LLMUsage{}, fmt.Errorf("ollama response empty")
+	}
+
+	usage := LLMUsage{PromptTokens: body.PromptEvalCount, CompletionTokens: body.EvalCount}
+	return strings.TrimSpace(body.Response), usage, nil
+}
+
+type ollamaRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+}
+
+type ollamaResponse struct {
+	Response        string `json:"response"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
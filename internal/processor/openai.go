@@ -0,0 +1,237 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIConfig 定义 OpenAI 兼容 Chat Completions 接口的配置，同样适用于绝大多数声称
+// "OpenAI 兼容" 的第三方网关。EmbeddingModel 为空时使用 text-embedding-3-small。
+type OpenAIConfig struct {
+	APIBase        string `yaml:"api_base" json:"api_base"`
+	APIKey         string `yaml:"api_key" json:"api_key"`
+	Model          string `yaml:"model" json:"model"`
+	EmbeddingModel string `yaml:"embedding_model" json:"embedding_model"`
+}
+
+// OpenAIClient 实现 UsageClient，请求体与 DeepseekClient 一致（均遵循 OpenAI Chat Completions 协议）。
+type OpenAIClient struct {
+	cfg    OpenAIConfig
+	client *http.Client
+}
+
+// NewOpenAIClient 创建客户端，APIBase 为空时使用官方地址。
+func NewOpenAIClient(cfg OpenAIConfig, httpClient *http.Client) *OpenAIClient {
+	base := strings.TrimSpace(cfg.APIBase)
+	if base == "" {
+		base = "https://api.openai.com/v1"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+	embeddingModel := cfg.EmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = "text-embedding-3-small"
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &OpenAIClient{cfg: OpenAIConfig{APIBase: base, APIKey: cfg.APIKey, Model: model, EmbeddingModel: embeddingModel}, client: httpClient}
+}
+
+func (c *OpenAIClient) Complete(ctx context.Context, prompt string) (string, error) {
+	text, _, err := c.CompleteWithUsage(ctx, prompt)
+	return text, err
+}
+
+// CompleteWithUsage 同 Complete，同时返回响应中携带的 prompt/completion token 数。
+func (c *OpenAIClient) CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if strings.TrimSpace(c.cfg.APIKey) == "" {
+		return "", LLMUsage{}, fmt.Errorf("openai api key missing")
+	}
+
+	payload := deepseekRequest{
+		Model: c.cfg.Model,
+		Messages: []deepseekMessage{
+			{Role: "system", Content: "You are a helpful talent acquisition assistant."},
+			{Role: "user", Content: prompt},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.cfg.APIBase, "/")+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", LLMUsage{}, fmt.Errorf("openai request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", LLMUsage{}, &HTTPStatusError{Provider: "openai", StatusCode: resp.StatusCode}
+	}
+
+	var body openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", LLMUsage{}, fmt.Errorf("decode openai response: %w", err)
+	}
+
+	if len(body.Choices) == 0 || body.Choices[0].Message.Content == "" {
+		return "", LLMUsage{}, fmt.Errorf("openai response empty")
+	}
+
+	usage := LLMUsage{PromptTokens: body.Usage.PromptTokens, CompletionTokens: body.Usage.CompletionTokens}
+	return strings.TrimSpace(body.Choices[0].Message.Content), usage, nil
+}
+
+// CompleteJSON 通过 response_format: json_schema 让 OpenAI 在服务端强制响应符合 schema，
+// 实现 JSONClient，使 Processor 跳过本地的修复重试循环。
+func (c *OpenAIClient) CompleteJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error) {
+	if strings.TrimSpace(c.cfg.APIKey) == "" {
+		return "", fmt.Errorf("openai api key missing")
+	}
+
+	payload := openAIJSONRequest{
+		Model: c.cfg.Model,
+		Messages: []deepseekMessage{
+			{Role: "system", Content: "You are a helpful talent acquisition assistant."},
+			{Role: "user", Content: prompt},
+		},
+		ResponseFormat: openAIResponseFormat{
+			Type: "json_schema",
+			JSONSchema: openAIJSONSchema{
+				Name:   "job_classification",
+				Schema: schema,
+			},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.cfg.APIBase, "/")+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", &HTTPStatusError{Provider: "openai", StatusCode: resp.StatusCode}
+	}
+
+	var body openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode openai response: %w", err)
+	}
+	if len(body.Choices) == 0 || body.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("openai response empty")
+	}
+	return strings.TrimSpace(body.Choices[0].Message.Content), nil
+}
+
+type openAIJSONRequest struct {
+	Model          string               `json:"model"`
+	Messages       []deepseekMessage    `json:"messages"`
+	ResponseFormat openAIResponseFormat `json:"response_format"`
+}
+
+type openAIResponseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema openAIJSONSchema `json:"json_schema"`
+}
+
+type openAIJSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+}
+
+// Embed 调用 OpenAI Embeddings 接口，将 text 向量化供语义去重使用，实现 EmbeddingClient。
+func (c *OpenAIClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	if strings.TrimSpace(c.cfg.APIKey) == "" {
+		return nil, fmt.Errorf("openai api key missing")
+	}
+
+	payload := openAIEmbeddingRequest{Model: c.cfg.EmbeddingModel, Input: text}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.cfg.APIBase, "/")+"/embeddings", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, &HTTPStatusError{Provider: "openai", StatusCode: resp.StatusCode}
+	}
+
+	var body openAIEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode openai response: %w", err)
+	}
+	if len(body.Data) == 0 {
+		return nil, fmt.Errorf("openai embedding response empty")
+	}
+
+	vector := make([]float32, len(body.Data[0].Embedding))
+	for i, v := range body.Data[0].Embedding {
+		vector[i] = float32(v)
+	}
+	return vector, nil
+}
+
+type openAIEmbeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
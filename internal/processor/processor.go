@@ -2,7 +2,6 @@ package processor
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -22,6 +21,8 @@ type Config struct {
 	LanguageOptions []string       `yaml:"language_options" json:"language_options"`
 	BatchSize       int            `yaml:"batch_size" json:"batch_size"`
 	Deepseek        DeepseekConfig `yaml:"deepseek" json:"deepseek"`
+	LLM             LLMConfig      `yaml:"llm" json:"llm"`
+	Dedup           DedupConfig    `yaml:"dedup" json:"dedup"`
 }
 
 // LLMClient 抽象大模型调用，便于测试注入。
@@ -52,9 +53,13 @@ type Result struct {
 
 // Processor 组合 LLM 与规则实现 JobProcessor。
 type Processor struct {
-	cfg       Config
-	llm       LLMClient
-	tagLookup map[string]string
+	cfg        Config
+	llm        LLMClient
+	tagLookup  map[string]string
+	contract   *PromptContract
+	embedder   EmbeddingClient
+	embedStore EmbeddingStore
+	dedupCfg   DedupConfig
 }
 
 // New 创建 Processor。
@@ -68,7 +73,7 @@ func New(cfg Config, llm LLMClient) *Processor {
 			tagLookup[strings.ToLower(trimmed)] = trimmed
 		}
 	}
-	return &Processor{cfg: cfg, llm: llm, tagLookup: tagLookup}
+	return &Processor{cfg: cfg, llm: llm, tagLookup: tagLookup, contract: NewPromptContract(cfg)}
 }
 
 // Process 执行关键词初筛 + LLM 归一化。
@@ -78,17 +83,23 @@ func (p *Processor) Process(ctx context.Context, raw model.RawJob) (Result, erro
 		return Result{Outcome: ResultRejected, Reason: "missing required keywords"}, nil
 	}
 
+	if dupID := p.checkDuplicate(ctx, raw, text); dupID != 0 {
+		return Result{Outcome: ResultRejected, Reason: duplicateReason(dupID)}, nil
+	}
+
 	prompt := p.buildPrompt(raw, text)
-	respText, err := p.llm.Complete(ctx, prompt)
+	payload, respText, usage, repairAttempts, err := p.completeStructured(ctx, prompt)
 	if err != nil {
 		return Result{}, fmt.Errorf("llm complete: %w", err)
 	}
 
 	trace := datatypes.JSONMap{"prompt": prompt, "llm_response": respText}
-
-	var payload llmClassification
-	if err := json.Unmarshal([]byte(respText), &payload); err != nil {
-		return Result{}, fmt.Errorf("parse llm response: %w", err)
+	if usage != (LLMUsage{}) {
+		trace["prompt_tokens"] = usage.PromptTokens
+		trace["completion_tokens"] = usage.CompletionTokens
+	}
+	if repairAttempts > 0 {
+		trace["repair_attempts"] = repairAttempts
 	}
 
 	if !payload.IsRemote {
@@ -103,6 +114,58 @@ func (p *Processor) Process(ctx context.Context, raw model.RawJob) (Result, erro
 	return Result{Outcome: ResultAccepted, Job: &job, Trace: trace}, nil
 }
 
+// complete 调用底层 LLMClient，若其实现 UsageClient（如 CachingClient）则一并取回 token 用量供写入 Trace。
+func (p *Processor) complete(ctx context.Context, prompt string) (string, LLMUsage, error) {
+	if uc, ok := p.llm.(UsageClient); ok {
+		return uc.CompleteWithUsage(ctx, prompt)
+	}
+	text, err := p.llm.Complete(ctx, prompt)
+	return text, LLMUsage{}, err
+}
+
+// completeStructured 调用 LLM 并依据 p.contract 校验其响应，返回解析后的 llmClassification、
+// 原始响应文本、token 用量与实际发生的修复轮数。若底层 LLMClient 实现 JSONClient（支持结构化
+// 输出），直接调用 CompleteJSON 绕过修复循环；否则在解析失败或违反 Schema 时，最多重试
+// maxRepairAttempts 次，把校验错误连同上一次输出一并回传给 LLM 要求修正。
+func (p *Processor) completeStructured(ctx context.Context, prompt string) (llmClassification, string, LLMUsage, int, error) {
+	if jc, ok := p.llm.(JSONClient); ok {
+		respText, err := jc.CompleteJSON(ctx, prompt, []byte(p.contract.Schema()))
+		if err != nil {
+			return llmClassification{}, "", LLMUsage{}, 0, fmt.Errorf("complete json: %w", err)
+		}
+		payload, err := p.contract.Validate(respText)
+		if err != nil {
+			return llmClassification{}, "", LLMUsage{}, 0, fmt.Errorf("structured response violates schema: %w", err)
+		}
+		return payload, respText, LLMUsage{}, 0, nil
+	}
+
+	respText, usage, err := p.complete(ctx, prompt)
+	if err != nil {
+		return llmClassification{}, "", LLMUsage{}, 0, err
+	}
+
+	payload, verr := p.contract.Validate(respText)
+	attempts := 0
+	for verr != nil && attempts < maxRepairAttempts {
+		attempts++
+		respText, usage, err = p.complete(ctx, p.buildRepairPrompt(respText, verr))
+		if err != nil {
+			return llmClassification{}, "", LLMUsage{}, attempts, err
+		}
+		payload, verr = p.contract.Validate(respText)
+	}
+	if verr != nil {
+		return llmClassification{}, "", LLMUsage{}, attempts, fmt.Errorf("response failed schema validation after %d repair attempts: %w", attempts, verr)
+	}
+	return payload, respText, usage, attempts, nil
+}
+
+// buildRepairPrompt 把上一次不合规的输出与校验错误一并回传给 LLM，要求只返回修正后的 JSON 对象。
+func (p *Processor) buildRepairPrompt(prevOutput string, validationErr error) string {
+	return fmt.Sprintf("你上一次的输出未通过校验：%s\n上一次输出：%s\n请只返回修正后的 JSON 对象，不要包含任何解释性文字。", validationErr, prevOutput)
+}
+
 func (p *Processor) containsKeyword(text string) bool {
 	if len(p.cfg.Keywords) == 0 {
 		return true
@@ -129,7 +192,7 @@ func (p *Processor) buildPrompt(raw model.RawJob, text string) string {
 	prompt := strings.ReplaceAll(template, "{{TEXT}}", text)
 	prompt = strings.ReplaceAll(prompt, "{{TAGS}}", tagList)
 
-	instructions := `\n请严格输出 JSON，对象字段:{"is_remote":bool,"summary":string,"verdict":string,"employment_type":string,"salary_range":string,"role_category":string,"language_requirement":string,"score":int,"tags":string数组,"skill_tags":string数组}.`
+	instructions := fmt.Sprintf("\n请严格输出符合以下 JSON Schema 的 JSON 对象，不要包含任何解释性文字：\n%s", p.contract.Schema())
 	return prompt + instructions
 }
 
@@ -116,6 +116,46 @@ func TestProcessorAcceptsLLMResponse(t *testing.T) {
 	}
 }
 
+func TestProcessorRepairsMalformedLLMResponse(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Keywords: []string{"remote"}}
+	llm := &stubLLM{responses: []string{"not json", `{"is_remote":true,"summary":"fixed"}`}}
+	p := New(cfg, llm)
+
+	raw := model.RawJob{Source: "eleduck", ExternalID: "ext-2", Title: "Remote Go Dev"}
+	res, err := p.Process(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	if res.Outcome != ResultAccepted {
+		t.Fatalf("expected acceptance after repair, got %v", res.Outcome)
+	}
+	if llm.calls != 2 {
+		t.Fatalf("expected one repair call in addition to the original, got %d calls", llm.calls)
+	}
+	if res.Trace["repair_attempts"] != 1 {
+		t.Fatalf("expected repair_attempts recorded in trace, got %#v", res.Trace["repair_attempts"])
+	}
+}
+
+func TestProcessorFailsAfterExhaustingRepairAttempts(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Keywords: []string{"remote"}}
+	llm := &stubLLM{responses: []string{"not json", "still not json", "nope"}}
+	p := New(cfg, llm)
+
+	raw := model.RawJob{Source: "eleduck", ExternalID: "ext-3", Title: "Remote Go Dev"}
+	_, err := p.Process(context.Background(), raw)
+	if err == nil {
+		t.Fatalf("expected error once repair attempts are exhausted")
+	}
+	if llm.calls != 1+maxRepairAttempts {
+		t.Fatalf("expected %d calls (1 original + %d repairs), got %d", 1+maxRepairAttempts, maxRepairAttempts, llm.calls)
+	}
+}
+
 func containsAll(haystack string, needles []string) bool {
 	for _, n := range needles {
 		if !strings.Contains(haystack, n) {
@@ -127,6 +167,7 @@ func containsAll(haystack string, needles []string) bool {
 
 type stubLLM struct {
 	response   string
+	responses  []string
 	err        error
 	calls      int
 	lastPrompt string
@@ -138,5 +179,10 @@ func (s *stubLLM) Complete(ctx context.Context, prompt string) (string, error) {
 	if s.err != nil {
 		return "", s.err
 	}
+	if len(s.responses) > 0 {
+		resp := s.responses[0]
+		s.responses = s.responses[1:]
+		return resp, nil
+	}
 	return s.response, nil
 }
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LLMUsage 记录一次 LLM 调用消耗的 token 数量，Provider 未提供用量信息时字段为零值。
+type LLMUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// UsageClient 由同时支持返回 token 用量的 LLMClient 实现，processor.CachingClient 据此记录
+// llm_usage 并据此估算花费；未实现该接口的 LLMClient（如测试用的 stub）只会按零用量计费。
+type UsageClient interface {
+	LLMClient
+	CompleteWithUsage(ctx context.Context, prompt string) (string, LLMUsage, error)
+}
+
+// LLMConfig 通过 Provider 字段决定实际使用哪个大模型供应商，Provider 为空时使用 deepseek，
+// 未注册的名称在 ProviderRegistry.Build 中返回错误。
+// Fallback 列出 Provider 调用失败（429/5xx/超时）时依次尝试的备用供应商名称，按顺序包装为
+// ChainedClient；每个供应商还会各自包一层 CircuitBreaker，连续失败 CircuitBreakerThreshold 次后
+// 在 CircuitBreakerCooldown 内跳过该供应商，直接尝试下一个。
+type LLMConfig struct {
+	Provider                string             `yaml:"provider" json:"provider"`
+	Fallback                []string           `yaml:"fallback" json:"fallback"`
+	Deepseek                DeepseekConfig     `yaml:"deepseek" json:"deepseek"`
+	OpenAI                  OpenAIConfig       `yaml:"openai" json:"openai"`
+	Anthropic               AnthropicConfig    `yaml:"anthropic" json:"anthropic"`
+	Ollama                  OllamaConfig       `yaml:"ollama" json:"ollama"`
+	Gemini                  GeminiConfig       `yaml:"gemini" json:"gemini"`
+	Cache                   bool               `yaml:"cache" json:"cache"`
+	SpendLimit              SpendLimiterConfig `yaml:"spend_limit" json:"spend_limit"`
+	Prices                  PriceTable         `yaml:"prices" json:"prices"`
+	CircuitBreakerThreshold int                `yaml:"circuit_breaker_threshold" json:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  time.Duration      `yaml:"circuit_breaker_cooldown" json:"circuit_breaker_cooldown"`
+}
+
+// ProviderFactory 依据 LLMConfig 与共享 http.Client 构建一个 LLMClient。
+type ProviderFactory func(cfg LLMConfig, client *http.Client) LLMClient
+
+// ProviderRegistry 按名称管理 LLMClient 构造函数，用于按配置选择大模型供应商。
+type ProviderRegistry struct {
+	factories map[string]ProviderFactory
+}
+
+// NewProviderRegistry 创建已注册内置供应商（deepseek/openai/anthropic/ollama/gemini）的 ProviderRegistry。
+func NewProviderRegistry() *ProviderRegistry {
+	r := &ProviderRegistry{factories: make(map[string]ProviderFactory)}
+	r.Register("deepseek", func(cfg LLMConfig, client *http.Client) LLMClient {
+		return NewDeepseekClient(cfg.Deepseek, client)
+	})
+	r.Register("openai", func(cfg LLMConfig, client *http.Client) LLMClient {
+		return NewOpenAIClient(cfg.OpenAI, client)
+	})
+	r.Register("anthropic", func(cfg LLMConfig, client *http.Client) LLMClient {
+		return NewAnthropicClient(cfg.Anthropic, client)
+	})
+	r.Register("ollama", func(cfg LLMConfig, client *http.Client) LLMClient {
+		return NewOllamaClient(cfg.Ollama, client)
+	})
+	r.Register("gemini", func(cfg LLMConfig, client *http.Client) LLMClient {
+		return NewGeminiClient(cfg.Gemini, client)
+	})
+	return r
+}
+
+// Register 注册一个供应商，重复注册同一名称会覆盖之前的构造函数。
+func (r *ProviderRegistry) Register(name string, factory ProviderFactory) {
+	r.factories[strings.ToLower(strings.TrimSpace(name))] = factory
+}
+
+// ResolvedModel 返回 cfg.Provider 对应子配置中的 model 名称，供 CachingClient 记录用量/查价使用。
+func (cfg LLMConfig) ResolvedModel() string {
+	return cfg.ResolvedModelFor(cfg.Provider)
+}
+
+// ResolvedModelFor 返回指定供应商名称对应子配置中的 model 名称，未识别的名称返回 deepseek 的 model。
+// 目前只由 ResolvedModel 调用；ChainedClient 不按各自供应商查价或记录用量。
+func (cfg LLMConfig) ResolvedModelFor(provider string) string {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "openai":
+		return cfg.OpenAI.Model
+	case "anthropic":
+		return cfg.Anthropic.Model
+	case "ollama":
+		return cfg.Ollama.Model
+	case "gemini":
+		return cfg.Gemini.Model
+	default:
+		return cfg.Deepseek.Model
+	}
+}
+
+// Build 依据 cfg.Provider 构建对应的 LLMClient，Provider 为空时回退到 deepseek，未注册的名称返回错误。
+func (r *ProviderRegistry) Build(cfg LLMConfig, client *http.Client) (LLMClient, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.Provider))
+	if name == "" {
+		name = "deepseek"
+	}
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown llm provider %q", cfg.Provider)
+	}
+	return factory(cfg, client), nil
+}
@@ -0,0 +1,38 @@
+package queue
+
+import "context"
+
+const defaultMemoryQueueBuffer = 256
+
+// MemoryQueue 是 JobQueue 的进程内实现，供单实例部署与测试使用，重启后队列内容丢失。
+type MemoryQueue struct {
+	ch chan RawJobRef
+}
+
+// NewMemoryQueue 创建 MemoryQueue，buffer 为 0 时使用默认容量。
+func NewMemoryQueue(buffer int) *MemoryQueue {
+	if buffer <= 0 {
+		buffer = defaultMemoryQueueBuffer
+	}
+	return &MemoryQueue{ch: make(chan RawJobRef, buffer)}
+}
+
+// Enqueue 实现 JobQueue，队列满时阻塞直到有空位或 ctx 取消。
+func (q *MemoryQueue) Enqueue(ctx context.Context, ref RawJobRef) error {
+	select {
+	case q.ch <- ref:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dequeue 实现 JobQueue，内存实现无需租约回收，AckFunc 始终返回 nil。
+func (q *MemoryQueue) Dequeue(ctx context.Context) (RawJobRef, AckFunc, error) {
+	select {
+	case ref := <-q.ch:
+		return ref, func(context.Context) error { return nil }, nil
+	case <-ctx.Done():
+		return RawJobRef{}, nil, ctx.Err()
+	}
+}
@@ -0,0 +1,57 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryQueueEnqueueDequeue(t *testing.T) {
+	t.Parallel()
+
+	q := NewMemoryQueue(0)
+	ctx := context.Background()
+
+	if err := q.Enqueue(ctx, RawJobRef{ID: 1}); err != nil {
+		t.Fatalf("Enqueue error: %v", err)
+	}
+
+	ref, ack, err := q.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("Dequeue error: %v", err)
+	}
+	if ref.ID != 1 {
+		t.Fatalf("expected ref.ID=1, got %d", ref.ID)
+	}
+	if err := ack(ctx); err != nil {
+		t.Fatalf("ack error: %v", err)
+	}
+}
+
+func TestMemoryQueueDequeueBlocksUntilCancel(t *testing.T) {
+	t.Parallel()
+
+	q := NewMemoryQueue(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := q.Dequeue(ctx); err != ctx.Err() {
+		t.Fatalf("expected context deadline error, got %v", err)
+	}
+}
+
+func TestMemoryQueueEnqueueBlocksWhenFull(t *testing.T) {
+	t.Parallel()
+
+	q := NewMemoryQueue(1)
+	ctx := context.Background()
+	if err := q.Enqueue(ctx, RawJobRef{ID: 1}); err != nil {
+		t.Fatalf("Enqueue error: %v", err)
+	}
+
+	fullCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.Enqueue(fullCtx, RawJobRef{ID: 2}); err != fullCtx.Err() {
+		t.Fatalf("expected context deadline error on full queue, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// Package queue 提供原始职位处理阶段的可插拔队列：内存实现供测试与单机部署使用，
+// Redis 实现基于可靠队列模式（BRPOPLPUSH + 租约过期回收），支持多进程水平扩展处理。
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+// RawJobRef 是队列中流转的最小引用，真正的 RawJob 数据仍由 Store 持有。
+type RawJobRef struct {
+	ID uint
+}
+
+// AckFunc 在一次 Dequeue 对应的处理完成后调用，确认该条目可以从处理中状态移除。
+// 不调用 AckFunc 的条目会在租约到期后被回收并重新进入待处理队列。
+type AckFunc func(ctx context.Context) error
+
+// JobQueue 抽象原始职位的入队与取出，便于在内存实现与 Redis 实现之间切换。
+type JobQueue interface {
+	// Enqueue 将一个待处理的 RawJob 引用放入队列。
+	Enqueue(ctx context.Context, ref RawJobRef) error
+	// Dequeue 取出一个待处理的引用，返回的 AckFunc 需在处理成功后调用。
+	// RedisQueue 在阻塞 BlockTimeout 仍无可处理项时返回 ErrEmpty；MemoryQueue 则一直阻塞到
+	// 取到条目或 ctx 取消，不返回 ErrEmpty。
+	Dequeue(ctx context.Context) (RawJobRef, AckFunc, error)
+}
+
+// ErrEmpty 表示本次 Dequeue 轮询未取到任何待处理项，调用方应按 poll_interval 重试。
+var ErrEmpty = errors.New("queue: no pending job")
@@ -0,0 +1,146 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	pendingKey   = "rawjobs:pending"
+	deadlineKey  = "rawjobs:processing:deadlines"
+	defaultLease = time.Minute
+	defaultBlock = 5 * time.Second
+)
+
+// RedisConfig 配置 Redis 可靠队列连接与租约参数。
+type RedisConfig struct {
+	Addr         string        `yaml:"addr" json:"addr"`
+	Password     string        `yaml:"password" json:"password"`
+	DB           int           `yaml:"db" json:"db"`
+	ConsumerID   string        `yaml:"consumer_id" json:"consumer_id"`
+	Lease        time.Duration `yaml:"lease" json:"lease"`
+	BlockTimeout time.Duration `yaml:"block_timeout" json:"block_timeout"`
+}
+
+// RedisQueue 用 BRPOPLPUSH 将 pending 列表的条目原子地转入每个消费者自己的 processing 列表，
+// 并用一个有序集合记录每条处理中条目的租约到期时间，供 Reap 定期回收卡死的条目。
+type RedisQueue struct {
+	client       *redis.Client
+	consumerID   string
+	lease        time.Duration
+	blockTimeout time.Duration
+}
+
+// NewRedisQueue 创建 RedisQueue，未设置的字段使用合理默认值。
+func NewRedisQueue(cfg RedisConfig) *RedisQueue {
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultLease
+	}
+	if cfg.BlockTimeout <= 0 {
+		cfg.BlockTimeout = defaultBlock
+	}
+	if cfg.ConsumerID == "" {
+		cfg.ConsumerID = "default"
+	}
+	return &RedisQueue{
+		client: redis.NewClient(&redis.Options{
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}),
+		consumerID:   cfg.ConsumerID,
+		lease:        cfg.Lease,
+		blockTimeout: cfg.BlockTimeout,
+	}
+}
+
+// Enqueue 实现 JobQueue，将 RawJob ID 推入 pending 列表。
+func (q *RedisQueue) Enqueue(ctx context.Context, ref RawJobRef) error {
+	if err := q.client.LPush(ctx, pendingKey, ref.ID).Err(); err != nil {
+		return fmt.Errorf("enqueue raw job: %w", err)
+	}
+	return nil
+}
+
+// Dequeue 实现 JobQueue：BRPOPLPUSH 将条目原子地转入本消费者的 processing 列表并记录租约，
+// 阻塞超过 BlockTimeout 仍无数据时返回 ErrEmpty。
+func (q *RedisQueue) Dequeue(ctx context.Context) (RawJobRef, AckFunc, error) {
+	raw, err := q.client.BRPopLPush(ctx, pendingKey, q.processingKey(), q.blockTimeout).Result()
+	if err == redis.Nil {
+		return RawJobRef{}, nil, ErrEmpty
+	}
+	if err != nil {
+		return RawJobRef{}, nil, fmt.Errorf("dequeue raw job: %w", err)
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return RawJobRef{}, nil, fmt.Errorf("parse raw job id %q: %w", raw, err)
+	}
+
+	member := q.deadlineMember(raw)
+	deadline := time.Now().Add(q.lease)
+	if err := q.client.ZAdd(ctx, deadlineKey, redis.Z{Score: float64(deadline.Unix()), Member: member}).Err(); err != nil {
+		return RawJobRef{}, nil, fmt.Errorf("record lease deadline: %w", err)
+	}
+
+	ack := func(ackCtx context.Context) error {
+		if err := q.client.LRem(ackCtx, q.processingKey(), 1, raw).Err(); err != nil {
+			return fmt.Errorf("ack remove from processing: %w", err)
+		}
+		if err := q.client.ZRem(ackCtx, deadlineKey, member).Err(); err != nil {
+			return fmt.Errorf("ack remove deadline: %w", err)
+		}
+		return nil
+	}
+	return RawJobRef{ID: uint(id)}, ack, nil
+}
+
+// Reap 将租约已过期的 processing 条目重新放回 pending 队列，用于消费者崩溃或处理超时后防止任务丢失。
+func (q *RedisQueue) Reap(ctx context.Context) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	expired, err := q.client.ZRangeByScore(ctx, deadlineKey, &redis.ZRangeBy{Min: "-inf", Max: now}).Result()
+	if err != nil {
+		return fmt.Errorf("list expired leases: %w", err)
+	}
+
+	for _, member := range expired {
+		consumer, raw, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
+		}
+		processingKey := processingKeyFor(consumer)
+		if err := q.client.LRem(ctx, processingKey, 1, raw).Err(); err != nil {
+			return fmt.Errorf("reap remove from processing: %w", err)
+		}
+		if err := q.client.LPush(ctx, pendingKey, raw).Err(); err != nil {
+			return fmt.Errorf("reap requeue pending: %w", err)
+		}
+		if err := q.client.ZRem(ctx, deadlineKey, member).Err(); err != nil {
+			return fmt.Errorf("reap remove deadline: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close 关闭底层 Redis 连接。
+func (q *RedisQueue) Close() error {
+	return q.client.Close()
+}
+
+func (q *RedisQueue) processingKey() string {
+	return processingKeyFor(q.consumerID)
+}
+
+func (q *RedisQueue) deadlineMember(raw string) string {
+	return q.consumerID + ":" + raw
+}
+
+func processingKeyFor(consumer string) string {
+	return "rawjobs:processing:" + consumer
+}
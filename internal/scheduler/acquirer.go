@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"remote-radar/internal/model"
+	"remote-radar/internal/processor"
+	"remote-radar/internal/storage"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AcquirerStore 抽象 Acquirer 所需的租约存储操作。
+type AcquirerStore interface {
+	AcquireRawJobBatch(ctx context.Context, workerID string, n int, leaseTTL time.Duration) ([]model.RawJob, error)
+	HeartbeatRawJobs(ctx context.Context, workerID string, ids []uint, leaseTTL time.Duration) error
+	AckRawJobLease(ctx context.Context, id uint) error
+	NackRawJobLease(ctx context.Context, id uint, backoff time.Duration, maxAttempts int) (bool, error)
+	RequeueExpiredLeases(ctx context.Context, now time.Time) (int, error)
+}
+
+// AcquirerConfig 控制基于数据库租约的分布式认领参数。LeaseTTL 内未 Heartbeat/Ack/Nack 视为 worker
+// 失联，租约到期后其他 worker 可重新认领；NackBackoff 为失败后重新可认领前的等待时长；MaxAttempts
+// 为转入死信前允许的最大失败次数；ReaperInterval 控制 reaper 清理过期租约的频率。
+type AcquirerConfig struct {
+	Workers        int           `yaml:"workers" json:"workers"`
+	BatchSize      int           `yaml:"batch_size" json:"batch_size"`
+	PollInterval   time.Duration `yaml:"poll_interval" json:"poll_interval"`
+	LeaseTTL       time.Duration `yaml:"lease_ttl" json:"lease_ttl"`
+	NackBackoff    time.Duration `yaml:"nack_backoff" json:"nack_backoff"`
+	MaxAttempts    int           `yaml:"max_attempts" json:"max_attempts"`
+	ReaperInterval time.Duration `yaml:"reaper_interval" json:"reaper_interval"`
+}
+
+const (
+	defaultAcquirerWorkers      = 1
+	defaultAcquirerBatchSize    = 10
+	defaultAcquirerPollInterval = time.Second
+	defaultLeaseTTL             = 30 * time.Second
+	defaultNackBackoff          = 10 * time.Second
+	defaultAcquirerMaxAttempts  = 5
+	defaultReaperInterval       = 15 * time.Second
+)
+
+func (c AcquirerConfig) withDefaults() AcquirerConfig {
+	if c.Workers <= 0 {
+		c.Workers = defaultAcquirerWorkers
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultAcquirerBatchSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultAcquirerPollInterval
+	}
+	if c.LeaseTTL <= 0 {
+		c.LeaseTTL = defaultLeaseTTL
+	}
+	if c.NackBackoff <= 0 {
+		c.NackBackoff = defaultNackBackoff
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultAcquirerMaxAttempts
+	}
+	if c.ReaperInterval <= 0 {
+		c.ReaperInterval = defaultReaperInterval
+	}
+	return c
+}
+
+// Acquirer 基于数据库租约实现分布式 RawJob 认领：AcquireBatch 原子地认领一批待处理记录并打上
+// workerID 的租约，处理期间通过 Heartbeat 续约，结束时调用 Ack（成功）或 Nack（失败，按退避重试，
+// 超过 MaxAttempts 转入死信状态）。多个 remote-radar 实例共享同一 Store 即可横向扩展而不会重复抓取/处理，
+// 不依赖 Redis，是 WorkerPool（基于 queue.JobQueue）之外的另一种分布式处理方式。
+type Acquirer struct {
+	store    AcquirerStore
+	workerID string
+	cfg      AcquirerConfig
+}
+
+// NewAcquirer 创建 Acquirer，workerID 用于标识租约持有者（建议使用主机名+PID 等跨实例唯一的值）。
+func NewAcquirer(store AcquirerStore, workerID string, cfg AcquirerConfig) *Acquirer {
+	return &Acquirer{store: store, workerID: workerID, cfg: cfg.withDefaults()}
+}
+
+// AcquireBatch 认领最多 cfg.BatchSize 条可处理的 RawJob。
+func (a *Acquirer) AcquireBatch(ctx context.Context) ([]model.RawJob, error) {
+	return a.store.AcquireRawJobBatch(ctx, a.workerID, a.cfg.BatchSize, a.cfg.LeaseTTL)
+}
+
+// Heartbeat 续期指定 RawJob 的租约，处理耗时较长时应周期性调用以防租约过期被其他 worker 抢占。
+func (a *Acquirer) Heartbeat(ctx context.Context, ids []uint) error {
+	return a.store.HeartbeatRawJobs(ctx, a.workerID, ids, a.cfg.LeaseTTL)
+}
+
+// Ack 释放指定 RawJob 的租约，调用前应已通过 UpdateRawJobStatus 写入终态。
+func (a *Acquirer) Ack(ctx context.Context, id uint) error {
+	return a.store.AckRawJobLease(ctx, id)
+}
+
+// Nack 记录一次处理失败：未达 MaxAttempts 时按 NackBackoff 延后租约到期时间以便重试，
+// 否则转入死信状态，返回值表示是否已转入死信。
+func (a *Acquirer) Nack(ctx context.Context, id uint) (bool, error) {
+	return a.store.NackRawJobLease(ctx, id, a.cfg.NackBackoff, a.cfg.MaxAttempts)
+}
+
+// RunReaper 按 ReaperInterval 周期性请求清空过期租约，使其重新可被认领，直到 ctx 取消。
+func (a *Acquirer) RunReaper(ctx context.Context) error {
+	ticker := time.NewTicker(a.cfg.ReaperInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := a.store.RequeueExpiredLeases(ctx, time.Now()); err != nil {
+				return fmt.Errorf("requeue expired leases: %w", err)
+			}
+		}
+	}
+}
+
+// AcquirerPool 启动多个 worker 轮询 Acquirer 认领 RawJob 并处理，处理结果经 CoalescingWriter 批量
+// 合并写入。与 WorkerPool 的区别在于认领状态直接存在 RawJob 表的租约字段里，不依赖 Redis。
+type AcquirerPool struct {
+	acquirer  *Acquirer
+	store     WorkerPoolStore
+	processor processor.JobProcessor
+	writer    *CoalescingWriter
+	workers   int
+	poll      time.Duration
+}
+
+// NewAcquirerPool 创建 AcquirerPool，未设置的字段使用 cfg.withDefaults() 的合理默认值。
+func NewAcquirerPool(acquirer *Acquirer, s WorkerPoolStore, proc processor.JobProcessor, notif Notifier, cfg AcquirerConfig) *AcquirerPool {
+	cfg = cfg.withDefaults()
+	return &AcquirerPool{
+		acquirer:  acquirer,
+		store:     s,
+		processor: proc,
+		writer:    NewCoalescingWriter(s, notif, cfg.BatchSize, defaultFlushInterval),
+		workers:   cfg.Workers,
+		poll:      cfg.PollInterval,
+	}
+}
+
+// Start 启动 cfg.Workers 个并发 worker 及 Acquirer 的 reaper goroutine，直到上下文取消。
+func (p *AcquirerPool) Start(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error { return p.writer.Run(ctx) })
+	g.Go(func() error { return p.acquirer.RunReaper(ctx) })
+
+	for i := 0; i < p.workers; i++ {
+		g.Go(func() error {
+			return p.runWorker(ctx)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (p *AcquirerPool) runWorker(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batch, err := p.acquirer.AcquireBatch(ctx)
+		if err != nil {
+			return fmt.Errorf("acquire raw job batch: %w", err)
+		}
+		if len(batch) == 0 {
+			timer := time.NewTimer(p.poll)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+
+		for _, raw := range batch {
+			if err := p.handle(ctx, raw); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle 处理一条已认领的 RawJob：处理失败时 Nack（按配置重试或转入死信），成功时写回终态并 Ack，
+// 与 WorkerPool.handle 不同的是处理失败不会终止整个 worker，而是转交给租约的重试/死信机制。
+func (p *AcquirerPool) handle(ctx context.Context, raw model.RawJob) error {
+	res, err := p.processor.Process(ctx, raw)
+	if err != nil {
+		if _, nackErr := p.acquirer.Nack(ctx, raw.ID); nackErr != nil {
+			return fmt.Errorf("nack raw job %d after process error: %w", raw.ID, nackErr)
+		}
+		return nil
+	}
+
+	update := storage.RawJobStatusUpdate{Status: model.RawJobStatusRejected, Reason: res.Reason, Details: res.Trace}
+	if res.Outcome == processor.ResultAccepted && res.Job != nil {
+		update.Status = model.RawJobStatusProcessed
+		update.Reason = ""
+	}
+	if err := p.store.UpdateRawJobStatus(ctx, raw.ID, update); err != nil {
+		return fmt.Errorf("update raw job status %d: %w", raw.ID, err)
+	}
+	if err := p.acquirer.Ack(ctx, raw.ID); err != nil {
+		return fmt.Errorf("ack raw job %d: %w", raw.ID, err)
+	}
+
+	if res.Outcome == processor.ResultAccepted && res.Job != nil {
+		p.writer.Add(*res.Job)
+	}
+	return nil
+}
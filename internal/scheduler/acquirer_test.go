@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+	"remote-radar/internal/processor"
+	"remote-radar/internal/storage"
+)
+
+type stubAcquirerStore struct {
+	mu            sync.Mutex
+	batch         []model.RawJob
+	heartbeats    []uint
+	acked         []uint
+	nackDeadLtr   bool
+	nackCalls     int
+	requeueCalls  int
+	requeuedCount int
+}
+
+func (s *stubAcquirerStore) AcquireRawJobBatch(ctx context.Context, workerID string, n int, leaseTTL time.Duration) ([]model.RawJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	batch := s.batch
+	s.batch = nil
+	return batch, nil
+}
+
+func (s *stubAcquirerStore) HeartbeatRawJobs(ctx context.Context, workerID string, ids []uint, leaseTTL time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heartbeats = append(s.heartbeats, ids...)
+	return nil
+}
+
+func (s *stubAcquirerStore) AckRawJobLease(ctx context.Context, id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acked = append(s.acked, id)
+	return nil
+}
+
+func (s *stubAcquirerStore) NackRawJobLease(ctx context.Context, id uint, backoff time.Duration, maxAttempts int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nackCalls++
+	return s.nackDeadLtr, nil
+}
+
+func (s *stubAcquirerStore) RequeueExpiredLeases(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requeueCalls++
+	return s.requeuedCount, nil
+}
+
+func TestAcquirerAcquireHeartbeatAck(t *testing.T) {
+	t.Parallel()
+
+	store := &stubAcquirerStore{batch: []model.RawJob{{ID: 1}, {ID: 2}}}
+	a := NewAcquirer(store, "worker-a", AcquirerConfig{})
+
+	batch, err := a.AcquireBatch(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireBatch error: %v", err)
+	}
+	if len(batch) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(batch))
+	}
+
+	if err := a.Heartbeat(context.Background(), []uint{1, 2}); err != nil {
+		t.Fatalf("Heartbeat error: %v", err)
+	}
+	if len(store.heartbeats) != 2 {
+		t.Fatalf("expected 2 heartbeats recorded, got %d", len(store.heartbeats))
+	}
+
+	if err := a.Ack(context.Background(), 1); err != nil {
+		t.Fatalf("Ack error: %v", err)
+	}
+	if len(store.acked) != 1 || store.acked[0] != 1 {
+		t.Fatalf("expected id 1 acked, got %+v", store.acked)
+	}
+}
+
+func TestAcquirerNackReturnsDeadLetterFlag(t *testing.T) {
+	t.Parallel()
+
+	store := &stubAcquirerStore{nackDeadLtr: true}
+	a := NewAcquirer(store, "worker-a", AcquirerConfig{MaxAttempts: 1})
+
+	deadLettered, err := a.Nack(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Nack error: %v", err)
+	}
+	if !deadLettered {
+		t.Fatal("expected deadLettered to be true")
+	}
+	if store.nackCalls != 1 {
+		t.Fatalf("expected 1 nack call, got %d", store.nackCalls)
+	}
+}
+
+func TestAcquirerRunReaperStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	store := &stubAcquirerStore{}
+	a := NewAcquirer(store, "worker-a", AcquirerConfig{ReaperInterval: 5 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.RunReaper(ctx) }()
+
+	deadline := time.After(time.Second)
+	for {
+		store.mu.Lock()
+		calls := store.requeueCalls
+		store.mu.Unlock()
+		if calls > 0 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for reaper to run")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; err == nil {
+		t.Fatal("expected RunReaper to return context.Canceled")
+	}
+}
+
+func TestAcquirerPoolProcessesAndFlushesBatch(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{
+		byID:      map[uint]model.RawJob{1: {ID: 1, ExternalID: "raw1", Source: "eleduck", Title: "Job1"}},
+		jobResult: storage.UpsertResult{Created: 1, NewJobs: []model.Job{{ID: "raw1"}}},
+	}
+	proc := &stubProcessor{
+		results: map[string]processor.Result{
+			"raw1": {Outcome: processor.ResultAccepted, Job: &model.Job{ID: "raw1", Title: "Job1"}},
+		},
+	}
+	n := &stubNotifier{}
+	acquirerStore := &stubAcquirerStore{batch: []model.RawJob{{ID: 1, ExternalID: "raw1", Source: "eleduck", Title: "Job1"}}}
+	acquirer := NewAcquirer(acquirerStore, "worker-a", AcquirerConfig{})
+
+	pool := NewAcquirerPool(acquirer, store, proc, n, AcquirerConfig{Workers: 1, PollInterval: 5 * time.Millisecond, BatchSize: 1, ReaperInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pool.Start(ctx)
+	}()
+
+	deadline := time.After(time.Second)
+	for n.calls.Load() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for notifier to fire")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	if len(store.statusUpdates) != 1 {
+		t.Fatalf("expected one status update, got %d", len(store.statusUpdates))
+	}
+	if store.statusUpdates[0].update.Status != model.RawJobStatusProcessed {
+		t.Fatalf("expected raw job marked processed, got %v", store.statusUpdates[0].update.Status)
+	}
+	if len(acquirerStore.acked) != 1 {
+		t.Fatalf("expected raw job acked once, got %d", len(acquirerStore.acked))
+	}
+}
+
+func TestAcquirerPoolNacksOnProcessError(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{byID: map[uint]model.RawJob{1: {ID: 1, ExternalID: "raw1"}}}
+	proc := &stubErrorProcessor{}
+	acquirerStore := &stubAcquirerStore{batch: []model.RawJob{{ID: 1, ExternalID: "raw1"}}}
+	acquirer := NewAcquirer(acquirerStore, "worker-a", AcquirerConfig{})
+
+	pool := NewAcquirerPool(acquirer, store, proc, nil, AcquirerConfig{Workers: 1, PollInterval: 5 * time.Millisecond, ReaperInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pool.Start(ctx)
+	}()
+
+	deadline := time.After(time.Second)
+	for {
+		acquirerStore.mu.Lock()
+		nackCalls := acquirerStore.nackCalls
+		acquirerStore.mu.Unlock()
+		if nackCalls > 0 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for nack to fire")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	if len(store.statusUpdates) != 0 {
+		t.Fatalf("expected no status update written on process error, got %d", len(store.statusUpdates))
+	}
+}
+
+type stubErrorProcessor struct{}
+
+func (s *stubErrorProcessor) Process(ctx context.Context, raw model.RawJob) (processor.Result, error) {
+	return processor.Result{}, context.DeadlineExceeded
+}
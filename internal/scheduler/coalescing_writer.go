@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// CoalescingWriter 将多个 worker 并发产出的 model.Job 合并为批次，
+// 按数量或时间阈值（先到者）触发一次 UpsertJobs 写入，再对新增职位调用 Notifier.Notify，
+// 避免多进程下每条 RawJob 处理完成都单独写库与通知。
+type CoalescingWriter struct {
+	store         WorkerPoolStore
+	notif         Notifier
+	batchSize     int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending []model.Job
+	flushCh chan struct{}
+}
+
+// NewCoalescingWriter 创建 CoalescingWriter。
+func NewCoalescingWriter(store WorkerPoolStore, notif Notifier, batchSize int, flushInterval time.Duration) *CoalescingWriter {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	return &CoalescingWriter{
+		store:         store,
+		notif:         notif,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		flushCh:       make(chan struct{}, 1),
+	}
+}
+
+// Add 将一个已处理完成的 Job 加入待写入批次，批次达到 batchSize 时触发一次立即刷新。
+func (w *CoalescingWriter) Add(job model.Job) {
+	w.mu.Lock()
+	w.pending = append(w.pending, job)
+	full := len(w.pending) >= w.batchSize
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run 按 flushInterval 周期或 batchSize 触发刷新批次，直到上下文取消（取消前会做最后一次刷新）。
+func (w *CoalescingWriter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = w.flush(context.Background())
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.flush(ctx); err != nil {
+				return err
+			}
+		case <-w.flushCh:
+			if err := w.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *CoalescingWriter) flush(ctx context.Context) error {
+	w.mu.Lock()
+	batch := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	res, err := w.store.UpsertJobs(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("flush jobs batch: %w", err)
+	}
+
+	if w.notif != nil && len(res.NewJobs) > 0 {
+		if err := w.notif.Notify(ctx, res.NewJobs); err != nil {
+			return fmt.Errorf("notify: %w", err)
+		}
+	}
+	return nil
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"remote-radar/internal/cluster"
 	"remote-radar/internal/fetcher"
 	"remote-radar/internal/model"
 	"remote-radar/internal/processor"
@@ -17,10 +19,12 @@ import (
 )
 
 // Config 用于调度配置。
+// ArchiveAfter 控制职位在源站消失多久后被标记 archived，留空或非法值时默认 72 小时（约 3 个抓取周期）。
 type Config struct {
 	Interval           string `yaml:"interval" json:"interval"`
 	Timeout            string `yaml:"timeout" json:"timeout"`
 	ProcessorBatchSize int    `yaml:"processor_batch_size" json:"processor_batch_size"`
+	ArchiveAfter       string `yaml:"archive_after" json:"archive_after"`
 }
 
 // Store 抽象存储接口，便于测试替换。
@@ -29,6 +33,8 @@ type Store interface {
 	UpsertRawJobs(ctx context.Context, jobs []model.RawJob) (storage.RawUpsertResult, error)
 	ListRawJobs(ctx context.Context, query storage.RawJobQuery) ([]model.RawJob, error)
 	UpdateRawJobStatus(ctx context.Context, id uint, update storage.RawJobStatusUpdate) error
+	TouchJobsSeen(ctx context.Context, ids []string, seenAt time.Time) error
+	ArchiveStaleJobs(ctx context.Context, before time.Time) (int64, error)
 }
 
 // Notifier 用于发送新增职位通知。
@@ -36,20 +42,78 @@ type Notifier interface {
 	Notify(ctx context.Context, jobs []model.Job) error
 }
 
+// ScheduleRunStore 持久化每次 runOnce 执行的历史记录，供 /api/jobs/runs 查询与排障。
+type ScheduleRunStore interface {
+	CreateScheduleRun(ctx context.Context, run *model.ScheduleRun) error
+	UpdateScheduleRun(ctx context.Context, id uint, update storage.ScheduleRunUpdate) error
+	RecordScheduleRunOutcome(ctx context.Context, outcome model.ScheduleRunOutcome) error
+}
+
+// RunHook 在每次由 ticker/cron 触发的 runOnce 执行（无论成功或失败）后调用，用于记录任务运行状态，
+// 例如 JobRegistry 借此维护 /api/schedules 暴露的 JobStatus；经 RunOnce/Trigger 手动触发的执行不调用它。
+type RunHook func(created int, err error)
+
+// RunPhase 描述一次 runOnce 执行当前所处的阶段，供控制面定位卡在哪一步的批次。
+type RunPhase string
+
+const (
+	RunPhaseFetching   RunPhase = "fetching"
+	RunPhaseProcessing RunPhase = "processing"
+	RunPhaseNotifying  RunPhase = "notifying"
+)
+
+// ActiveRun 描述一次仍在执行的 runOnce 批次，供 /api/jobs/runs/active 暴露并支持按 ID 取消。
+type ActiveRun struct {
+	ID        uint
+	JobName   string
+	Phase     RunPhase
+	StartedAt time.Time
+}
+
+// activeRun 持有正在执行批次的取消点与阶段，Phase 由 runOnce 在推进阶段时更新。
+type activeRun struct {
+	cancel    context.CancelFunc
+	jobName   string
+	startedAt time.Time
+	mu        sync.Mutex
+	phase     RunPhase
+}
+
+func (r *activeRun) setPhase(phase RunPhase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phase = phase
+}
+
+func (r *activeRun) getPhase() RunPhase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.phase
+}
+
 // Scheduler 负责周期性抓取并写入存储。
 type Scheduler struct {
-	fetcher   fetcher.JobFetcher
-	store     Store
-	processor processor.JobProcessor
-	notif     Notifier
-	interval  time.Duration
-	cronSpec  string
-	cron      *cronSchedule
-	timeout   time.Duration
-	batchSize int
-	running   atomic.Bool
-	newTicker func(time.Duration) ticker
-	now       func() time.Time
+	fetcher      fetcher.JobFetcher
+	store        Store
+	processor    processor.JobProcessor
+	notif        Notifier
+	interval     time.Duration
+	cronSpec     string
+	cron         *cronSchedule
+	timeout      time.Duration
+	batchSize    int
+	archiveAfter time.Duration
+	newTicker    func(time.Duration) ticker
+	now          func() time.Time
+	leader       cluster.Leader
+	runHook      RunHook
+	name         string
+	runStore     ScheduleRunStore
+
+	runsMu  sync.Mutex
+	busy    bool
+	runs    map[uint]*activeRun
+	localID atomic.Uint64
 }
 
 type ticker interface {
@@ -70,22 +134,57 @@ func NewScheduler(f fetcher.JobFetcher, s Store, proc processor.JobProcessor, n
 	if batch <= 0 {
 		batch = 20
 	}
+	archiveAfter := 72 * time.Hour
+	if cfg.ArchiveAfter != "" {
+		if d, err := time.ParseDuration(cfg.ArchiveAfter); err == nil && d > 0 {
+			archiveAfter = d
+		}
+	}
 
 	return &Scheduler{
-		fetcher:   f,
-		store:     s,
-		processor: proc,
-		notif:     n,
-		interval:  interval,
-		cronSpec:  cronCfg.spec,
-		cron:      cronCfg.schedule,
-		timeout:   timeout,
-		batchSize: batch,
-		newTicker: defaultTicker,
-		now:       time.Now,
+		fetcher:      f,
+		store:        s,
+		processor:    proc,
+		notif:        n,
+		interval:     interval,
+		cronSpec:     cronCfg.spec,
+		cron:         cronCfg.schedule,
+		timeout:      timeout,
+		batchSize:    batch,
+		archiveAfter: archiveAfter,
+		newTicker:    defaultTicker,
+		now:          time.Now,
+		leader:    cluster.NewSingleLeader(""),
+		name:      defaultJobName,
+		runs:      make(map[uint]*activeRun),
 	}
 }
 
+// WithLeader 设置多实例部署下的 leader 选举实现，未设置时默认单机始终为 leader。
+// 仅 fetch/tick 侧受 leader 身份限制，队列 worker 在所有节点上都会运行。
+func (s *Scheduler) WithLeader(leader cluster.Leader) *Scheduler {
+	s.leader = leader
+	return s
+}
+
+// WithRunHook 设置每次由 ticker/cron 触发的 runOnce 执行后的回调，未设置时不记录任何状态。
+func (s *Scheduler) WithRunHook(hook RunHook) *Scheduler {
+	s.runHook = hook
+	return s
+}
+
+// WithName 设置该调度器对应的任务名称，写入 ScheduleRun.JobName，未设置时使用 "default"。
+func (s *Scheduler) WithName(name string) *Scheduler {
+	s.name = name
+	return s
+}
+
+// WithRunStore 设置调度运行历史的持久化实现，未设置时不记录 ScheduleRun。
+func (s *Scheduler) WithRunStore(store ScheduleRunStore) *Scheduler {
+	s.runStore = store
+	return s
+}
+
 // Start 启动调度循环，直到上下文取消。
 func (s *Scheduler) Start(ctx context.Context) error {
 	if s.fetcher == nil || s.store == nil || s.processor == nil {
@@ -94,6 +193,12 @@ func (s *Scheduler) Start(ctx context.Context) error {
 
 	g, ctx := errgroup.WithContext(ctx)
 
+	if r, ok := s.leader.(cluster.Runnable); ok {
+		g.Go(func() error {
+			return r.Run(ctx)
+		})
+	}
+
 	if s.cron != nil {
 		g.Go(func() error {
 			return s.startCron(ctx)
@@ -109,7 +214,14 @@ func (s *Scheduler) Start(ctx context.Context) error {
 				case <-ctx.Done():
 					return ctx.Err()
 				case <-ch:
-					if _, err := s.runOnce(ctx); err != nil {
+					if !s.leader.IsLeader() {
+						continue
+					}
+					_, created, err := s.runOnce(ctx, model.ScheduleRunTriggerCron)
+					if s.runHook != nil {
+						s.runHook(created, err)
+					}
+					if err != nil {
 						return err
 					}
 				drain:
@@ -129,23 +241,129 @@ func (s *Scheduler) Start(ctx context.Context) error {
 	return g.Wait()
 }
 
-// RunOnce 对外暴露单次抓取接口，便于手动刷新。
+// RunOnce 对外暴露单次抓取接口，便于手动刷新；返回创建数量，兼容既有调用方。
 func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
-	return s.runOnce(ctx)
+	_, created, err := s.runOnce(ctx, model.ScheduleRunTriggerManual)
+	return created, err
 }
 
-func (s *Scheduler) runOnce(ctx context.Context) (int, error) {
-	if s.running.Swap(true) {
-		return 0, nil
+// Trigger 与 RunOnce 等价，但额外返回本次运行的 ID，供调用方查询 /api/jobs/runs/{id} 或取消。
+func (s *Scheduler) Trigger(ctx context.Context) (uint, error) {
+	runID, _, err := s.runOnce(ctx, model.ScheduleRunTriggerAPI)
+	return runID, err
+}
+
+// LeaderStatus 返回当前 leader 选举状态，供 /api/cluster/leader 暴露。
+func (s *Scheduler) LeaderStatus(ctx context.Context) (cluster.Status, error) {
+	return s.leader.Status(ctx)
+}
+
+// ActiveRuns 返回当前仍在执行的批次快照，供 /api/jobs/runs/active 暴露。
+func (s *Scheduler) ActiveRuns() []ActiveRun {
+	s.runsMu.Lock()
+	defer s.runsMu.Unlock()
+
+	out := make([]ActiveRun, 0, len(s.runs))
+	for id, r := range s.runs {
+		out = append(out, ActiveRun{ID: id, JobName: r.jobName, Phase: r.getPhase(), StartedAt: r.startedAt})
 	}
-	defer s.running.Store(false)
+	return out
+}
+
+// Cancel 取消指定 ID 的在途批次，若该批次不属于本 Scheduler 则返回 false。
+func (s *Scheduler) Cancel(id uint) bool {
+	s.runsMu.Lock()
+	r, ok := s.runs[id]
+	s.runsMu.Unlock()
+	if !ok {
+		return false
+	}
+	r.cancel()
+	return true
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, triggeredBy model.ScheduleRunTrigger) (runID uint, created int, err error) {
+	s.runsMu.Lock()
+	if s.busy {
+		s.runsMu.Unlock()
+		return 0, 0, nil
+	}
+	s.busy = true
+	s.runsMu.Unlock()
+	defer func() {
+		s.runsMu.Lock()
+		s.busy = false
+		s.runsMu.Unlock()
+	}()
 
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
+	var run *model.ScheduleRun
+	var fetchedCount, acceptedCount, rejectedCount int
+	if s.runStore != nil {
+		run = &model.ScheduleRun{JobName: s.name, TriggeredBy: triggeredBy}
+		if cerr := s.runStore.CreateScheduleRun(ctx, run); cerr != nil {
+			return 0, 0, fmt.Errorf("create schedule run: %w", cerr)
+		}
+		runID = run.ID
+	} else {
+		runID = uint(s.localID.Add(1))
+	}
+
+	active := &activeRun{cancel: cancel, jobName: s.name, startedAt: time.Now()}
+	s.runsMu.Lock()
+	s.runs[runID] = active
+	s.runsMu.Unlock()
+	defer func() {
+		s.runsMu.Lock()
+		delete(s.runs, runID)
+		s.runsMu.Unlock()
+	}()
+
+	if run != nil {
+		defer func() {
+			status := model.ScheduleRunStatusSuccess
+			errMsg := ""
+			if r := recover(); r != nil {
+				status = model.ScheduleRunStatusFailed
+				errMsg = fmt.Sprintf("panic: %v", r)
+				err = fmt.Errorf("runOnce panic: %v", r)
+			} else if err != nil {
+				status = model.ScheduleRunStatusFailed
+				errMsg = err.Error()
+			} else if ctx.Err() != nil {
+				status = model.ScheduleRunStatusCanceled
+			}
+			if uerr := s.runStore.UpdateScheduleRun(context.Background(), run.ID, storage.ScheduleRunUpdate{
+				Status:        status,
+				FetchedCount:  fetchedCount,
+				AcceptedCount: acceptedCount,
+				RejectedCount: rejectedCount,
+				CreatedCount:  created,
+				Error:         errMsg,
+			}); uerr != nil && err == nil {
+				err = fmt.Errorf("update schedule run: %w", uerr)
+			}
+		}()
+	}
+
+	active.setPhase(RunPhaseFetching)
 	jobs, err := s.fetcher.Fetch(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("fetch jobs: %w", err)
+		return runID, 0, fmt.Errorf("fetch jobs: %w", err)
+	}
+	fetchedCount = len(jobs)
+
+	seenIDs := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		seenIDs = append(seenIDs, job.ID)
+	}
+	if err := s.store.TouchJobsSeen(ctx, seenIDs, time.Now()); err != nil {
+		return runID, 0, fmt.Errorf("touch jobs seen: %w", err)
+	}
+	if _, err := s.store.ArchiveStaleJobs(ctx, time.Now().Add(-s.archiveAfter)); err != nil {
+		return runID, 0, fmt.Errorf("archive stale jobs: %w", err)
 	}
 
 	rawJobs := make([]model.RawJob, 0, len(jobs))
@@ -162,19 +380,24 @@ func (s *Scheduler) runOnce(ctx context.Context) (int, error) {
 		})
 	}
 	if _, err := s.store.UpsertRawJobs(ctx, rawJobs); err != nil {
-		return 0, fmt.Errorf("upsert raw jobs: %w", err)
+		return runID, 0, fmt.Errorf("upsert raw jobs: %w", err)
 	}
 
 	pending, err := s.store.ListRawJobs(ctx, storage.RawJobQuery{Status: model.RawJobStatusPending, Limit: s.batchSize})
 	if err != nil {
-		return 0, fmt.Errorf("list raw jobs: %w", err)
+		return runID, 0, fmt.Errorf("list raw jobs: %w", err)
 	}
 
+	active.setPhase(RunPhaseProcessing)
 	processed := make([]model.Job, 0, len(pending))
 	for _, raw := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+
 		res, err := s.processor.Process(ctx, raw)
 		if err != nil {
-			return 0, fmt.Errorf("process raw job %d: %w", raw.ID, err)
+			return runID, 0, fmt.Errorf("process raw job %d: %w", raw.ID, err)
 		}
 
 		update := storage.RawJobStatusUpdate{Status: model.RawJobStatusRejected, Reason: res.Reason, Details: res.Trace}
@@ -182,28 +405,43 @@ func (s *Scheduler) runOnce(ctx context.Context) (int, error) {
 			processed = append(processed, *res.Job)
 			update.Status = model.RawJobStatusProcessed
 			update.Reason = ""
+			acceptedCount++
+		} else {
+			rejectedCount++
 		}
 		if err := s.store.UpdateRawJobStatus(ctx, raw.ID, update); err != nil {
-			return 0, fmt.Errorf("update raw job status: %w", err)
+			return runID, 0, fmt.Errorf("update raw job status: %w", err)
+		}
+		if run != nil {
+			if oerr := s.runStore.RecordScheduleRunOutcome(ctx, model.ScheduleRunOutcome{
+				ScheduleRunID: run.ID,
+				RawJobID:      raw.ID,
+				Status:        update.Status,
+				Reason:        update.Reason,
+			}); oerr != nil {
+				return runID, 0, fmt.Errorf("record schedule run outcome: %w", oerr)
+			}
 		}
 	}
 
 	if len(processed) == 0 {
-		return 0, nil
+		return runID, 0, nil
 	}
 
 	res, err := s.store.UpsertJobs(ctx, processed)
 	if err != nil {
-		return 0, fmt.Errorf("upsert jobs: %w", err)
+		return runID, 0, fmt.Errorf("upsert jobs: %w", err)
 	}
+	created = res.Created
 
 	if s.notif != nil && len(res.NewJobs) > 0 {
+		active.setPhase(RunPhaseNotifying)
 		if err := s.notif.Notify(ctx, res.NewJobs); err != nil {
-			return res.Created, fmt.Errorf("notify: %w", err)
+			return runID, res.Created, fmt.Errorf("notify: %w", err)
 		}
 	}
 
-	return res.Created, nil
+	return runID, res.Created, nil
 }
 
 func defaultTicker(d time.Duration) ticker {
@@ -238,7 +476,14 @@ func (s *Scheduler) startCron(ctx context.Context) error {
 			timer.Stop()
 			return ctx.Err()
 		case <-timer.C:
-			if _, err := s.runOnce(ctx); err != nil {
+			if !s.leader.IsLeader() {
+				continue
+			}
+			_, created, err := s.runOnce(ctx, model.ScheduleRunTriggerCron)
+			if s.runHook != nil {
+				s.runHook(created, err)
+			}
+			if err != nil {
 				return err
 			}
 		}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -147,6 +148,38 @@ func TestSchedulerNotifiesOnlyWhenNewJobs(t *testing.T) {
 	}
 }
 
+func TestSchedulerRecordsScheduleRun(t *testing.T) {
+	t.Parallel()
+
+	f := &stubFetcher{jobs: []model.Job{{ID: "raw1", Title: "Job1"}}}
+	store := &stubStore{}
+	store.pending = []model.RawJob{{ID: 1, ExternalID: "raw1", Source: "eleduck", Title: "Job1"}}
+	store.jobResult = storage.UpsertResult{Created: 1, NewJobs: []model.Job{{ID: "raw1"}}}
+
+	proc := &stubProcessor{
+		results: map[string]processor.Result{
+			"raw1": {Outcome: processor.ResultAccepted, Job: &model.Job{ID: "raw1", Title: "Job1"}},
+		},
+	}
+
+	runStore := &stubRunStore{}
+	sched := NewScheduler(f, store, proc, nil, Config{Interval: "1h", Timeout: "5s", ProcessorBatchSize: 5}).WithName("eleduck").WithRunStore(runStore)
+
+	if _, err := sched.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+
+	if runStore.created == nil || runStore.created.JobName != "eleduck" {
+		t.Fatalf("expected schedule run created with job name, got %+v", runStore.created)
+	}
+	if runStore.updated.Status != model.ScheduleRunStatusSuccess || runStore.updated.CreatedCount != 1 || runStore.updated.AcceptedCount != 1 {
+		t.Fatalf("unexpected schedule run update: %+v", runStore.updated)
+	}
+	if len(runStore.outcomes) != 1 || runStore.outcomes[0].Status != model.RawJobStatusProcessed {
+		t.Fatalf("unexpected schedule run outcomes: %+v", runStore.outcomes)
+	}
+}
+
 // --- stubs ---
 
 type stubFetcher struct {
@@ -168,6 +201,7 @@ type stubStore struct {
 	rawUpsertResult storage.RawUpsertResult
 	jobResult       storage.UpsertResult
 	pending         []model.RawJob
+	byID            map[uint]model.RawJob
 	statusUpdates   []statusRecord
 	upsertCalls     atomic.Int32
 	mu              sync.Mutex
@@ -188,6 +222,16 @@ func (s *stubStore) ListRawJobs(ctx context.Context, q storage.RawJobQuery) ([]m
 	return append([]model.RawJob(nil), s.pending...), nil
 }
 
+func (s *stubStore) GetRawJob(ctx context.Context, id uint) (model.RawJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, ok := s.byID[id]
+	if !ok {
+		return model.RawJob{}, sql.ErrNoRows
+	}
+	return raw, nil
+}
+
 func (s *stubStore) UpdateRawJobStatus(ctx context.Context, id uint, update storage.RawJobStatusUpdate) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -200,6 +244,14 @@ func (s *stubStore) UpsertJobs(ctx context.Context, jobs []model.Job) (storage.U
 	return s.jobResult, nil
 }
 
+func (s *stubStore) TouchJobsSeen(ctx context.Context, ids []string, seenAt time.Time) error {
+	return nil
+}
+
+func (s *stubStore) ArchiveStaleJobs(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
 type stubProcessor struct {
 	mu      sync.Mutex
 	results map[string]processor.Result
@@ -214,6 +266,35 @@ func (s *stubProcessor) Process(ctx context.Context, raw model.RawJob) (processo
 	return processor.Result{Outcome: processor.ResultRejected, Reason: "missing"}, nil
 }
 
+type stubRunStore struct {
+	mu       sync.Mutex
+	created  *model.ScheduleRun
+	updated  storage.ScheduleRunUpdate
+	outcomes []model.ScheduleRunOutcome
+}
+
+func (s *stubRunStore) CreateScheduleRun(ctx context.Context, run *model.ScheduleRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run.ID = 1
+	s.created = run
+	return nil
+}
+
+func (s *stubRunStore) UpdateScheduleRun(ctx context.Context, id uint, update storage.ScheduleRunUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updated = update
+	return nil
+}
+
+func (s *stubRunStore) RecordScheduleRunOutcome(ctx context.Context, outcome model.ScheduleRunOutcome) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomes = append(s.outcomes, outcome)
+	return nil
+}
+
 type stubTicker struct {
 	ch chan time.Time
 }
@@ -0,0 +1,284 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"remote-radar/internal/cluster"
+	"remote-radar/internal/fetcher"
+	"remote-radar/internal/processor"
+)
+
+// defaultJobName 是 RegisterDefault 注册的任务名称，供仅有单一来源的既有调用方保持兼容。
+const defaultJobName = "default"
+
+// JobSpec 描述单个命名抓取任务的调度参数。
+type JobSpec struct {
+	Interval     string `yaml:"interval" json:"interval"`
+	Timeout      string `yaml:"timeout" json:"timeout"`
+	BatchSize    int    `yaml:"batch_size" json:"batch_size"`
+	Enabled      bool   `yaml:"enabled" json:"enabled"`
+	ArchiveAfter string `yaml:"archive_after" json:"archive_after"`
+}
+
+// JobStatus 描述某个命名任务最近一次由 ticker/cron 触发的运行的状态，供 /api/schedules 暴露；
+// RunNamed/RunOnce 手动执行不更新它。
+type JobStatus struct {
+	Name      string    `json:"name"`
+	Spec      JobSpec   `json:"spec"`
+	LastRunAt time.Time `json:"last_run_at"`
+	LastCount int       `json:"last_count"`
+	LastErr   string    `json:"last_err,omitempty"`
+}
+
+// JobRegistry 管理多个命名抓取任务，每个任务拥有独立的调度周期、超时与运行状态，
+// 支持运行时 Register/Update/Remove 而无需重启进程；所有任务共享同一个 Store/Processor/Notifier/Leader。
+type JobRegistry struct {
+	store    Store
+	proc     processor.JobProcessor
+	notif    Notifier
+	leader   cluster.Leader
+	runStore ScheduleRunStore
+
+	mu   sync.Mutex
+	ctx  context.Context
+	jobs map[string]*registeredJob
+}
+
+type registeredJob struct {
+	name    string
+	fetcher fetcher.JobFetcher
+	sched   *Scheduler
+	spec    JobSpec
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// NewJobRegistry 创建 JobRegistry，默认单机始终为 leader。
+func NewJobRegistry(s Store, proc processor.JobProcessor, notif Notifier) *JobRegistry {
+	return &JobRegistry{
+		store:  s,
+		proc:   proc,
+		notif:  notif,
+		leader: cluster.NewSingleLeader(""),
+		jobs:   make(map[string]*registeredJob),
+	}
+}
+
+// WithLeader 设置所有任务共用的 leader 选举实现，需在 Register 之前调用才能应用到已注册任务。
+func (r *JobRegistry) WithLeader(leader cluster.Leader) *JobRegistry {
+	r.leader = leader
+	return r
+}
+
+// WithRunStore 设置所有任务共用的调度运行历史存储，需在 Register 之前调用才能应用到已注册任务。
+func (r *JobRegistry) WithRunStore(store ScheduleRunStore) *JobRegistry {
+	r.runStore = store
+	return r
+}
+
+// Register 注册一个命名抓取任务，spec.Enabled 为 true 且 registry 已 Start 时立即开始调度。
+func (r *JobRegistry) Register(name string, f fetcher.JobFetcher, spec JobSpec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[name]; exists {
+		return fmt.Errorf("job %q already registered", name)
+	}
+
+	job := r.newJob(name, f, spec)
+	r.jobs[name] = job
+	if spec.Enabled && r.ctx != nil {
+		job.start(r.ctx)
+	}
+	return nil
+}
+
+// RegisterDefault 将旧版 Config 转换为一个名为 "default" 的任务并注册，
+// 供仅有单一来源的既有调用方在不感知 JobRegistry 的情况下保持兼容。
+func (r *JobRegistry) RegisterDefault(f fetcher.JobFetcher, cfg Config) error {
+	return r.Register(defaultJobName, f, JobSpec{
+		Interval:     cfg.Interval,
+		Timeout:      cfg.Timeout,
+		BatchSize:    cfg.ProcessorBatchSize,
+		Enabled:      true,
+		ArchiveAfter: cfg.ArchiveAfter,
+	})
+}
+
+// Update 更新已注册任务的调度参数，原地重启其调度循环以应用新配置。
+func (r *JobRegistry) Update(name string, spec JobSpec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[name]
+	if !ok {
+		return fmt.Errorf("job %q not registered", name)
+	}
+
+	job.stop()
+	newJob := r.newJob(name, job.fetcher, spec)
+	r.jobs[name] = newJob
+	if spec.Enabled && r.ctx != nil {
+		newJob.start(r.ctx)
+	}
+	return nil
+}
+
+// Remove 停止并移除一个已注册任务。
+func (r *JobRegistry) Remove(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[name]
+	if !ok {
+		return fmt.Errorf("job %q not registered", name)
+	}
+	job.stop()
+	delete(r.jobs, name)
+	return nil
+}
+
+// List 返回所有已注册任务的当前状态，按名称排序。
+func (r *JobRegistry) List() []JobStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		job.mu.Lock()
+		statuses = append(statuses, job.status)
+		job.mu.Unlock()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Start 启动所有已注册且 Enabled 的任务，阻塞直到 ctx 取消，随后停止所有任务并返回 ctx.Err()。
+func (r *JobRegistry) Start(ctx context.Context) error {
+	r.mu.Lock()
+	r.ctx = ctx
+	for _, job := range r.jobs {
+		if job.spec.Enabled {
+			job.start(ctx)
+		}
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+
+	r.mu.Lock()
+	for _, job := range r.jobs {
+		job.stop()
+	}
+	r.ctx = nil
+	r.mu.Unlock()
+	return ctx.Err()
+}
+
+// RunOnce 立即手动执行一次 "default" 任务，兼容既有只注册单一来源的调用方（如 /api/refresh）。
+func (r *JobRegistry) RunOnce(ctx context.Context) (int, error) {
+	return r.RunNamed(ctx, defaultJobName)
+}
+
+// RunNamed 立即手动执行一次指定名称的任务，忽略其调度间隔。
+func (r *JobRegistry) RunNamed(ctx context.Context, name string) (int, error) {
+	r.mu.Lock()
+	job, ok := r.jobs[name]
+	r.mu.Unlock()
+	if !ok {
+		return 0, fmt.Errorf("job %q not registered", name)
+	}
+	return job.sched.RunOnce(ctx)
+}
+
+// LeaderStatus 返回当前 leader 选举状态（registry 下所有任务共享同一个 leader），供 /api/cluster/leader 暴露。
+func (r *JobRegistry) LeaderStatus(ctx context.Context) (cluster.Status, error) {
+	return r.leader.Status(ctx)
+}
+
+// ActiveRuns 汇总所有已注册任务当前仍在执行的批次，供 /api/jobs/runs/active 暴露。
+func (r *JobRegistry) ActiveRuns() []ActiveRun {
+	r.mu.Lock()
+	jobs := make([]*registeredJob, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		jobs = append(jobs, job)
+	}
+	r.mu.Unlock()
+
+	var out []ActiveRun
+	for _, job := range jobs {
+		out = append(out, job.sched.ActiveRuns()...)
+	}
+	return out
+}
+
+// Cancel 在所有已注册任务中查找并取消指定 ID 的在途批次。
+func (r *JobRegistry) Cancel(id uint) bool {
+	r.mu.Lock()
+	jobs := make([]*registeredJob, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		jobs = append(jobs, job)
+	}
+	r.mu.Unlock()
+
+	for _, job := range jobs {
+		if job.sched.Cancel(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *JobRegistry) newJob(name string, f fetcher.JobFetcher, spec JobSpec) *registeredJob {
+	job := &registeredJob{
+		name:    name,
+		fetcher: f,
+		spec:    spec,
+		status:  JobStatus{Name: name, Spec: spec},
+	}
+	job.sched = NewScheduler(f, r.store, r.proc, r.notif, Config{
+		Interval:           spec.Interval,
+		Timeout:            spec.Timeout,
+		ProcessorBatchSize: spec.BatchSize,
+		ArchiveAfter:       spec.ArchiveAfter,
+	}).WithLeader(r.leader).WithRunHook(job.recordRun).WithName(name).WithRunStore(r.runStore)
+	return job
+}
+
+func (j *registeredJob) recordRun(created int, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status.LastRunAt = time.Now()
+	j.status.LastCount = created
+	if err != nil {
+		j.status.LastErr = err.Error()
+	} else {
+		j.status.LastErr = ""
+	}
+}
+
+func (j *registeredJob) start(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	j.cancel = cancel
+	j.done = make(chan struct{})
+	go func() {
+		defer close(j.done)
+		_ = j.sched.Start(ctx)
+	}()
+}
+
+func (j *registeredJob) stop() {
+	if j.cancel == nil {
+		return
+	}
+	j.cancel()
+	<-j.done
+	j.cancel = nil
+}
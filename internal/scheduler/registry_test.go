@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+	"remote-radar/internal/processor"
+	"remote-radar/internal/storage"
+)
+
+func TestJobRegistryRunsRegisteredJob(t *testing.T) {
+	t.Parallel()
+
+	f := &stubFetcher{jobs: []model.Job{{ID: "x"}}}
+	store := &stubStore{
+		jobResult: storage.UpsertResult{Created: 1, NewJobs: []model.Job{{ID: "x"}}},
+		pending:   []model.RawJob{{ID: 1, ExternalID: "x", Source: "eleduck"}},
+	}
+	proc := &stubProcessor{
+		results: map[string]processor.Result{"x": {Outcome: processor.ResultAccepted, Job: &model.Job{ID: "x"}}},
+	}
+
+	registry := NewJobRegistry(store, proc, nil)
+	if err := registry.Register("eleduck", f, JobSpec{Interval: "20ms", Timeout: "1s", BatchSize: 1, Enabled: true}); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = registry.Start(ctx)
+	}()
+
+	deadline := time.After(time.Second)
+	for f.calls.Load() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for job to run")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	statuses := registry.List()
+	if len(statuses) != 1 || statuses[0].Name != "eleduck" {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestJobRegistryDuplicateRegisterFails(t *testing.T) {
+	t.Parallel()
+
+	registry := NewJobRegistry(&stubStore{}, &stubProcessor{}, nil)
+	f := &stubFetcher{}
+	if err := registry.Register("a", f, JobSpec{Interval: "1h"}); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	if err := registry.Register("a", f, JobSpec{Interval: "1h"}); err == nil {
+		t.Fatal("expected error registering duplicate job name")
+	}
+}
+
+func TestJobRegistryUpdateAndRemove(t *testing.T) {
+	t.Parallel()
+
+	registry := NewJobRegistry(&stubStore{}, &stubProcessor{}, nil)
+	f := &stubFetcher{}
+	if err := registry.Register("a", f, JobSpec{Interval: "1h"}); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+
+	if err := registry.Update("a", JobSpec{Interval: "2h", BatchSize: 5}); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	statuses := registry.List()
+	if len(statuses) != 1 || statuses[0].Spec.BatchSize != 5 {
+		t.Fatalf("expected updated spec to apply, got %+v", statuses)
+	}
+
+	if err := registry.Remove("a"); err != nil {
+		t.Fatalf("Remove error: %v", err)
+	}
+	if len(registry.List()) != 0 {
+		t.Fatalf("expected no jobs after Remove, got %+v", registry.List())
+	}
+
+	if err := registry.Update("missing", JobSpec{}); err == nil {
+		t.Fatal("expected error updating unregistered job")
+	}
+	if err := registry.Remove("missing"); err == nil {
+		t.Fatal("expected error removing unregistered job")
+	}
+}
+
+func TestJobRegistryRunOnceDefaultsToDefaultJob(t *testing.T) {
+	t.Parallel()
+
+	f := &stubFetcher{jobs: []model.Job{{ID: "raw1"}}}
+	store := &stubStore{
+		jobResult: storage.UpsertResult{Created: 1, NewJobs: []model.Job{{ID: "raw1"}}},
+		pending:   []model.RawJob{{ID: 1, ExternalID: "raw1", Source: "eleduck"}},
+	}
+	proc := &stubProcessor{
+		results: map[string]processor.Result{"raw1": {Outcome: processor.ResultAccepted, Job: &model.Job{ID: "raw1"}}},
+	}
+
+	registry := NewJobRegistry(store, proc, nil)
+	if err := registry.RegisterDefault(f, Config{Interval: "1h", Timeout: "5s", ProcessorBatchSize: 5}); err != nil {
+		t.Fatalf("RegisterDefault error: %v", err)
+	}
+
+	created, err := registry.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+	if created != 1 {
+		t.Fatalf("expected created=1, got %d", created)
+	}
+
+	if _, err := registry.RunNamed(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error running unregistered job")
+	}
+}
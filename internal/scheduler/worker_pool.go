@@ -0,0 +1,153 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"remote-radar/internal/model"
+	"remote-radar/internal/processor"
+	"remote-radar/internal/queue"
+	"remote-radar/internal/storage"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// WorkerPoolStore 抽象 WorkerPool 所需的存储操作。
+type WorkerPoolStore interface {
+	GetRawJob(ctx context.Context, id uint) (model.RawJob, error)
+	UpdateRawJobStatus(ctx context.Context, id uint, update storage.RawJobStatusUpdate) error
+	UpsertJobs(ctx context.Context, jobs []model.Job) (storage.UpsertResult, error)
+}
+
+// WorkerPoolConfig 配置多进程分布式处理 RawJob 的工作池。
+type WorkerPoolConfig struct {
+	Workers       int           `yaml:"workers" json:"workers"`
+	PollInterval  time.Duration `yaml:"poll_interval" json:"poll_interval"`
+	BatchSize     int           `yaml:"batch_size" json:"batch_size"`
+	FlushInterval time.Duration `yaml:"flush_interval" json:"flush_interval"`
+}
+
+const (
+	defaultWorkers       = 1
+	defaultPollInterval  = time.Second
+	defaultBatchSize     = 20
+	defaultFlushInterval = 5 * time.Second
+)
+
+// WorkerPool 从 JobQueue 取出 RawJob 引用并分发给多个 worker 处理，
+// 处理结果经 CoalescingWriter 批量合并写入，供多进程水平扩展时替代 Scheduler.runOnce 的串行处理阶段使用。
+type WorkerPool struct {
+	queue     queue.JobQueue
+	store     WorkerPoolStore
+	processor processor.JobProcessor
+	writer    *CoalescingWriter
+	workers   int
+	poll      time.Duration
+}
+
+// NewWorkerPool 创建 WorkerPool，未设置的字段使用合理默认值。
+func NewWorkerPool(q queue.JobQueue, s WorkerPoolStore, proc processor.JobProcessor, notif Notifier, cfg WorkerPoolConfig) *WorkerPool {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	poll := cfg.PollInterval
+	if poll <= 0 {
+		poll = defaultPollInterval
+	}
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+
+	return &WorkerPool{
+		queue:     q,
+		store:     s,
+		processor: proc,
+		writer:    NewCoalescingWriter(s, notif, batchSize, flushInterval),
+		workers:   workers,
+		poll:      poll,
+	}
+}
+
+// Start 启动 cfg.Workers 个并发 worker，直到上下文取消。
+func (p *WorkerPool) Start(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return p.writer.Run(ctx)
+	})
+
+	for i := 0; i < p.workers; i++ {
+		g.Go(func() error {
+			return p.runWorker(ctx)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (p *WorkerPool) runWorker(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ref, ack, err := p.queue.Dequeue(ctx)
+		if errors.Is(err, queue.ErrEmpty) {
+			timer := time.NewTimer(p.poll)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			return fmt.Errorf("dequeue raw job: %w", err)
+		}
+
+		if err := p.handle(ctx, ref); err != nil {
+			return err
+		}
+		if err := ack(ctx); err != nil {
+			return fmt.Errorf("ack raw job %d: %w", ref.ID, err)
+		}
+	}
+}
+
+func (p *WorkerPool) handle(ctx context.Context, ref queue.RawJobRef) error {
+	raw, err := p.store.GetRawJob(ctx, ref.ID)
+	if err != nil {
+		return fmt.Errorf("get raw job %d: %w", ref.ID, err)
+	}
+
+	res, err := p.processor.Process(ctx, raw)
+	if err != nil {
+		return fmt.Errorf("process raw job %d: %w", ref.ID, err)
+	}
+
+	update := storage.RawJobStatusUpdate{Status: model.RawJobStatusRejected, Reason: res.Reason, Details: res.Trace}
+	if res.Outcome == processor.ResultAccepted && res.Job != nil {
+		update.Status = model.RawJobStatusProcessed
+		update.Reason = ""
+	}
+	if err := p.store.UpdateRawJobStatus(ctx, ref.ID, update); err != nil {
+		return fmt.Errorf("update raw job status %d: %w", ref.ID, err)
+	}
+
+	if res.Outcome == processor.ResultAccepted && res.Job != nil {
+		p.writer.Add(*res.Job)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+	"remote-radar/internal/processor"
+	"remote-radar/internal/queue"
+	"remote-radar/internal/storage"
+)
+
+func TestWorkerPoolProcessesAndFlushesBatch(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{
+		byID:      map[uint]model.RawJob{1: {ID: 1, ExternalID: "raw1", Source: "eleduck", Title: "Job1"}},
+		jobResult: storage.UpsertResult{Created: 1, NewJobs: []model.Job{{ID: "raw1"}}},
+	}
+	proc := &stubProcessor{
+		results: map[string]processor.Result{
+			"raw1": {Outcome: processor.ResultAccepted, Job: &model.Job{ID: "raw1", Title: "Job1"}},
+		},
+	}
+	n := &stubNotifier{}
+	q := queue.NewMemoryQueue(1)
+
+	pool := NewWorkerPool(q, store, proc, n, WorkerPoolConfig{Workers: 1, PollInterval: 5 * time.Millisecond, BatchSize: 1, FlushInterval: 5 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pool.Start(ctx)
+	}()
+
+	if err := q.Enqueue(ctx, queue.RawJobRef{ID: 1}); err != nil {
+		t.Fatalf("Enqueue error: %v", err)
+	}
+
+	deadline := time.After(time.Second)
+	for n.calls.Load() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for notifier to fire")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	if len(store.statusUpdates) != 1 {
+		t.Fatalf("expected one status update, got %d", len(store.statusUpdates))
+	}
+	if store.statusUpdates[0].update.Status != model.RawJobStatusProcessed {
+		t.Fatalf("expected raw job marked processed, got %v", store.statusUpdates[0].update.Status)
+	}
+	if store.upsertCalls.Load() != 1 {
+		t.Fatalf("expected jobs upserted once, got %d", store.upsertCalls.Load())
+	}
+}
+
+func TestCoalescingWriterFlushesOnBatchSize(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{jobResult: storage.UpsertResult{Created: 2, NewJobs: []model.Job{{ID: "a"}, {ID: "b"}}}}
+	n := &stubNotifier{}
+
+	w := NewCoalescingWriter(store, n, 2, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = w.Run(ctx)
+	}()
+
+	w.Add(model.Job{ID: "a"})
+	w.Add(model.Job{ID: "b"})
+
+	deadline := time.After(time.Second)
+	for store.upsertCalls.Load() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for batch flush")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	if n.calls.Load() != 1 {
+		t.Fatalf("expected notifier called once, got %d", n.calls.Load())
+	}
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+// indexWriter 抽象批量写入接口，便于测试注入假实现。
+type indexWriter interface {
+	IndexJobs(ctx context.Context, jobs []model.Job) error
+}
+
+// ESIndexer 实现与 scheduler.Notifier 相同形状的 Notify 方法，将新增职位增量写入 ElasticSearch。
+// 它挂在通知链上，因此索引发生在与 LogNotifier/EmailNotifier 相同的新增职位广播中。
+type ESIndexer struct {
+	svc        indexWriter
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewESIndexer 创建 ESIndexer。maxRetries<=0 时默认重试 3 次，backoff<=0 时默认从 500ms 起步指数退避，
+// 避免抓取突发时一次性打满集群。
+func NewESIndexer(svc *SearchService, maxRetries int, backoff time.Duration) *ESIndexer {
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+	return &ESIndexer{svc: svc, maxRetries: maxRetries, backoff: backoff}
+}
+
+// Notify 批量写入新增职位，失败时按指数退避重试。
+func (idx *ESIndexer) Notify(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 || idx.svc == nil {
+		return nil
+	}
+
+	wait := idx.backoff
+	var lastErr error
+	for attempt := 0; attempt <= idx.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+		if err := idx.svc.IndexJobs(ctx, jobs); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("index jobs after %d attempts: %w", idx.maxRetries+1, lastErr)
+}
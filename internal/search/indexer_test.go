@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestESIndexerRetriesOnFailure(t *testing.T) {
+	t.Parallel()
+
+	writer := &stubIndexWriter{failures: 2}
+	idx := NewESIndexer(nil, 3, time.Millisecond)
+	idx.svc = writer
+
+	if err := idx.Notify(context.Background(), []model.Job{{ID: "1"}}); err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if writer.calls != 3 {
+		t.Fatalf("expected 3 attempts (2 failures + 1 success), got %d", writer.calls)
+	}
+}
+
+func TestESIndexerGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	writer := &stubIndexWriter{failures: 10}
+	idx := NewESIndexer(nil, 2, time.Millisecond)
+	idx.svc = writer
+
+	if err := idx.Notify(context.Background(), []model.Job{{ID: "1"}}); err == nil {
+		t.Fatalf("expected error after exhausting retries")
+	}
+	if writer.calls != 3 {
+		t.Fatalf("expected 3 attempts (1 + 2 retries), got %d", writer.calls)
+	}
+}
+
+func TestESIndexerSkipsEmptyJobs(t *testing.T) {
+	t.Parallel()
+
+	writer := &stubIndexWriter{}
+	idx := NewESIndexer(nil, 3, time.Millisecond)
+	idx.svc = writer
+
+	if err := idx.Notify(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if writer.calls != 0 {
+		t.Fatalf("expected no writer calls for empty job list, got %d", writer.calls)
+	}
+}
+
+type stubIndexWriter struct {
+	calls    int
+	failures int
+}
+
+func (s *stubIndexWriter) IndexJobs(ctx context.Context, jobs []model.Job) error {
+	s.calls++
+	if s.calls <= s.failures {
+		return errors.New("simulated failure")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// Config 配置 ElasticSearch 连接与索引名。
+type Config struct {
+	Addresses []string `yaml:"addresses" json:"addresses"`
+	Index     string   `yaml:"index" json:"index"`
+	Sniff     bool     `yaml:"sniff" json:"sniff"`
+}
+
+// SearchQuery 描述 /api/jobs/search 支持的查询条件。
+type SearchQuery struct {
+	Keyword string
+	Tags    []string
+	Sources []string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+	Offset  int
+}
+
+// jobIndexMapping 定义 title/summary 的 CJK 分词字段、tags/source 关键字字段与 published_at 日期字段。
+const jobIndexMapping = `{
+  "mappings": {
+    "properties": {
+      "title":        {"type": "text", "analyzer": "cjk"},
+      "summary":      {"type": "text", "analyzer": "cjk"},
+      "tags":         {"type": "keyword"},
+      "source":       {"type": "keyword"},
+      "published_at": {"type": "date"}
+    }
+  }
+}`
+
+// SearchService 基于 ElasticSearch 镜像职位数据，提供全文检索、标签/来源过滤与时间范围查询。
+type SearchService struct {
+	client *elastic.Client
+	index  string
+}
+
+// NewSearchService 创建 SearchService 并确保索引映射存在。cfg.Sniff 建议在 Docker 化的 ES 集群中设为 false。
+func NewSearchService(ctx context.Context, cfg Config) (*SearchService, error) {
+	if cfg.Index == "" {
+		cfg.Index = "jobs"
+	}
+
+	opts := []elastic.ClientOptionFunc{elastic.SetSniff(cfg.Sniff)}
+	if len(cfg.Addresses) > 0 {
+		opts = append(opts, elastic.SetURL(cfg.Addresses...))
+	}
+
+	client, err := elastic.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new elastic client: %w", err)
+	}
+
+	svc := &SearchService{client: client, index: cfg.Index}
+	if err := svc.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+func (s *SearchService) ensureIndex(ctx context.Context) error {
+	exists, err := s.client.IndexExists(s.index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("check index exists: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if _, err := s.client.CreateIndex(s.index).BodyString(jobIndexMapping).Do(ctx); err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+	return nil
+}
+
+// IndexJobs 批量写入/更新职位文档，按 ID 做幂等覆盖。
+func (s *SearchService) IndexJobs(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	bulk := s.client.Bulk().Index(s.index)
+	for _, job := range jobs {
+		bulk = bulk.Add(elastic.NewBulkIndexRequest().Id(job.ID).Doc(toDocument(job)))
+	}
+
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("bulk index: %w", err)
+	}
+	if resp.Errors {
+		return fmt.Errorf("bulk index reported item-level errors")
+	}
+	return nil
+}
+
+// Query 执行全文检索，支持关键字、标签/来源过滤、发布时间范围与分页。
+func (s *SearchService) Query(ctx context.Context, q SearchQuery) ([]model.Job, error) {
+	boolQuery := elastic.NewBoolQuery()
+	if keyword := strings.TrimSpace(q.Keyword); keyword != "" {
+		boolQuery = boolQuery.Must(elastic.NewMultiMatchQuery(keyword, "title", "summary"))
+	}
+	for _, tag := range q.Tags {
+		boolQuery = boolQuery.Filter(elastic.NewTermQuery("tags", tag))
+	}
+	if len(q.Sources) > 0 {
+		boolQuery = boolQuery.Filter(elastic.NewTermsQueryFromStrings("source", q.Sources...))
+	}
+	if !q.Since.IsZero() || !q.Until.IsZero() {
+		rangeQuery := elastic.NewRangeQuery("published_at")
+		if !q.Since.IsZero() {
+			rangeQuery = rangeQuery.Gte(q.Since)
+		}
+		if !q.Until.IsZero() {
+			rangeQuery = rangeQuery.Lte(q.Until)
+		}
+		boolQuery = boolQuery.Filter(rangeQuery)
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	resp, err := s.client.Search().Index(s.index).Query(boolQuery).
+		Sort("published_at", false).
+		From(q.Offset).Size(limit).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	jobs := make([]model.Job, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		var job model.Job
+		if err := json.Unmarshal(hit.Source, &job); err != nil {
+			return nil, fmt.Errorf("decode hit: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func toDocument(job model.Job) map[string]any {
+	return map[string]any{
+		"id":           job.ID,
+		"title":        job.Title,
+		"summary":      job.Summary,
+		"published_at": job.PublishedAt,
+		"source":       job.Source,
+		"tags":         tagKeys(job.NormalizedTags),
+	}
+}
+
+func tagKeys(tags map[string]any) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	return keys
+}
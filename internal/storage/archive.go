@@ -0,0 +1,351 @@
+package storage
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/gorm"
+)
+
+const defaultArchiveBatchSize = 500
+
+// ArchiveResult 汇总一次归档操作迁移、删除的记录数量与写入的归档文件路径，供调用方日志/上报用。
+type ArchiveResult struct {
+	Archived int64
+	Deleted  int64
+	Files    []string
+}
+
+// ArchiveRawJobsOptions 描述一次 RawJob 归档的筛选与落盘参数：Source/Status 为空表示不按该字段过滤，
+// Before 为归档截止时间（按 CreatedAt 比较），Dir 为归档根目录，BatchSize 控制单批读取/删除的行数，
+// 非正时取 defaultArchiveBatchSize。调用方（如 cmd/server.go 的归档 worker）按配置的保留策略为每个
+// source/status 组合分别传入不同的 Before，从而实现“按来源、按状态差异化保留”。
+type ArchiveRawJobsOptions struct {
+	Source    string
+	Status    model.RawJobStatus
+	Before    time.Time
+	Dir       string
+	BatchSize int
+}
+
+// ArchiveRawJobs 将满足 opts 筛选条件的 RawJob 分批写入 opts.Dir 下按 YYYY/MM/source 分区的压缩
+// NDJSON 文件并校验 sha256，校验通过后在事务中删除对应行；任一批次写入/校验失败时该批次的行不会被
+// 删除，已完成的批次不受影响（幂等：重跑只会重新处理尚未删除的行）。
+func (s *Store) ArchiveRawJobs(ctx context.Context, opts ArchiveRawJobsOptions) (ArchiveResult, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultArchiveBatchSize
+	}
+
+	query := s.db.WithContext(ctx).Where("created_at < ?", opts.Before)
+	if opts.Source != "" {
+		query = query.Where("source = ?", opts.Source)
+	}
+	if opts.Status != "" {
+		query = query.Where("status = ?", opts.Status)
+	}
+
+	var result ArchiveResult
+	var batch []model.RawJob
+	err := query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, batchNum int) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		source := opts.Source
+		if source == "" {
+			source = "mixed"
+		}
+		file, err := writeArchiveBatch(opts.Dir, source, "raw_jobs", batch)
+		if err != nil {
+			return fmt.Errorf("write archive batch: %w", err)
+		}
+
+		ids := make([]uint, 0, len(batch))
+		for _, raw := range batch {
+			ids = append(ids, raw.ID)
+		}
+		res := tx.Where("id IN ?", ids).Delete(&model.RawJob{})
+		if res.Error != nil {
+			return fmt.Errorf("delete archived raw jobs: %w", res.Error)
+		}
+
+		result.Archived += int64(len(batch))
+		result.Deleted += res.RowsAffected
+		result.Files = append(result.Files, file)
+		return nil
+	}).Error
+	if err != nil {
+		return result, fmt.Errorf("archive raw jobs: %w", err)
+	}
+	return result, nil
+}
+
+// ArchiveJobsOptions 描述一次 Job 归档的筛选与落盘参数：Before 按 DisappearedAt 比较，只归档已下线
+// （status=archived）且下线时间早于 Before 的职位，避免误删仍在展示的 open 职位。
+type ArchiveJobsOptions struct {
+	Source    string
+	Before    time.Time
+	Dir       string
+	BatchSize int
+}
+
+// ArchiveJobs 将满足 opts 筛选条件、已下线的 Job 分批写入压缩 NDJSON 归档文件并校验，随后删除对应行，
+// 行为与 ArchiveRawJobs 对称。
+func (s *Store) ArchiveJobs(ctx context.Context, opts ArchiveJobsOptions) (ArchiveResult, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultArchiveBatchSize
+	}
+
+	query := s.db.WithContext(ctx).
+		Where("status = ? AND disappeared_at IS NOT NULL AND disappeared_at < ?", model.JobStatusArchived, opts.Before)
+	if opts.Source != "" {
+		query = query.Where("source = ?", opts.Source)
+	}
+
+	var result ArchiveResult
+	var batch []model.Job
+	err := query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, batchNum int) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		source := opts.Source
+		if source == "" {
+			source = "mixed"
+		}
+		file, err := writeArchiveBatch(opts.Dir, source, "jobs", batch)
+		if err != nil {
+			return fmt.Errorf("write archive batch: %w", err)
+		}
+
+		ids := make([]string, 0, len(batch))
+		for _, job := range batch {
+			ids = append(ids, job.ID)
+		}
+		res := tx.Where("id IN ?", ids).Delete(&model.Job{})
+		if res.Error != nil {
+			return fmt.Errorf("delete archived jobs: %w", res.Error)
+		}
+
+		result.Archived += int64(len(batch))
+		result.Deleted += res.RowsAffected
+		result.Files = append(result.Files, file)
+		return nil
+	}).Error
+	if err != nil {
+		return result, fmt.Errorf("archive jobs: %w", err)
+	}
+
+	if result.Archived > 0 {
+		s.invalidateJobCache()
+	}
+	return result, nil
+}
+
+// writeArchiveBatch 把 records 序列化为 NDJSON 并 gzip 压缩写入 dir/YYYY/MM/source/table-<时间戳>.ndjson.gz，
+// 分区以归档发生的时刻（而非记录自身时间戳）划分，与大多数日志归档系统的惯例一致，便于按“何时归档”回溯。
+// 写入后重新读取文件计算 sha256 并与同名 .sha256 校验文件比对，确认落盘内容完整后才返回，调用方据此决定
+// 是否可以安全删除源表的行。
+func writeArchiveBatch(dir, source, table string, records interface{}) (string, error) {
+	now := time.Now()
+	partition := filepath.Join(dir, fmt.Sprintf("%04d", now.Year()), fmt.Sprintf("%02d", now.Month()), sanitizePathSegment(source))
+	if err := os.MkdirAll(partition, 0o755); err != nil {
+		return "", fmt.Errorf("create archive partition dir: %w", err)
+	}
+
+	path := filepath.Join(partition, fmt.Sprintf("%s-%d.ndjson.gz", table, now.UnixNano()))
+	if err := writeNDJSONGZ(path, records); err != nil {
+		return "", err
+	}
+	if err := verifyArchiveChecksum(path); err != nil {
+		_ = os.Remove(path)
+		_ = os.Remove(path + ".sha256")
+		return "", err
+	}
+	return path, nil
+}
+
+func writeNDJSONGZ(path string, records interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create archive file: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+
+	switch rows := records.(type) {
+	case []model.RawJob:
+		for _, row := range rows {
+			if err := enc.Encode(row); err != nil {
+				_ = gz.Close()
+				return fmt.Errorf("encode archive record: %w", err)
+			}
+		}
+	case []model.Job:
+		for _, row := range rows {
+			if err := enc.Encode(row); err != nil {
+				_ = gz.Close()
+				return fmt.Errorf("encode archive record: %w", err)
+			}
+		}
+	default:
+		_ = gz.Close()
+		return fmt.Errorf("unsupported archive record type %T", records)
+	}
+
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("close archive gzip writer: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("sync archive file: %w", err)
+	}
+
+	sum, err := checksumFile(path)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".sha256", []byte(sum), 0o644); err != nil {
+		return fmt.Errorf("write archive checksum: %w", err)
+	}
+	return nil
+}
+
+func verifyArchiveChecksum(path string) error {
+	want, err := os.ReadFile(path + ".sha256")
+	if err != nil {
+		return fmt.Errorf("read archive checksum: %w", err)
+	}
+	got, err := checksumFile(path)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(want)) != got {
+		return fmt.Errorf("archive checksum mismatch for %s", path)
+	}
+	return nil
+}
+
+func checksumFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open archive file for checksum: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("checksum archive file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sanitizePathSegment 把 source 名中的路径分隔符替换为下划线，避免意外逃逸出归档分区目录。
+func sanitizePathSegment(segment string) string {
+	replacer := strings.NewReplacer("/", "_", "\\", "_", "..", "_")
+	sanitized := replacer.Replace(segment)
+	if sanitized == "" {
+		return "unknown"
+	}
+	return sanitized
+}
+
+// ListArchivedRawJobs 读取 dir/yearMonth/source/ 下已归档的 raw_jobs NDJSON 文件并解码返回，供审计、
+// 问题排查时回溯已从热表删除的原始数据；yearMonth 形如 "2026/01"。
+func (s *Store) ListArchivedRawJobs(ctx context.Context, dir, source, yearMonth string) ([]model.RawJob, error) {
+	partition := filepath.Join(dir, yearMonth, sanitizePathSegment(source))
+	entries, err := os.ReadDir(partition)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read archive partition: %w", err)
+	}
+
+	var out []model.RawJob
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "raw_jobs-") || !strings.HasSuffix(name, ".ndjson.gz") {
+			continue
+		}
+		rows, err := readArchivedRawJobFile(filepath.Join(partition, name))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rows...)
+	}
+	return out, nil
+}
+
+func readArchivedRawJobFile(path string) ([]model.RawJob, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open archived raw jobs file: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("open archive gzip reader: %w", err)
+	}
+	defer gz.Close()
+
+	var out []model.RawJob
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var row model.RawJob
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			return nil, fmt.Errorf("decode archived raw job: %w", err)
+		}
+		out = append(out, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan archived raw jobs file: %w", err)
+	}
+	return out, nil
+}
+
+// PendingRawJobArchiveCount 返回满足 source/status/before 条件、尚未归档的 RawJob 数量，供归档 worker
+// 上报“待归档积压量”指标；source/status 为空表示不按该字段过滤。
+func (s *Store) PendingRawJobArchiveCount(ctx context.Context, source string, status model.RawJobStatus, before time.Time) (int64, error) {
+	query := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("created_at < ?", before)
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("count pending raw job archive: %w", err)
+	}
+	return total, nil
+}
+
+// PendingJobArchiveCount 返回满足 source/before 条件、已下线但尚未归档的 Job 数量。
+func (s *Store) PendingJobArchiveCount(ctx context.Context, source string, before time.Time) (int64, error) {
+	query := s.db.WithContext(ctx).Model(&model.Job{}).
+		Where("status = ? AND disappeared_at IS NOT NULL AND disappeared_at < ?", model.JobStatusArchived, before)
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("count pending job archive: %w", err)
+	}
+	return total, nil
+}
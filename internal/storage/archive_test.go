@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestArchiveRawJobsWritesFileAndDeletesRows(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+	if _, err := store.UpsertRawJobs(ctx, []model.RawJob{{Source: "eleduck", ExternalID: "raw-1", Title: "Raw", PublishedAt: old}}); err != nil {
+		t.Fatalf("UpsertRawJobs error: %v", err)
+	}
+	if err := store.db.Model(&model.RawJob{}).Where("external_id = ?", "raw-1").Update("created_at", old).Error; err != nil {
+		t.Fatalf("backdate raw job: %v", err)
+	}
+
+	archiveDir := filepath.Join(tmp, "archive")
+	result, err := store.ArchiveRawJobs(ctx, ArchiveRawJobsOptions{
+		Source: "eleduck",
+		Before: time.Now(),
+		Dir:    archiveDir,
+	})
+	if err != nil {
+		t.Fatalf("ArchiveRawJobs error: %v", err)
+	}
+	if result.Archived != 1 || result.Deleted != 1 || len(result.Files) != 1 {
+		t.Fatalf("unexpected archive result: %+v", result)
+	}
+	if _, err := os.Stat(result.Files[0]); err != nil {
+		t.Fatalf("expected archive file to exist: %v", err)
+	}
+	if _, err := os.Stat(result.Files[0] + ".sha256"); err != nil {
+		t.Fatalf("expected checksum sidecar to exist: %v", err)
+	}
+
+	pending, err := store.ListRawJobs(ctx, RawJobQuery{Status: model.RawJobStatusPending, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListRawJobs error: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected raw job to be deleted from hot table, got %+v", pending)
+	}
+
+	yearMonth := time.Now().Format("2006/01")
+	archived, err := store.ListArchivedRawJobs(ctx, archiveDir, "eleduck", yearMonth)
+	if err != nil {
+		t.Fatalf("ListArchivedRawJobs error: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ExternalID != "raw-1" {
+		t.Fatalf("expected archived raw job to round-trip, got %+v", archived)
+	}
+}
+
+func TestArchiveJobsOnlyArchivesDisappearedBeforeCutoff(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	now := time.Now()
+	if _, err := store.UpsertJobs(ctx, []model.Job{{ID: "job-1", Title: "Old", Source: "eleduck", FirstSeenAt: now, LastSeenAt: now}}); err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+	disappeared := now.Add(-48 * time.Hour)
+	if err := store.db.Model(&model.Job{}).Where("id = ?", "job-1").
+		Updates(map[string]interface{}{"status": model.JobStatusArchived, "disappeared_at": disappeared}).Error; err != nil {
+		t.Fatalf("mark job archived: %v", err)
+	}
+
+	archiveDir := filepath.Join(tmp, "archive")
+	result, err := store.ArchiveJobs(ctx, ArchiveJobsOptions{Before: now, Dir: archiveDir})
+	if err != nil {
+		t.Fatalf("ArchiveJobs error: %v", err)
+	}
+	if result.Archived != 1 || result.Deleted != 1 {
+		t.Fatalf("unexpected archive result: %+v", result)
+	}
+
+	if _, err := store.GetJob(ctx, "job-1"); err == nil {
+		t.Fatalf("expected job-1 to be deleted from hot table")
+	}
+}
+
+func TestPendingArchiveCountsReflectCutoff(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+	if _, err := store.UpsertRawJobs(ctx, []model.RawJob{{Source: "eleduck", ExternalID: "raw-1", Title: "Raw", PublishedAt: old}}); err != nil {
+		t.Fatalf("UpsertRawJobs error: %v", err)
+	}
+	if err := store.db.Model(&model.RawJob{}).Where("external_id = ?", "raw-1").Update("created_at", old).Error; err != nil {
+		t.Fatalf("backdate raw job: %v", err)
+	}
+
+	countBefore, err := store.PendingRawJobArchiveCount(ctx, "eleduck", model.RawJobStatusPending, time.Now().Add(-72*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingRawJobArchiveCount error: %v", err)
+	}
+	if countBefore != 0 {
+		t.Fatalf("expected 0 pending raw jobs older than 72h, got %d", countBefore)
+	}
+
+	countAfter, err := store.PendingRawJobArchiveCount(ctx, "eleduck", model.RawJobStatusPending, time.Now())
+	if err != nil {
+		t.Fatalf("PendingRawJobArchiveCount error: %v", err)
+	}
+	if countAfter != 1 {
+		t.Fatalf("expected 1 pending raw job, got %d", countAfter)
+	}
+
+	jobCount, err := store.PendingJobArchiveCount(ctx, "eleduck", time.Now())
+	if err != nil {
+		t.Fatalf("PendingJobArchiveCount error: %v", err)
+	}
+	if jobCount != 0 {
+		t.Fatalf("expected 0 pending jobs, got %d", jobCount)
+	}
+}
+
+func TestVerifyArchiveChecksumDetectsCorruption(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "sample.ndjson.gz")
+	if err := writeNDJSONGZ(path, []model.RawJob{{Source: "eleduck", ExternalID: "raw-1"}}); err != nil {
+		t.Fatalf("writeNDJSONGZ error: %v", err)
+	}
+	if err := verifyArchiveChecksum(path); err != nil {
+		t.Fatalf("expected checksum to verify cleanly: %v", err)
+	}
+
+	if err := os.WriteFile(path+".sha256", []byte("0000000000000000000000000000000000000000000000000000000000000000"), 0o644); err != nil {
+		t.Fatalf("corrupt checksum sidecar: %v", err)
+	}
+	if err := verifyArchiveChecksum(path); err == nil {
+		t.Fatalf("expected checksum mismatch to be detected")
+	}
+}
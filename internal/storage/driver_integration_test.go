@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"github.com/testcontainers/testcontainers-go/modules/mysql"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"gorm.io/datatypes"
+)
+
+// TestStorePostgresIntegration 针对真实 Postgres 校验 NewStoreWithConfig 与 tag 过滤；
+// 仅在设置 REMOTE_RADAR_TEST_POSTGRES=1 时运行，默认跳过，避免要求每位开发者本地装有 Docker。
+func TestStorePostgresIntegration(t *testing.T) {
+	if os.Getenv("REMOTE_RADAR_TEST_POSTGRES") == "" {
+		t.Skip("set REMOTE_RADAR_TEST_POSTGRES=1 to run against a real Postgres container")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := postgres.Run(ctx, "postgres:16-alpine",
+		postgres.WithDatabase("remote_radar"),
+		postgres.WithUsername("remote_radar"),
+		postgres.WithPassword("remote_radar"),
+	)
+	if err != nil {
+		t.Fatalf("start postgres container: %v", err)
+	}
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("postgres connection string: %v", err)
+	}
+
+	store, err := NewStoreWithConfig(Config{Driver: DriverPostgres, DSN: dsn})
+	if err != nil {
+		t.Fatalf("NewStoreWithConfig error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	assertTagFilterRoundTrip(t, store)
+}
+
+// TestStoreMySQLIntegration 针对真实 MySQL 校验 NewStoreWithConfig 与 tag 过滤；
+// 仅在设置 REMOTE_RADAR_TEST_MYSQL=1 时运行。
+func TestStoreMySQLIntegration(t *testing.T) {
+	if os.Getenv("REMOTE_RADAR_TEST_MYSQL") == "" {
+		t.Skip("set REMOTE_RADAR_TEST_MYSQL=1 to run against a real MySQL container")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := mysql.Run(ctx, "mysql:8",
+		mysql.WithDatabase("remote_radar"),
+		mysql.WithUsername("remote_radar"),
+		mysql.WithPassword("remote_radar"),
+	)
+	if err != nil {
+		t.Fatalf("start mysql container: %v", err)
+	}
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	dsn, err := container.ConnectionString(ctx, "parseTime=true")
+	if err != nil {
+		t.Fatalf("mysql connection string: %v", err)
+	}
+
+	store, err := NewStoreWithConfig(Config{Driver: DriverMySQL, DSN: dsn})
+	if err != nil {
+		t.Fatalf("NewStoreWithConfig error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	assertTagFilterRoundTrip(t, store)
+}
+
+// assertTagFilterRoundTrip 在给定 store 上写入两条带不同标签的职位，断言按标签过滤只命中期望的一条，
+// 供 Postgres/MySQL 集成测试共用，避免重复装配逻辑。
+func assertTagFilterRoundTrip(t *testing.T, store *Store) {
+	t.Helper()
+
+	ctx := context.Background()
+	_, err := store.UpsertJobs(ctx, []model.Job{
+		{ID: "driver-tag-1", Title: "Backend", PublishedAt: time.Now(), Source: "eleduck", NormalizedTags: datatypes.JSONMap{"backend": true}},
+		{ID: "driver-tag-2", Title: "Frontend", PublishedAt: time.Now(), Source: "eleduck", NormalizedTags: datatypes.JSONMap{"frontend": true}},
+	})
+	if err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+
+	jobs, err := store.ListJobs(ctx, JobQueryOptions{Tags: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("ListJobs error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "driver-tag-1" {
+		t.Fatalf("expected only driver-tag-1 to match backend tag filter, got %+v", jobs)
+	}
+}
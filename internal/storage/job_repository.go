@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobRepository 封装 Job 聚合的增删查，与 RawJobRepository/SubscriptionRepository 一起
+// 由 Storage 组合、共享同一个 *gorm.DB（见 storage.go）。driver 决定 applyJobFilters/jobOrderExpr
+// 等按方言分支的 SQL 片段；sqliteFTS5 标记 sqlite 下 go-sqlite3 是否实际编译了 FTS5 模块（见
+// search.go 的 ensureFullTextSearchSchema），未编译时按该标记退化为 LIKE 匹配。queryCache 为只读
+// 查询提供有界 TTL 缓存，Storage 未启用缓存时为 nil。Store 仍然保留 UpsertJobs 等同名方法作为
+// 过渡期的兼容 shim，内部直接转发到这里。
+type JobRepository struct {
+	db         *gorm.DB
+	driver     string
+	sqliteFTS5 bool
+	queryCache *queryCache
+}
+
+// WithTx 在一个事务内执行 fn，fn 接收的 tx 绑定到该事务，事务内的多次 Job 写入要么全部生效要么全部
+// 回滚。跨 Job/RawJob/Subscription 聚合的原子操作（如“分类 RawJob → 写入 Job → 标记 RawJob 已处理”）
+// 请使用 Storage.WithTx。
+func (r *JobRepository) WithTx(ctx context.Context, fn func(tx *JobRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&JobRepository{db: tx, driver: r.driver, sqliteFTS5: r.sqliteFTS5, queryCache: r.queryCache})
+	})
+}
+
+func (r *JobRepository) invalidateCache() {
+	clearQueryCache(r.queryCache)
+}
+
+// UpsertJobs 写入职位列表，已有主键则更新，返回新增数量与新增记录。新记录的 FirstSeenAt/LastSeenAt
+// 置为当前时间并标记 open；已存在的记录覆盖 title/summary 等内容字段并刷新 LastSeenAt/Status/DisappearedAt（视为重新出现），
+// FirstSeenAt 保留首次入库时的取值不变。
+func (r *JobRepository) UpsertJobs(ctx context.Context, jobs []model.Job) (UpsertResult, error) {
+	res := UpsertResult{}
+	if len(jobs) == 0 {
+		return res, nil
+	}
+
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+
+	var existing []string
+	if err := r.db.WithContext(ctx).Model(&model.Job{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+		return res, fmt.Errorf("query existing ids: %w", err)
+	}
+
+	existingSet := make(map[string]struct{}, len(existing))
+	for _, id := range existing {
+		existingSet[id] = struct{}{}
+	}
+
+	now := time.Now()
+	for i, id := range ids {
+		jobs[i].LastSeenAt = now
+		jobs[i].Status = model.JobStatusOpen
+		jobs[i].DisappearedAt = nil
+		if _, ok := existingSet[id]; !ok {
+			jobs[i].FirstSeenAt = now
+			res.Created++
+			res.NewJobs = append(res.NewJobs, jobs[i])
+			existingSet[id] = struct{}{}
+		}
+	}
+
+	tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"title",
+			"summary",
+			"published_at",
+			"source",
+			"url",
+			"tags",
+			"raw_attributes",
+			"normalized_tags",
+			"skill_tags",
+			"employment_type",
+			"salary_range",
+			"role_category",
+			"language_requirement",
+			"score",
+			"verdict",
+			"last_seen_at",
+			"status",
+			"disappeared_at",
+			"updated_at",
+		}),
+	}).Create(&jobs)
+	if tx.Error != nil {
+		return res, fmt.Errorf("upsert jobs: %w", tx.Error)
+	}
+
+	r.invalidateCache()
+	return res, nil
+}
+
+// ListJobs 返回职位列表：opts.Query 为空时按发布时间倒序，非空时按全文检索相关度排序（见
+// search.go 的 jobOrderExpr）；命中只读查询缓存（启用时）则直接返回缓存结果。
+func (r *JobRepository) ListJobs(ctx context.Context, opts JobQueryOptions) ([]model.Job, error) {
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+
+	var cacheKey string
+	if r.queryCache != nil {
+		cacheKey = jobQueryCacheKey("list", opts)
+		if cached, ok := r.queryCache.get(cacheKey); ok {
+			return cached.([]model.Job), nil
+		}
+	}
+
+	var jobs []model.Job
+	query := r.db.WithContext(ctx).Model(&model.Job{})
+	query = applyJobFilters(query, opts, r.driver, r.sqliteFTS5)
+	query = query.Order(jobOrderExpr(opts, r.driver, r.sqliteFTS5))
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+
+	now := time.Now()
+	for i := range jobs {
+		jobs[i].Age = model.FormatDuration(now.Sub(jobs[i].FirstSeenAt))
+		end := now
+		if jobs[i].DisappearedAt != nil {
+			end = *jobs[i].DisappearedAt
+		}
+		jobs[i].DurationOpen = model.FormatDuration(end.Sub(jobs[i].FirstSeenAt))
+	}
+
+	if r.queryCache != nil {
+		r.queryCache.set(cacheKey, jobs)
+	}
+	return jobs, nil
+}
+
+// CountJobs 返回满足过滤条件的职位数量；命中只读查询缓存（启用时）则直接返回缓存结果。
+func (r *JobRepository) CountJobs(ctx context.Context, opts JobQueryOptions) (int64, error) {
+	var cacheKey string
+	if r.queryCache != nil {
+		cacheKey = jobQueryCacheKey("count", opts)
+		if cached, ok := r.queryCache.get(cacheKey); ok {
+			return cached.(int64), nil
+		}
+	}
+
+	var total int64
+	query := applyJobFilters(r.db.WithContext(ctx).Model(&model.Job{}), opts, r.driver, r.sqliteFTS5)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("count jobs: %w", err)
+	}
+
+	if r.queryCache != nil {
+		r.queryCache.set(cacheKey, total)
+	}
+	return total, nil
+}
+
+// GetJob 根据 ID 获取职位；命中只读查询缓存（启用时）则直接返回缓存结果。
+func (r *JobRepository) GetJob(ctx context.Context, id string) (*model.Job, error) {
+	cacheKey := "job:" + id
+	if r.queryCache != nil {
+		if cached, ok := r.queryCache.get(cacheKey); ok {
+			return cached.(*model.Job), nil
+		}
+	}
+
+	var job model.Job
+	if err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+
+	if r.queryCache != nil {
+		r.queryCache.set(cacheKey, &job)
+	}
+	return &job, nil
+}
+
+// ListJobsSince 返回发布时间不早于 since 的职位，按发布时间升序排列，供摘要导出使用。
+func (r *JobRepository) ListJobsSince(ctx context.Context, since time.Time) ([]model.Job, error) {
+	var jobs []model.Job
+	if err := r.db.WithContext(ctx).
+		Where("published_at >= ?", since).
+		Order("published_at ASC").
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("list jobs since: %w", err)
+	}
+	return jobs, nil
+}
+
+// TouchJobsSeen 将本次抓取中仍然存在的职位标记为最近可见：更新 LastSeenAt，并把此前因缺席被标记
+// archived 的记录重新置回 open（清空 DisappearedAt、StaleWarnedAt），FirstSeenAt 保持不变。
+func (r *JobRepository) TouchJobsSeen(ctx context.Context, ids []string, seenAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Model(&model.Job{}).
+		Where("id IN ?", ids).
+		Update("last_seen_at", seenAt).Error; err != nil {
+		return fmt.Errorf("touch jobs seen: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Model(&model.Job{}).
+		Where("id IN ? AND status = ?", ids, model.JobStatusArchived).
+		Updates(map[string]interface{}{"status": model.JobStatusOpen, "disappeared_at": nil, "stale_warned_at": nil}).Error; err != nil {
+		return fmt.Errorf("reopen jobs: %w", err)
+	}
+	r.invalidateCache()
+	return nil
+}
+
+// ArchiveStaleJobs 将 LastSeenAt 早于 before 且仍为 open 的职位标记为 archived，供调度器在每次抓取后
+// 清理源站已下线的职位；返回本次归档数量。
+func (r *JobRepository) ArchiveStaleJobs(ctx context.Context, before time.Time) (int64, error) {
+	tx := r.db.WithContext(ctx).Model(&model.Job{}).
+		Where("status = ? AND last_seen_at < ?", model.JobStatusOpen, before).
+		Updates(map[string]interface{}{"status": model.JobStatusArchived, "disappeared_at": time.Now()})
+	if tx.Error != nil {
+		return 0, fmt.Errorf("archive stale jobs: %w", tx.Error)
+	}
+	r.invalidateCache()
+	return tx.RowsAffected, nil
+}
+
+// ListStaleOpenJobs 返回仍为 open、FirstSeenAt 早于 olderThan 且尚未发出过期预警（StaleWarnedAt 为空）
+// 的职位，供后台 sweeper 推送一次性“停留过久”提醒。
+func (r *JobRepository) ListStaleOpenJobs(ctx context.Context, olderThan time.Duration) ([]model.Job, error) {
+	var jobs []model.Job
+	cutoff := time.Now().Add(-olderThan)
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND first_seen_at <= ? AND stale_warned_at IS NULL", model.JobStatusOpen, cutoff).
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("list stale open jobs: %w", err)
+	}
+	now := time.Now()
+	for i := range jobs {
+		jobs[i].Age = model.FormatDuration(now.Sub(jobs[i].FirstSeenAt))
+		jobs[i].DurationOpen = jobs[i].Age
+	}
+	return jobs, nil
+}
+
+// MarkStaleWarned 把指定职位的 StaleWarnedAt 置为当前时间，避免同一职位重复发出过期预警。
+func (r *JobRepository) MarkStaleWarned(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Model(&model.Job{}).
+		Where("id IN ?", ids).
+		Update("stale_warned_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("mark stale warned: %w", err)
+	}
+	r.invalidateCache()
+	return nil
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultQueryCacheSize = 256
+	defaultQueryCacheTTL  = 30 * time.Second
+)
+
+// QueryCacheConfig 控制 Store 对 GetJob/ListJobs/CountJobs 等只读查询结果的缓存；Enabled 为 false
+// 时 Store 不做任何缓存，行为与引入缓存前完全一致。Size/TTL 留空或非正时分别取默认值 256、30 秒。
+type QueryCacheConfig struct {
+	Enabled bool
+	Size    int
+	TTL     time.Duration
+}
+
+// CacheStats 汇报只读查询缓存的累计命中、未命中与淘汰次数，供运维据此调整 QueryCacheConfig。
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// queryCache 是一个按最近使用顺序淘汰的有界 TTL 缓存，用于缓存 Store 读路径的查询结果；
+// value 为 any 以同时承载 []model.Job、int64、*model.Job 等不同类型的查询结果。
+type queryCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List
+	stats   CacheStats
+}
+
+type queryCacheEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newQueryCache(cfg QueryCacheConfig) *queryCache {
+	size := cfg.Size
+	if size <= 0 {
+		size = defaultQueryCacheSize
+	}
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultQueryCacheTTL
+	}
+	return &queryCache{size: size, ttl: ttl, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *queryCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	entry := el.Value.(*queryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(el)
+		c.stats.Misses++
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	c.stats.Hits++
+	return entry.value, true
+}
+
+func (c *queryCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*queryCacheEntry)
+		entry.value = value
+		entry.expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+
+	entry := &queryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(c.ttl)}
+	el := c.order.PushFront(entry)
+	c.entries[key] = el
+	if c.order.Len() > c.size {
+		if oldest := c.order.Back(); oldest != nil {
+			c.removeElement(oldest)
+			c.stats.Evictions++
+		}
+	}
+}
+
+func (c *queryCache) removeElement(el *list.Element) {
+	entry := el.Value.(*queryCacheEntry)
+	delete(c.entries, entry.key)
+	c.order.Remove(el)
+}
+
+// clear 清空缓存，供写路径在修改底层表后使缓存结果失效。
+func (c *queryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+func (c *queryCache) statsSnapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// jobQueryCacheKey 以 opts 的字段值生成缓存 key，保证相同过滤条件命中同一缓存项。
+func jobQueryCacheKey(prefix string, opts JobQueryOptions) string {
+	raw := fmt.Sprintf("%s|tags=%v|status=%s|maxAge=%s|limit=%d|offset=%d|query=%s|language=%s|matchMode=%s",
+		prefix, opts.Tags, opts.Status, opts.MaxAge, opts.Limit, opts.Offset, opts.Query, opts.Language, opts.MatchMode)
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// clearQueryCache 清空 c 持有的查询缓存，c 为 nil（未启用缓存）时不做任何事；
+// JobRepository.invalidateCache 与 Store.invalidateJobCache 共用这份逻辑，保证两者作用于同一个
+// *queryCache 实例时行为一致。
+func clearQueryCache(c *queryCache) {
+	if c != nil {
+		c.clear()
+	}
+}
+
+// invalidateJobCache 清空职位相关的查询缓存，供任何修改 jobs 表的写路径调用。
+func (s *Store) invalidateJobCache() {
+	clearQueryCache(s.queryCache)
+}
+
+// CacheStats 返回只读查询缓存的累计命中/未命中/淘汰次数；未启用缓存时返回零值。
+func (s *Store) CacheStats() CacheStats {
+	if s.queryCache == nil {
+		return CacheStats{}
+	}
+	return s.queryCache.statsSnapshot()
+}
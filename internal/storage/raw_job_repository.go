@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RawJobRepository 封装 RawJob 聚合的核心增删查（写入、按状态列表、按 ID 获取、状态流转），与
+// JobRepository/SubscriptionRepository 一起由 Storage 组合、共享同一个 *gorm.DB（见 storage.go）。
+// 队列租约（AcquireRawJobBatch 等）、向量去重（FindSimilarRaw 等）属于同一张表但关注点不同的扩展能力，
+// 本次重构暂未迁移，仍由 Store 直接提供；后续可按同样的模式继续拆分。
+// Store 仍然保留 UpsertRawJobs 等同名方法作为过渡期的兼容 shim，内部直接转发到这里。
+type RawJobRepository struct {
+	db     *gorm.DB
+	driver string
+}
+
+// WithTx 在一个事务内执行 fn，fn 接收的 tx 绑定到该事务。跨 Job/RawJob/Subscription 聚合的原子操作
+// （如“分类 RawJob → 写入 Job → 标记 RawJob 已处理”）请使用 Storage.WithTx。
+func (r *RawJobRepository) WithTx(ctx context.Context, fn func(tx *RawJobRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&RawJobRepository{db: tx, driver: r.driver})
+	})
+}
+
+// UpsertRawJobs 写入原始抓取数据，按 source + external_id 去重。
+func (r *RawJobRepository) UpsertRawJobs(ctx context.Context, jobs []model.RawJob) (RawUpsertResult, error) {
+	res := RawUpsertResult{}
+	if len(jobs) == 0 {
+		return res, nil
+	}
+
+	bySource := make(map[string][]string)
+	for i := range jobs {
+		if jobs[i].Status == "" {
+			jobs[i].Status = model.RawJobStatusPending
+		}
+		bySource[jobs[i].Source] = append(bySource[jobs[i].Source], jobs[i].ExternalID)
+	}
+
+	existing := make(map[string]struct{})
+	for source, ids := range bySource {
+		if len(ids) == 0 {
+			continue
+		}
+		var rows []string
+		if err := r.db.WithContext(ctx).Model(&model.RawJob{}).
+			Where("source = ? AND external_id IN ?", source, ids).
+			Pluck("external_id", &rows).Error; err != nil {
+			return res, fmt.Errorf("query existing raw ids: %w", err)
+		}
+		for _, ext := range rows {
+			existing[source+"|"+ext] = struct{}{}
+		}
+	}
+
+	var newIndexes []int
+	for i := range jobs {
+		key := jobs[i].Source + "|" + jobs[i].ExternalID
+		if _, ok := existing[key]; !ok {
+			res.Created++
+			newIndexes = append(newIndexes, i)
+			existing[key] = struct{}{}
+		}
+	}
+
+	tx := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source"}, {Name: "external_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "summary", "content", "url", "tags", "raw_payload", "published_at", "updated_at"}),
+	}).Create(&jobs)
+	if tx.Error != nil {
+		return res, fmt.Errorf("upsert raw jobs: %w", tx.Error)
+	}
+
+	// Create 只把自增 ID 回填进传给它的 jobs 切片，上面收集 newIndexes 而不是直接拷贝 jobs[i]，
+	// 就是为了在这里取到带 ID 的版本。
+	for _, i := range newIndexes {
+		res.NewJobs = append(res.NewJobs, jobs[i])
+	}
+
+	return res, nil
+}
+
+// ListRawJobs 返回指定状态的原始数据，默认 pending，按创建时间升序。
+func (r *RawJobRepository) ListRawJobs(ctx context.Context, query RawJobQuery) ([]model.RawJob, error) {
+	var raws []model.RawJob
+	status := query.Status
+	if status == "" {
+		status = model.RawJobStatusPending
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&raws).Error; err != nil {
+		return nil, fmt.Errorf("list raw jobs: %w", err)
+	}
+	return raws, nil
+}
+
+// GetRawJob 根据 ID 获取原始抓取数据，供分布式 worker 按队列中的引用取回完整记录。
+func (r *RawJobRepository) GetRawJob(ctx context.Context, id uint) (model.RawJob, error) {
+	var raw model.RawJob
+	if err := r.db.WithContext(ctx).First(&raw, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.RawJob{}, sql.ErrNoRows
+		}
+		return model.RawJob{}, fmt.Errorf("get raw job: %w", err)
+	}
+	return raw, nil
+}
+
+// UpdateRawJobStatus 更新原始数据状态及 LLM 详情。
+func (r *RawJobRepository) UpdateRawJobStatus(ctx context.Context, id uint, update RawJobStatusUpdate) error {
+	if update.Status == "" {
+		update.Status = model.RawJobStatusProcessed
+	}
+	values := map[string]any{
+		"status": update.Status,
+		"reason": update.Reason,
+	}
+	if update.Details != nil {
+		values["llm_response"] = update.Details
+	}
+	tx := r.db.WithContext(ctx).Model(&model.RawJob{}).Where("id = ?", id).Updates(values)
+	if tx.Error != nil {
+		return fmt.Errorf("update raw job status: %w", tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("update raw job status: id %d not found", id)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestStorageExposesSharedRepositories(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	st := store.Storage()
+
+	if _, err := st.Jobs.UpsertJobs(ctx, []model.Job{
+		{ID: "job1", Title: "Remote Engineer", PublishedAt: time.Now(), Source: "eleduck"},
+	}); err != nil {
+		t.Fatalf("JobRepo.UpsertJobs error: %v", err)
+	}
+
+	// Store 的兼容 shim 应当看到 Storage 经仓储写入的同一份数据。
+	found, err := store.GetJob(ctx, "job1")
+	if err != nil {
+		t.Fatalf("Store.GetJob error: %v", err)
+	}
+	if found.Title != "Remote Engineer" {
+		t.Fatalf("unexpected job title: %q", found.Title)
+	}
+
+	var repoSet RepoSet = st
+	if repoSet.JobRepo() != st.Jobs || repoSet.RawJobRepo() != st.RawJobs || repoSet.SubscriptionRepo() != st.Subscriptions {
+		t.Fatalf("RepoSet accessors did not return the underlying repositories")
+	}
+}
+
+func TestJobRepositoryWithTxRollsBackOnError(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	sentinel := errors.New("boom")
+	err = store.Storage().Jobs.WithTx(ctx, func(tx *JobRepository) error {
+		if _, err := tx.UpsertJobs(ctx, []model.Job{
+			{ID: "job1", Title: "Remote Engineer", PublishedAt: time.Now(), Source: "eleduck"},
+		}); err != nil {
+			return err
+		}
+		return sentinel
+	})
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("expected sentinel error, got %v", err)
+	}
+
+	if _, err := store.GetJob(ctx, "job1"); err == nil {
+		t.Fatalf("expected job1 to not exist after rolled back transaction")
+	}
+}
+
+func TestStorageWithTxClassifiesRawJobIntoJobAtomically(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	st := store.Storage()
+
+	rawRes, err := st.RawJobs.UpsertRawJobs(ctx, []model.RawJob{
+		{Source: "eleduck", ExternalID: "ext-1", Title: "Remote Engineer"},
+	})
+	if err != nil {
+		t.Fatalf("UpsertRawJobs error: %v", err)
+	}
+	if len(rawRes.NewJobs) != 1 {
+		t.Fatalf("expected 1 new raw job, got %d", len(rawRes.NewJobs))
+	}
+	rawID := rawRes.NewJobs[0].ID
+
+	err = st.WithTx(ctx, func(tx *Storage) error {
+		if _, err := tx.Jobs.UpsertJobs(ctx, []model.Job{
+			{ID: "job-from-raw-1", Title: "Remote Engineer", PublishedAt: time.Now(), Source: "eleduck"},
+		}); err != nil {
+			return err
+		}
+		return tx.RawJobs.UpdateRawJobStatus(ctx, rawID, RawJobStatusUpdate{Status: model.RawJobStatusProcessed})
+	})
+	if err != nil {
+		t.Fatalf("Storage.WithTx error: %v", err)
+	}
+
+	if _, err := store.GetJob(ctx, "job-from-raw-1"); err != nil {
+		t.Fatalf("expected job-from-raw-1 to exist: %v", err)
+	}
+	raw, err := store.GetRawJob(ctx, rawID)
+	if err != nil {
+		t.Fatalf("GetRawJob error: %v", err)
+	}
+	if raw.Status != model.RawJobStatusProcessed {
+		t.Fatalf("expected raw job to be marked processed, got status %q", raw.Status)
+	}
+}
+
+func TestSubscriptionRepositoryWithTx(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	sub := &model.Subscription{Email: "user@example.com", FeedToken: "tok-1"}
+	err = store.Storage().Subscriptions.WithTx(ctx, func(tx *SubscriptionRepository) error {
+		return tx.CreateSubscription(ctx, sub)
+	})
+	if err != nil {
+		t.Fatalf("SubscriptionRepository.WithTx error: %v", err)
+	}
+
+	found, err := store.GetSubscriptionByFeedToken(ctx, "tok-1")
+	if err != nil {
+		t.Fatalf("GetSubscriptionByFeedToken error: %v", err)
+	}
+	if found.Email != "user@example.com" {
+		t.Fatalf("unexpected subscription email: %q", found.Email)
+	}
+}
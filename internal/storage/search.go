@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MatchMode 控制 JobQueryOptions.Query 的解析方式：
+//   - phrase（默认）：整体按短语匹配（相邻词序一致）
+//   - prefix：sqlite FTS5 下对每个词做前缀匹配（词尾加 *），适合边输入边搜索；postgres 下使用
+//     websearch_to_tsquery，不做前缀匹配
+//   - bool：原样传给底层全文检索引擎，调用方自行拼接 AND/OR/NOT 语法
+const (
+	MatchModePhrase = "phrase"
+	MatchModePrefix = "prefix"
+	MatchModeBool   = "bool"
+)
+
+// JobSearchResult 在 model.Job 基础上附带命中片段高亮，供 Store.SearchJobs 返回。
+type JobSearchResult struct {
+	model.Job
+	Snippet string `json:"snippet"`
+}
+
+// ensureFullTextSearchSchema 为 jobs 表建立与之同步的全文检索索引：sqlite 下创建 FTS5 虚拟表
+// fts_jobs（content='jobs' 外部内容表，经 triggers 与 jobs 表的增删改保持同步）并对已有数据做一次性
+// 回填；postgres 下追加 search_vector tsvector 列 + GIN 索引，经 trigger 在写入时自动刷新并回填存量行。
+// mysql 暂不支持（request 仅要求 sqlite/postgres），applyJobFilters/SearchJobs 在 mysql 下对 Query 退化
+// 为 LIKE 匹配。仓库目前的 model.Job 没有独立的公司字段，索引范围限定在已有的 Title/Summary 字段上。
+//
+// sqlite 的 FTS5 虚拟表模块是否可用取决于 go-sqlite3 的编译参数（需要 -tags sqlite_fts5 或
+// libsqlite3），默认构建不带这个 tag。返回的 sqliteFTS5 标记该能力是否实际可用：不可用时
+// （CREATE VIRTUAL TABLE 报 "no such module: fts5"）按 mysql 的思路退化为 LIKE 匹配，而不是让
+// 每次打开 sqlite store 都报错。
+func ensureFullTextSearchSchema(db *gorm.DB, driver string) (sqliteFTS5 bool, err error) {
+	switch driver {
+	case DriverPostgres:
+		return false, ensurePostgresSearchSchema(db)
+	case DriverMySQL:
+		return false, nil
+	default:
+		return ensureSQLiteSearchSchema(db)
+	}
+}
+
+func ensureSQLiteSearchSchema(db *gorm.DB) (bool, error) {
+	var exists int64
+	if err := db.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'fts_jobs'").Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("check fts_jobs existence: %w", err)
+	}
+
+	if exists == 0 {
+		if err := db.Exec(`CREATE VIRTUAL TABLE fts_jobs USING fts5(title, summary, content='jobs', content_rowid='rowid', tokenize='porter unicode61')`).Error; err != nil {
+			if isFTS5Unavailable(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("create fts_jobs virtual table: %w", err)
+		}
+		if err := db.Exec(`INSERT INTO fts_jobs(rowid, title, summary) SELECT rowid, title, summary FROM jobs`).Error; err != nil {
+			return false, fmt.Errorf("backfill fts_jobs: %w", err)
+		}
+	}
+
+	statements := []string{
+		`CREATE TRIGGER IF NOT EXISTS jobs_fts_ai AFTER INSERT ON jobs BEGIN
+			INSERT INTO fts_jobs(rowid, title, summary) VALUES (new.rowid, new.title, new.summary);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS jobs_fts_ad AFTER DELETE ON jobs BEGIN
+			INSERT INTO fts_jobs(fts_jobs, rowid, title, summary) VALUES ('delete', old.rowid, old.title, old.summary);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS jobs_fts_au AFTER UPDATE ON jobs BEGIN
+			INSERT INTO fts_jobs(fts_jobs, rowid, title, summary) VALUES ('delete', old.rowid, old.title, old.summary);
+			INSERT INTO fts_jobs(rowid, title, summary) VALUES (new.rowid, new.title, new.summary);
+		END`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return false, fmt.Errorf("create fts_jobs trigger: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// isFTS5Unavailable 识别 go-sqlite3 未编译 FTS5 支持时 CREATE VIRTUAL TABLE ... USING fts5 报出的
+// "no such module: fts5" 错误。
+func isFTS5Unavailable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such module: fts5")
+}
+
+func ensurePostgresSearchSchema(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+		`CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector)`,
+		`CREATE OR REPLACE FUNCTION jobs_search_vector_update() RETURNS trigger AS $$
+		BEGIN
+			NEW.search_vector := to_tsvector('simple', coalesce(NEW.title, '') || ' ' || coalesce(NEW.summary, ''));
+			RETURN NEW;
+		END
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS jobs_search_vector_trigger ON jobs`,
+		`CREATE TRIGGER jobs_search_vector_trigger BEFORE INSERT OR UPDATE ON jobs
+			FOR EACH ROW EXECUTE FUNCTION jobs_search_vector_update()`,
+		`UPDATE jobs SET search_vector = to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(summary, '')) WHERE search_vector IS NULL`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("apply postgres search schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// applySearchFilter 在 opts.Query 非空时为 db 附加全文检索的 JOIN/WHERE 条件；driver 不支持全文
+// 索引（mysql），或 sqlite 的 go-sqlite3 构建没有编译 FTS5（sqliteFTS5=false）时，退化为对
+// title/summary 的 LIKE 匹配。配合 jobOrderExpr 按相关度排序。
+func applySearchFilter(db *gorm.DB, opts JobQueryOptions, driver string, sqliteFTS5 bool) *gorm.DB {
+	if opts.Query == "" {
+		return db
+	}
+	switch driver {
+	case DriverPostgres:
+		config := searchLanguageConfig(opts.Language)
+		tsQuery := tsQueryFunc(opts.MatchMode)
+		return db.Where(fmt.Sprintf("search_vector @@ %s(?, ?)", tsQuery), config, opts.Query)
+	case DriverMySQL:
+		like := "%" + opts.Query + "%"
+		return db.Where("(title LIKE ? OR summary LIKE ?)", like, like)
+	default:
+		if !sqliteFTS5 {
+			like := "%" + opts.Query + "%"
+			return db.Where("(title LIKE ? OR summary LIKE ?)", like, like)
+		}
+		return db.Joins("JOIN fts_jobs ON fts_jobs.rowid = jobs.rowid").Where("fts_jobs MATCH ?", ftsMatchQuery(opts.Query, opts.MatchMode))
+	}
+}
+
+// jobOrderExpr 返回 ListJobs/SearchJobs 应使用的排序表达式：没有查询词时按发布时间倒序；
+// 有查询词时按各 driver 的相关度函数排序（sqlite: bm25，postgres: ts_rank，mysql 没有排序函数，
+// 仍退回发布时间倒序）。sqlite 没有编译 FTS5 时同样退回发布时间倒序，因为 LIKE 匹配没有相关度可排。
+func jobOrderExpr(opts JobQueryOptions, driver string, sqliteFTS5 bool) interface{} {
+	if opts.Query == "" {
+		return "published_at DESC"
+	}
+	switch driver {
+	case DriverPostgres:
+		config := searchLanguageConfig(opts.Language)
+		tsQuery := tsQueryFunc(opts.MatchMode)
+		return clause.Expr{SQL: fmt.Sprintf("ts_rank(search_vector, %s(?, ?)) DESC", tsQuery), Vars: []interface{}{config, opts.Query}}
+	case DriverMySQL:
+		return "published_at DESC"
+	default:
+		if !sqliteFTS5 {
+			return "published_at DESC"
+		}
+		return "bm25(fts_jobs)"
+	}
+}
+
+// searchLanguageConfig 把 JobQueryOptions.Language 映射为 postgres 文本检索配置名，留空时用 simple
+// （不做词形还原，适合多语言混合的职位标题/摘要）。
+func searchLanguageConfig(language string) string {
+	if language == "" {
+		return "simple"
+	}
+	return language
+}
+
+// tsQueryFunc 按 MatchMode 选择 postgres 的查询函数：phrase 用 phraseto_tsquery 保证词序相邻，
+// prefix 用 websearch_to_tsquery（按网页搜索语法解析，不做前缀匹配），bool 让调用方自带 &/|/! 语法。
+func tsQueryFunc(matchMode string) string {
+	switch matchMode {
+	case MatchModePrefix:
+		return "websearch_to_tsquery"
+	case MatchModeBool:
+		return "to_tsquery"
+	default:
+		return "phraseto_tsquery"
+	}
+}
+
+// ftsMatchQuery 按 MatchMode 构造 SQLite FTS5 的 MATCH 表达式。
+func ftsMatchQuery(query, matchMode string) string {
+	switch matchMode {
+	case MatchModePrefix:
+		fields := strings.Fields(query)
+		for i, field := range fields {
+			fields[i] = field + "*"
+		}
+		return strings.Join(fields, " ")
+	case MatchModeBool:
+		return query
+	default:
+		return `"` + strings.ReplaceAll(query, `"`, `""`) + `"`
+	}
+}
+
+// SearchJobs 对 title/summary 做全文检索并返回按相关度排序、附带命中片段高亮的结果；复用
+// applyJobFilters 做 tag/status/search 过滤，opts.Limit<=0 时默认返回 20 条。片段高亮：sqlite 用
+// snippet()，postgres 用 ts_headline，mysql 退化为不截断的 summary 原文（LIKE 匹配没有片段函数可用）；
+// sqlite 没有编译 FTS5 时同样退化为 summary 原文。
+func (r *JobRepository) SearchJobs(ctx context.Context, q string, opts JobQueryOptions) ([]JobSearchResult, error) {
+	opts.Query = q
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	query := applyJobFilters(r.db.WithContext(ctx).Model(&model.Job{}), opts, r.driver, r.sqliteFTS5)
+	query = query.Order(jobOrderExpr(opts, r.driver, r.sqliteFTS5)).Limit(limit)
+
+	switch r.driver {
+	case DriverPostgres:
+		config := searchLanguageConfig(opts.Language)
+		tsQuery := tsQueryFunc(opts.MatchMode)
+		query = query.Select(fmt.Sprintf("jobs.*, ts_headline(?, coalesce(summary, ''), %s(?, ?)) AS snippet", tsQuery), config, config, opts.Query)
+	case DriverMySQL:
+		query = query.Select("jobs.*, summary AS snippet")
+	default:
+		if !r.sqliteFTS5 {
+			query = query.Select("jobs.*, summary AS snippet")
+		} else {
+			query = query.Select("jobs.*, snippet(fts_jobs, -1, '<mark>', '</mark>', '...', 10) AS snippet")
+		}
+	}
+
+	var results []JobSearchResult
+	if err := query.Find(&results).Error; err != nil {
+		return nil, fmt.Errorf("search jobs: %w", err)
+	}
+	return results, nil
+}
+
+// SearchJobs 是 JobRepository.SearchJobs 的过渡期兼容 shim。
+func (s *Store) SearchJobs(ctx context.Context, q string, opts JobQueryOptions) ([]JobSearchResult, error) {
+	return s.jobs.SearchJobs(ctx, q, opts)
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+)
+
+func TestListJobsQuerySearchesTitleAndSummary(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	jobs := []model.Job{
+		{ID: "job1", Title: "Remote Golang Engineer", Summary: "Build distributed systems", PublishedAt: time.Now(), Source: "eleduck"},
+		{ID: "job2", Title: "Frontend Designer", Summary: "Ship delightful interfaces", PublishedAt: time.Now().Add(-time.Hour), Source: "eleduck"},
+	}
+	if _, err := store.UpsertJobs(ctx, jobs); err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+
+	found, err := store.ListJobs(ctx, JobQueryOptions{Query: "Golang"})
+	if err != nil {
+		t.Fatalf("ListJobs with query error: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != "job1" {
+		t.Fatalf("expected only job1 to match Golang query, got %+v", found)
+	}
+
+	total, err := store.CountJobs(ctx, JobQueryOptions{Query: "interfaces"})
+	if err != nil {
+		t.Fatalf("CountJobs with query error: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected 1 match for interfaces query, got %d", total)
+	}
+}
+
+func TestSearchJobsReturnsHighlightedSnippet(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	if _, err := store.UpsertJobs(ctx, []model.Job{
+		{ID: "job1", Title: "Remote Golang Engineer", Summary: "Build distributed systems in Golang", PublishedAt: time.Now(), Source: "eleduck"},
+	}); err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+
+	results, err := store.SearchJobs(ctx, "Golang", JobQueryOptions{})
+	if err != nil {
+		t.Fatalf("SearchJobs error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "job1" {
+		t.Fatalf("expected job1 to match, got %+v", results)
+	}
+	if !strings.Contains(results[0].Snippet, "<mark>") {
+		t.Fatalf("expected snippet to contain highlight markup, got %q", results[0].Snippet)
+	}
+}
+
+func TestListJobsQueryWithPrefixMatchMode(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	if _, err := store.UpsertJobs(ctx, []model.Job{
+		{ID: "job1", Title: "Golang Engineer", PublishedAt: time.Now(), Source: "eleduck"},
+	}); err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+
+	found, err := store.ListJobs(ctx, JobQueryOptions{Query: "Gola", MatchMode: MatchModePrefix})
+	if err != nil {
+		t.Fatalf("ListJobs with prefix query error: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != "job1" {
+		t.Fatalf("expected prefix match to find job1, got %+v", found)
+	}
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Storage 组合 JobRepository/RawJobRepository/SubscriptionRepository，三者共享同一个 *gorm.DB。
+// Store 通过 Storage() 暴露自身持有的这三个仓储，供需要直接操作仓储（而非 Store 兼容 shim）或需要
+// Storage.WithTx 跨聚合事务的调用方使用。
+type Storage struct {
+	Jobs          *JobRepository
+	RawJobs       *RawJobRepository
+	Subscriptions *SubscriptionRepository
+}
+
+// RepoSet 是 Storage 的最小接口形式，供依赖注入时按接口而非具体类型声明依赖。
+type RepoSet interface {
+	JobRepo() *JobRepository
+	RawJobRepo() *RawJobRepository
+	SubscriptionRepo() *SubscriptionRepository
+}
+
+func (st *Storage) JobRepo() *JobRepository                  { return st.Jobs }
+func (st *Storage) RawJobRepo() *RawJobRepository             { return st.RawJobs }
+func (st *Storage) SubscriptionRepo() *SubscriptionRepository { return st.Subscriptions }
+
+// WithTx 在一个事务内执行 fn，fn 接收的 tx 是一个三个仓储都绑定到同一事务的 *Storage，
+// 用于跨 Job/RawJob/Subscription 聚合的原子操作，例如“分类 RawJob → 写入 Job → 标记 RawJob
+// 已处理”这类必须要么全部生效要么全部回滚的序列。
+func (st *Storage) WithTx(ctx context.Context, fn func(tx *Storage) error) error {
+	return st.Jobs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Storage{
+			Jobs:          &JobRepository{db: tx, driver: st.Jobs.driver, sqliteFTS5: st.Jobs.sqliteFTS5, queryCache: st.Jobs.queryCache},
+			RawJobs:       &RawJobRepository{db: tx, driver: st.RawJobs.driver},
+			Subscriptions: &SubscriptionRepository{db: tx},
+		})
+	})
+}
+
+// Storage 返回 Store 持有的 JobRepository/RawJobRepository/SubscriptionRepository 组合，
+// 供调用方绕开 Store 的兼容 shim 直接操作仓储，或使用 Storage.WithTx 做跨聚合事务。
+func (s *Store) Storage() *Storage {
+	return &Storage{Jobs: s.jobs, RawJobs: s.rawJobs, Subscriptions: s.subs}
+}
+
+var _ RepoSet = (*Storage)(nil)
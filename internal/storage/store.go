@@ -3,21 +3,65 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"remote-radar/internal/model"
 
 	"gorm.io/datatypes"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-// Store 封装 SQLite 数据库访问，负责职位、原始数据、订阅的增删查。
+// DriverSQLite/DriverPostgres/DriverMySQL 为 Config.Driver 支持的取值，大小写不敏感，
+// 未识别或留空时 NewStoreWithConfig 回退到 DriverSQLite 以兼容历史单机部署。
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// Config 描述 Store 要连接的数据库：Driver 决定使用的 GORM dialector 与查询分支，
+// DSN 为该 driver 对应的连接串（sqlite 下即数据库文件路径）。Cache 控制只读查询结果缓存，
+// 零值表示不开启。
+type Config struct {
+	Driver string
+	DSN    string
+	Cache  QueryCacheConfig
+}
+
+// Store 封装数据库访问，负责职位、原始数据、订阅的增删查；driver 记录底层 SQL 方言，
+// 供 applyJobFilters 等按 tag 过滤、UpsertJobs 等写入路径选择方言相关的 SQL 片段。queryCache
+// 为 GetJob/ListJobs/CountJobs 等只读查询提供有界 TTL 缓存，cfg.Cache.Enabled 为 false 时为 nil。
+//
+// Job/RawJob/Subscription 三个聚合的增删查已拆分到 JobRepository/RawJobRepository/
+// SubscriptionRepository（见 job_repository.go、raw_job_repository.go、subscription_repository.go），
+// jobs/rawJobs/subs 是 Store 持有的这三个仓储实例；Store 上同名方法保留为过渡期的兼容 shim，
+// 直接转发到对应仓储，其余尚未拆分的聚合（队列租约、向量去重、LLM 缓存/用量、Outbox、JobRun、
+// ScheduleRun 等）仍由 Store 直接实现。
 type Store struct {
-	db *gorm.DB
+	db         *gorm.DB
+	driver     string
+	sqliteFTS5 bool
+	queryCache *queryCache
+	jobs       *JobRepository
+	rawJobs    *RawJobRepository
+	subs       *SubscriptionRepository
+}
+
+// Driver 返回当前连接使用的数据库方言（DriverSQLite/DriverPostgres/DriverMySQL），
+// 供迁移等需要按方言分支的调用方查询。
+func (s *Store) Driver() string {
+	return s.driver
 }
 
 // UpsertResult 表示最终职位写入结果。
@@ -32,11 +76,37 @@ type RawUpsertResult struct {
 	NewJobs []model.RawJob
 }
 
+// WorkerLeaseSummary 按 worker 聚合当前未过期的租约持有情况，供 /api/workers 暴露。
+type WorkerLeaseSummary struct {
+	WorkerID    string    `json:"worker_id"`
+	LeasedJobs  int64     `json:"leased_jobs"`
+	OldestLease time.Time `json:"oldest_lease"`
+}
+
+// QueueStats 汇总 RawJob 各状态及租约占用情况，供 /api/queue/stats 暴露。
+type QueueStats struct {
+	Pending    int64 `json:"pending"`
+	Leased     int64 `json:"leased"`
+	Processed  int64 `json:"processed"`
+	Rejected   int64 `json:"rejected"`
+	DeadLetter int64 `json:"dead_letter"`
+}
+
 // JobQueryOptions 提供职位查询过滤条件。
+// Status 为 model.JobStatusOpen/JobStatusArchived 时按存续状态过滤，留空不限制；
+// MaxAge 设置时只返回 FirstSeenAt 距今不超过该时长的职位。
+// Query 非空时对 title/summary 做全文检索（见 search.go），结果按相关度而非 PublishedAt 排序；
+// Language 指定 postgres 下的文本检索配置（stemmer/分词器），留空为 simple；MatchMode 取
+// MatchModePhrase/MatchModePrefix/MatchModeBool 之一，留空按 MatchModePhrase 处理。
 type JobQueryOptions struct {
-	Limit  int
-	Offset int
-	Tags   []string
+	Limit     int
+	Offset    int
+	Tags      []string
+	Status    string
+	MaxAge    time.Duration
+	Query     string
+	Language  string
+	MatchMode string
 }
 
 // RawJobQuery 描述原始数据筛选条件。
@@ -52,22 +122,88 @@ type RawJobStatusUpdate struct {
 	Details datatypes.JSONMap
 }
 
-// NewStore 创建 Store 并自动迁移数据表。
+// ScheduleRunQuery 过滤调度运行历史列表。
+type ScheduleRunQuery struct {
+	JobName string
+	Status  model.ScheduleRunStatus
+	Limit   int
+}
+
+// ScheduleRunUpdate 用于调度运行结束时写入统计字段与终态。
+type ScheduleRunUpdate struct {
+	Status        model.ScheduleRunStatus
+	FetchedCount  int
+	AcceptedCount int
+	RejectedCount int
+	CreatedCount  int
+	Error         string
+}
+
+// NewStore 以 SQLite 打开数据库并自动迁移数据表，dbPath 为数据库文件路径；
+// 等价于 NewStoreWithConfig(Config{Driver: DriverSQLite, DSN: dbPath})，保留给历史单机部署使用。
 func NewStore(dbPath string) (*Store, error) {
-	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
-		return nil, fmt.Errorf("create db dir: %w", err)
+	return NewStoreWithConfig(Config{Driver: DriverSQLite, DSN: dbPath})
+}
+
+// NewStoreWithConfig 依据 cfg.Driver 打开对应的 GORM dialector（sqlite/postgres/mysql）并自动迁移
+// 数据表；Driver 为空时回退到 sqlite。sqlite 下 DSN 是数据库文件路径，会自动创建所在目录。
+func NewStoreWithConfig(cfg Config) (*Store, error) {
+	driver := normalizeDriver(cfg.Driver)
+
+	var dialector gorm.Dialector
+	switch driver {
+	case DriverPostgres:
+		dialector = postgres.Open(cfg.DSN)
+	case DriverMySQL:
+		dialector = mysql.Open(cfg.DSN)
+	default:
+		if err := os.MkdirAll(filepath.Dir(cfg.DSN), 0o755); err != nil {
+			return nil, fmt.Errorf("create db dir: %w", err)
+		}
+		dialector = sqlite.Open(cfg.DSN)
 	}
 
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	// PrepareStmt 让 GORM 按 SQL 文本缓存并复用预编译语句（ClusterCockpit 的 stmtCache 思路），
+	// 避免 ListJobs/CountJobs/GetJob 等热点查询每次都重新解析、规划 SQL。
+	db, err := gorm.Open(dialector, &gorm.Config{PrepareStmt: true})
 	if err != nil {
-		return nil, fmt.Errorf("open sqlite: %w", err)
+		return nil, fmt.Errorf("open %s: %w", driver, err)
 	}
 
-	if err := db.AutoMigrate(&model.Job{}, &model.RawJob{}, &model.Subscription{}); err != nil {
+	if err := db.AutoMigrate(&model.Job{}, &model.RawJob{}, &model.Subscription{}, &model.FilterViolation{}, &model.NotificationOutbox{}, &model.JobRun{}, &model.ScheduleRun{}, &model.ScheduleRunOutcome{}, &model.PendingNotification{}, &model.LLMCacheEntry{}, &model.LLMUsageRecord{}, &model.RawJobEmbedding{}); err != nil {
 		return nil, fmt.Errorf("auto migrate models: %w", err)
 	}
 
-	return &Store{db: db}, nil
+	// AutoMigrate 只管理 GORM 模型对应的普通表，FTS5 虚拟表/触发器与 postgres 的 tsvector 列+触发器
+	// 需要单独建立（并在 jobs 表已有数据时回填）。sqlite 下 go-sqlite3 若没有编译 FTS5
+	// （未带 -tags sqlite_fts5/libsqlite3），ensureFullTextSearchSchema 不会报错，而是返回
+	// sqliteFTS5=false，driver/JobRepository 据此把 Query 检索退化为 LIKE 匹配。
+	sqliteFTS5, err := ensureFullTextSearchSchema(db, driver)
+	if err != nil {
+		return nil, fmt.Errorf("ensure full text search schema: %w", err)
+	}
+
+	store := &Store{db: db, driver: driver, sqliteFTS5: sqliteFTS5}
+	if cfg.Cache.Enabled {
+		store.queryCache = newQueryCache(cfg.Cache)
+	}
+	store.jobs = &JobRepository{db: db, driver: driver, sqliteFTS5: sqliteFTS5, queryCache: store.queryCache}
+	store.rawJobs = &RawJobRepository{db: db, driver: driver}
+	store.subs = &SubscriptionRepository{db: db}
+	return store, nil
+}
+
+// normalizeDriver 将 Config.Driver 归一化为 DriverSQLite/DriverPostgres/DriverMySQL 之一，
+// 空值或未识别的取值回退到 DriverSQLite。
+func normalizeDriver(driver string) string {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case DriverPostgres:
+		return DriverPostgres
+	case DriverMySQL:
+		return DriverMySQL
+	default:
+		return DriverSQLite
+	}
 }
 
 // Close 关闭底层数据库连接。
@@ -82,229 +218,811 @@ func (s *Store) Close() error {
 	return nil
 }
 
-// UpsertJobs 写入职位列表，已有主键则更新，返回新增数量与新增记录。
+// UpsertJobs 写入职位列表，已有主键则更新，返回新增数量与新增记录。新记录的 FirstSeenAt/LastSeenAt
+// 置为当前时间并标记 open；已存在的记录覆盖 title/summary 等内容字段并刷新 LastSeenAt/Status/DisappearedAt（视为重新出现），
+// FirstSeenAt 保留首次入库时的取值不变。
 func (s *Store) UpsertJobs(ctx context.Context, jobs []model.Job) (UpsertResult, error) {
-	res := UpsertResult{}
-	if len(jobs) == 0 {
-		return res, nil
-	}
+	return s.jobs.UpsertJobs(ctx, jobs)
+}
+
+// UpsertRawJobs 写入原始抓取数据，按 source + external_id 去重。
+func (s *Store) UpsertRawJobs(ctx context.Context, jobs []model.RawJob) (RawUpsertResult, error) {
+	return s.rawJobs.UpsertRawJobs(ctx, jobs)
+}
+
+// ListJobs 返回职位列表：opts.Query 为空时按发布时间倒序，非空时按全文检索相关度排序（见
+// search.go 的 jobOrderExpr）；命中只读查询缓存（启用时）则直接返回缓存结果。
+func (s *Store) ListJobs(ctx context.Context, opts JobQueryOptions) ([]model.Job, error) {
+	return s.jobs.ListJobs(ctx, opts)
+}
+
+// CountJobs 返回满足过滤条件的职位数量；命中只读查询缓存（启用时）则直接返回缓存结果。
+func (s *Store) CountJobs(ctx context.Context, opts JobQueryOptions) (int64, error) {
+	return s.jobs.CountJobs(ctx, opts)
+}
+
+// ListRawJobs 返回指定状态的原始数据，默认 pending，按创建时间升序。
+func (s *Store) ListRawJobs(ctx context.Context, query RawJobQuery) ([]model.RawJob, error) {
+	return s.rawJobs.ListRawJobs(ctx, query)
+}
 
-	ids := make([]string, 0, len(jobs))
-	for _, job := range jobs {
-		ids = append(ids, job.ID)
+// GetRawJob 根据 ID 获取原始抓取数据，供分布式 worker 按队列中的引用取回完整记录。
+func (s *Store) GetRawJob(ctx context.Context, id uint) (model.RawJob, error) {
+	return s.rawJobs.GetRawJob(ctx, id)
+}
+
+// UpdateRawJobStatus 更新原始数据状态及 LLM 详情。
+func (s *Store) UpdateRawJobStatus(ctx context.Context, id uint, update RawJobStatusUpdate) error {
+	return s.rawJobs.UpdateRawJobStatus(ctx, id, update)
+}
+
+// SaveRawJobEmbedding 保存（或更新）rawJobID 对应的语义去重向量，vector 编码为小端序 float32 blob，
+// 并预计算 L2 范数，供 FindSimilarRaw 退化为点积扫描。
+func (s *Store) SaveRawJobEmbedding(ctx context.Context, rawJobID uint, vector []float32) error {
+	embedding := model.RawJobEmbedding{
+		RawJobID: rawJobID,
+		Vector:   encodeVector(vector),
+		Norm:     vectorNorm(vector),
 	}
+	if err := s.db.WithContext(ctx).Save(&embedding).Error; err != nil {
+		return fmt.Errorf("save raw job embedding: %w", err)
+	}
+	return nil
+}
 
-	var existing []string
-	if err := s.db.WithContext(ctx).Model(&model.Job{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
-		return res, fmt.Errorf("query existing ids: %w", err)
+// FindSimilarRaw 返回 since 之后、与 vector 余弦相似度最高的至多 k 条既有 RawJob 向量，按相似度降序
+// 排列。语料规模较小（<10 万）时线性扫描足够，相似度退化为点积 / (两向量 L2 范数之积)。
+func (s *Store) FindSimilarRaw(ctx context.Context, vector []float32, k int, since time.Time) ([]model.SimilarRawJob, error) {
+	var rows []model.RawJobEmbedding
+	if err := s.db.WithContext(ctx).Where("created_at >= ?", since).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list raw job embeddings: %w", err)
 	}
 
-	existingSet := make(map[string]struct{}, len(existing))
-	for _, id := range existing {
-		existingSet[id] = struct{}{}
+	queryNorm := vectorNorm(vector)
+	if queryNorm == 0 || len(rows) == 0 {
+		return nil, nil
 	}
 
-	for i, id := range ids {
-		if _, ok := existingSet[id]; !ok {
-			res.Created++
-			res.NewJobs = append(res.NewJobs, jobs[i])
-			existingSet[id] = struct{}{}
+	candidates := make([]model.SimilarRawJob, 0, len(rows))
+	for _, row := range rows {
+		if row.Norm == 0 {
+			continue
 		}
+		score := dotProduct(vector, decodeVector(row.Vector)) / (queryNorm * row.Norm)
+		candidates = append(candidates, model.SimilarRawJob{RawJobID: row.RawJobID, Score: score})
 	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	if k > 0 && len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates, nil
+}
 
-	tx := s.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "id"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"title",
-			"summary",
-			"published_at",
-			"source",
-			"url",
-			"tags",
-			"raw_attributes",
-			"normalized_tags",
-			"skill_tags",
-			"employment_type",
-			"salary_range",
-			"role_category",
-			"language_requirement",
-			"score",
-			"verdict",
-			"updated_at",
-		}),
-	}).Create(&jobs)
-	if tx.Error != nil {
-		return res, fmt.Errorf("upsert jobs: %w", tx.Error)
+func encodeVector(vector []float32) []byte {
+	buf := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
 	}
+	return buf
+}
 
-	return res, nil
+func decodeVector(data []byte) []float32 {
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return vector
 }
 
-// UpsertRawJobs 写入原始抓取数据，按 source + external_id 去重。
-func (s *Store) UpsertRawJobs(ctx context.Context, jobs []model.RawJob) (RawUpsertResult, error) {
-	res := RawUpsertResult{}
-	if len(jobs) == 0 {
-		return res, nil
+func vectorNorm(vector []float32) float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
 	}
+	return math.Sqrt(sum)
+}
 
-	bySource := make(map[string][]string)
-	for i := range jobs {
-		if jobs[i].Status == "" {
-			jobs[i].Status = model.RawJobStatusPending
-		}
-		bySource[jobs[i].Source] = append(bySource[jobs[i].Source], jobs[i].ExternalID)
+func dotProduct(a, b []float32) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += float64(a[i]) * float64(b[i])
 	}
+	return sum
+}
 
-	existing := make(map[string]struct{})
-	for source, ids := range bySource {
-		if len(ids) == 0 {
-			continue
+// AcquireRawJobBatch 在一个事务内原子地认领最多 n 条可处理的 RawJob：状态为 pending 且租约已过期
+// （或从未被认领，零值 LeaseExpiresAt 天然早于 now），标记为 workerID 持有，租约在 leaseTTL 后到期。
+// SQLite 不支持 SELECT ... FOR UPDATE SKIP LOCKED，这里改为事务内先选出候选 ID 再批量更新，
+// 借助 SQLite 事务隐含的写锁达到同等的互斥效果。
+func (s *Store) AcquireRawJobBatch(ctx context.Context, workerID string, n int, leaseTTL time.Duration) ([]model.RawJob, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var claimed []model.RawJob
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var candidates []model.RawJob
+		if err := tx.
+			Where("status = ? AND lease_expires_at < ?", model.RawJobStatusPending, now).
+			Order("created_at ASC").
+			Limit(n).
+			Find(&candidates).Error; err != nil {
+			return fmt.Errorf("select lease candidates: %w", err)
 		}
-		var rows []string
-		if err := s.db.WithContext(ctx).Model(&model.RawJob{}).
-			Where("source = ? AND external_id IN ?", source, ids).
-			Pluck("external_id", &rows).Error; err != nil {
-			return res, fmt.Errorf("query existing raw ids: %w", err)
+		if len(candidates) == 0 {
+			return nil
 		}
-		for _, ext := range rows {
-			existing[source+"|"+ext] = struct{}{}
+
+		ids := make([]uint, 0, len(candidates))
+		for _, c := range candidates {
+			ids = append(ids, c.ID)
+		}
+		expiresAt := now.Add(leaseTTL)
+		if err := tx.Model(&model.RawJob{}).Where("id IN ?", ids).Updates(map[string]any{
+			"lease_owner":      workerID,
+			"lease_expires_at": expiresAt,
+		}).Error; err != nil {
+			return fmt.Errorf("stamp lease: %w", err)
 		}
+
+		for i := range candidates {
+			candidates[i].LeaseOwner = workerID
+			candidates[i].LeaseExpiresAt = expiresAt
+		}
+		claimed = candidates
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("acquire raw job batch: %w", err)
+	}
+	return claimed, nil
+}
+
+// HeartbeatRawJobs 为 workerID 当前持有的 ids 续期 leaseTTL，用于处理耗时较长时防止租约过期被其他 worker 抢占。
+func (s *Store) HeartbeatRawJobs(ctx context.Context, workerID string, ids []uint, leaseTTL time.Duration) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).
+		Where("id IN ? AND lease_owner = ?", ids, workerID).
+		Update("lease_expires_at", time.Now().Add(leaseTTL)).Error; err != nil {
+		return fmt.Errorf("heartbeat raw jobs: %w", err)
 	}
+	return nil
+}
+
+// AckRawJobLease 在处理成功并已通过 UpdateRawJobStatus 写入终态后释放租约。
+func (s *Store) AckRawJobLease(ctx context.Context, id uint) error {
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("id = ?", id).
+		Updates(map[string]any{"lease_owner": "", "lease_expires_at": time.Time{}}).Error; err != nil {
+		return fmt.Errorf("ack raw job lease: %w", err)
+	}
+	return nil
+}
+
+// NackRawJobLease 记录一次处理失败：attempt_count 自增，未达 maxAttempts 时按 backoff 延后
+// lease_expires_at 以便重新被认领重试；达到 maxAttempts 时转入 dead_letter 终态并清空租约，
+// 返回值表示本次调用是否已转入死信。
+func (s *Store) NackRawJobLease(ctx context.Context, id uint, backoff time.Duration, maxAttempts int) (bool, error) {
+	var deadLettered bool
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var raw model.RawJob
+		if err := tx.First(&raw, id).Error; err != nil {
+			return fmt.Errorf("get raw job: %w", err)
+		}
 
-	for i := range jobs {
-		key := jobs[i].Source + "|" + jobs[i].ExternalID
-		if _, ok := existing[key]; !ok {
-			res.Created++
-			res.NewJobs = append(res.NewJobs, jobs[i])
-			existing[key] = struct{}{}
+		attempts := raw.AttemptCount + 1
+		values := map[string]any{"attempt_count": attempts}
+		if maxAttempts > 0 && attempts >= maxAttempts {
+			deadLettered = true
+			values["status"] = model.RawJobStatusDeadLetter
+			values["lease_owner"] = ""
+			values["lease_expires_at"] = time.Time{}
+		} else {
+			values["lease_expires_at"] = time.Now().Add(backoff)
 		}
+		return tx.Model(&model.RawJob{}).Where("id = ?", id).Updates(values).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("nack raw job lease: %w", err)
 	}
+	return deadLettered, nil
+}
 
-	tx := s.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "source"}, {Name: "external_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"title", "summary", "content", "url", "tags", "raw_payload", "published_at", "updated_at"}),
-	}).Create(&jobs)
+// RequeueExpiredLeases 清空租约已过期但仍处于 pending 状态的 RawJob 的租约信息，供 reaper 周期性调用，
+// 避免 worker 崩溃后遗留的租约信息让 /api/workers、/api/queue/stats 的统计失真
+// （AcquireRawJobBatch 的 WHERE 条件本身已经会重新选中这些记录，这里只是让统计口径及时反映现状）。
+func (s *Store) RequeueExpiredLeases(ctx context.Context, now time.Time) (int, error) {
+	tx := s.db.WithContext(ctx).Model(&model.RawJob{}).
+		Where("status = ? AND lease_owner <> '' AND lease_expires_at < ?", model.RawJobStatusPending, now).
+		Updates(map[string]any{"lease_owner": "", "lease_expires_at": time.Time{}})
 	if tx.Error != nil {
-		return res, fmt.Errorf("upsert raw jobs: %w", tx.Error)
+		return 0, fmt.Errorf("requeue expired leases: %w", tx.Error)
 	}
+	return int(tx.RowsAffected), nil
+}
 
-	return res, nil
+// ListActiveLeases 按 worker 聚合当前未过期的租约持有情况，供 /api/workers 暴露。
+func (s *Store) ListActiveLeases(ctx context.Context, now time.Time) ([]WorkerLeaseSummary, error) {
+	var out []WorkerLeaseSummary
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).
+		Select("lease_owner as worker_id, count(*) as leased_jobs, min(lease_expires_at) as oldest_lease").
+		Where("lease_owner <> '' AND lease_expires_at >= ?", now).
+		Group("lease_owner").
+		Scan(&out).Error; err != nil {
+		return nil, fmt.Errorf("list active leases: %w", err)
+	}
+	return out, nil
 }
 
-// ListJobs 返回按发布时间倒序的职位列表。
-func (s *Store) ListJobs(ctx context.Context, opts JobQueryOptions) ([]model.Job, error) {
-	var jobs []model.Job
-	if opts.Offset < 0 {
-		opts.Offset = 0
+// QueueStats 汇总 RawJob 各状态及当前租约占用数量，供 /api/queue/stats 暴露。
+func (s *Store) QueueStats(ctx context.Context) (QueueStats, error) {
+	var stats QueueStats
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("status = ?", model.RawJobStatusPending).Count(&stats.Pending).Error; err != nil {
+		return QueueStats{}, fmt.Errorf("count pending raw jobs: %w", err)
+	}
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("lease_owner <> '' AND lease_expires_at >= ?", time.Now()).Count(&stats.Leased).Error; err != nil {
+		return QueueStats{}, fmt.Errorf("count leased raw jobs: %w", err)
+	}
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("status = ?", model.RawJobStatusProcessed).Count(&stats.Processed).Error; err != nil {
+		return QueueStats{}, fmt.Errorf("count processed raw jobs: %w", err)
+	}
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("status = ?", model.RawJobStatusRejected).Count(&stats.Rejected).Error; err != nil {
+		return QueueStats{}, fmt.Errorf("count rejected raw jobs: %w", err)
 	}
+	if err := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("status = ?", model.RawJobStatusDeadLetter).Count(&stats.DeadLetter).Error; err != nil {
+		return QueueStats{}, fmt.Errorf("count dead letter raw jobs: %w", err)
+	}
+	return stats, nil
+}
 
-	query := s.db.WithContext(ctx).Model(&model.Job{}).Order("published_at DESC")
-	query = applyJobFilters(query, opts)
-	if opts.Offset > 0 {
-		query = query.Offset(opts.Offset)
+// GetLLMCache 按 prompt 的 sha256 查找已缓存的 LLM 响应，不存在时 ok 为 false。
+func (s *Store) GetLLMCache(ctx context.Context, hash string) (string, bool, error) {
+	var entry model.LLMCacheEntry
+	err := s.db.WithContext(ctx).Where("hash = ?", hash).First(&entry).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", false, nil
 	}
-	if opts.Limit > 0 {
-		query = query.Limit(opts.Limit)
+	if err != nil {
+		return "", false, fmt.Errorf("get llm cache: %w", err)
 	}
+	return entry.Response, true, nil
+}
 
-	if err := query.Find(&jobs).Error; err != nil {
-		return nil, fmt.Errorf("list jobs: %w", err)
+// SaveLLMCache 写入或覆盖一条 prompt 的缓存响应。
+func (s *Store) SaveLLMCache(ctx context.Context, hash, prompt, response string) error {
+	entry := model.LLMCacheEntry{Hash: hash, Prompt: prompt, Response: response, CreatedAt: time.Now()}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"prompt", "response", "created_at"}),
+	}).Create(&entry).Error; err != nil {
+		return fmt.Errorf("save llm cache: %w", err)
 	}
-	return jobs, nil
+	return nil
 }
 
-// CountJobs 返回满足过滤条件的职位数量。
-func (s *Store) CountJobs(ctx context.Context, opts JobQueryOptions) (int64, error) {
-	var total int64
-	query := applyJobFilters(s.db.WithContext(ctx).Model(&model.Job{}), opts)
-	if err := query.Count(&total).Error; err != nil {
-		return 0, fmt.Errorf("count jobs: %w", err)
+// RecordLLMUsage 持久化一次 LLM 调用的 token 用量与预估花费。
+func (s *Store) RecordLLMUsage(ctx context.Context, record model.LLMUsageRecord) error {
+	record.CreatedAt = time.Now()
+	if err := s.db.WithContext(ctx).Create(&record).Error; err != nil {
+		return fmt.Errorf("record llm usage: %w", err)
 	}
-	return total, nil
+	return nil
 }
 
-// ListRawJobs 返回指定状态的原始数据，默认 pending，按创建时间升序。
-func (s *Store) ListRawJobs(ctx context.Context, query RawJobQuery) ([]model.RawJob, error) {
-	var raws []model.RawJob
-	status := query.Status
-	if status == "" {
-		status = model.RawJobStatusPending
+// DailyLLMSpend 汇总自 since 起至今累计的预估花费（USD），供 processor 的每日花费上限判断。
+func (s *Store) DailyLLMSpend(ctx context.Context, since time.Time) (float64, error) {
+	var total sql.NullFloat64
+	if err := s.db.WithContext(ctx).Model(&model.LLMUsageRecord{}).
+		Where("created_at >= ?", since).
+		Select("sum(cost_usd)").
+		Scan(&total).Error; err != nil {
+		return 0, fmt.Errorf("sum daily llm spend: %w", err)
 	}
-	limit := query.Limit
-	if limit <= 0 {
-		limit = 50
+	return total.Float64, nil
+}
+
+// LLMUsageSummary 聚合指定时间起的 LLM 用量，供 /api/llm/usage 暴露。
+type LLMUsageSummary struct {
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	Calls            int64   `json:"calls"`
+}
+
+// LLMUsageSince 汇总自 since 起的 LLM 调用次数、token 用量与预估花费。
+func (s *Store) LLMUsageSince(ctx context.Context, since time.Time) (LLMUsageSummary, error) {
+	var out LLMUsageSummary
+	if err := s.db.WithContext(ctx).Model(&model.LLMUsageRecord{}).
+		Where("created_at >= ?", since).
+		Select("count(*) as calls, coalesce(sum(prompt_tokens),0) as prompt_tokens, coalesce(sum(completion_tokens),0) as completion_tokens, coalesce(sum(cost_usd),0) as cost_usd").
+		Scan(&out).Error; err != nil {
+		return LLMUsageSummary{}, fmt.Errorf("sum llm usage: %w", err)
 	}
+	return out, nil
+}
+
+// CreateSubscription 新增订阅。
+func (s *Store) CreateSubscription(ctx context.Context, sub *model.Subscription) error {
+	return s.subs.CreateSubscription(ctx, sub)
+}
+
+// RecordFilterViolation 记录一次敏感词命中并返回该邮箱的累计命中次数。
+func (s *Store) RecordFilterViolation(ctx context.Context, email, keyword string) (int64, error) {
+	return s.subs.RecordFilterViolation(ctx, email, keyword)
+}
+
+// FreezeSubscriptionsByEmail 将该邮箱下所有订阅置为 frozen 状态。
+func (s *Store) FreezeSubscriptionsByEmail(ctx context.Context, email string) error {
+	return s.subs.FreezeSubscriptionsByEmail(ctx, email)
+}
+
+// UnfreezeSubscription 将指定订阅恢复为 active 状态，供管理员手动解冻使用。
+func (s *Store) UnfreezeSubscription(ctx context.Context, id uint) error {
+	return s.subs.UnfreezeSubscription(ctx, id)
+}
+
+// GetSubscription 根据 ID 获取订阅。
+func (s *Store) GetSubscription(ctx context.Context, id uint) (model.Subscription, error) {
+	return s.subs.GetSubscription(ctx, id)
+}
+
+// GetSubscriptionByFeedToken 根据个人化 Atom feed 的访问 token 获取订阅，供 /feeds/{token}.xml 使用。
+func (s *Store) GetSubscriptionByFeedToken(ctx context.Context, token string) (model.Subscription, error) {
+	return s.subs.GetSubscriptionByFeedToken(ctx, token)
+}
+
+// ListSubscriptions 返回所有订阅记录。
+func (s *Store) ListSubscriptions(ctx context.Context) ([]model.Subscription, error) {
+	return s.subs.ListSubscriptions(ctx)
+}
+
+// EnqueueOutbox 写入一条待重试的通知投递记录。
+func (s *Store) EnqueueOutbox(ctx context.Context, entry model.NotificationOutbox) error {
+	if entry.Status == "" {
+		entry.Status = model.NotificationOutboxStatusPending
+	}
+	if err := s.db.WithContext(ctx).Create(&entry).Error; err != nil {
+		return fmt.Errorf("enqueue outbox: %w", err)
+	}
+	return nil
+}
+
+// DueOutboxEntries 返回所有待重试且已到期的投递记录，按下次重试时间升序排列。
+func (s *Store) DueOutboxEntries(ctx context.Context, before time.Time) ([]model.NotificationOutbox, error) {
+	var entries []model.NotificationOutbox
 	if err := s.db.WithContext(ctx).
-		Where("status = ?", status).
+		Where("status = ? AND next_attempt_at <= ?", model.NotificationOutboxStatusPending, before).
+		Order("next_attempt_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("list due outbox entries: %w", err)
+	}
+	return entries, nil
+}
+
+// MarkOutboxDelivered 标记投递记录已成功送达。
+func (s *Store) MarkOutboxDelivered(ctx context.Context, id uint) error {
+	if err := s.db.WithContext(ctx).Model(&model.NotificationOutbox{}).Where("id = ?", id).
+		Update("status", model.NotificationOutboxStatusDelivered).Error; err != nil {
+		return fmt.Errorf("mark outbox delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxFailed 记录一次失败重试，更新尝试次数、下次重试时间与最近一次错误信息。
+func (s *Store) MarkOutboxFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	if err := s.db.WithContext(ctx).Model(&model.NotificationOutbox{}).Where("id = ?", id).
+		Updates(map[string]any{"attempts": attempts, "next_attempt_at": nextAttemptAt, "last_error": lastErr}).Error; err != nil {
+		return fmt.Errorf("mark outbox failed: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxAbandoned 标记投递记录已达最大重试次数，不再重试。
+func (s *Store) MarkOutboxAbandoned(ctx context.Context, id uint, lastErr string) error {
+	if err := s.db.WithContext(ctx).Model(&model.NotificationOutbox{}).Where("id = ?", id).
+		Updates(map[string]any{"status": model.NotificationOutboxStatusAbandoned, "last_error": lastErr}).Error; err != nil {
+		return fmt.Errorf("mark outbox abandoned: %w", err)
+	}
+	return nil
+}
+
+// EnqueuePendingNotification 写入一条待重试的顶层通知批次，默认状态为 pending。
+func (s *Store) EnqueuePendingNotification(ctx context.Context, entry model.PendingNotification) error {
+	if entry.Status == "" {
+		entry.Status = model.PendingNotificationStatusPending
+	}
+	if err := s.db.WithContext(ctx).Create(&entry).Error; err != nil {
+		return fmt.Errorf("enqueue pending notification: %w", err)
+	}
+	return nil
+}
+
+// ListPendingNotifications 返回所有待重试的顶层通知批次，按创建时间升序排列。
+func (s *Store) ListPendingNotifications(ctx context.Context) ([]model.PendingNotification, error) {
+	var entries []model.PendingNotification
+	if err := s.db.WithContext(ctx).
+		Where("status = ?", model.PendingNotificationStatusPending).
 		Order("created_at ASC").
-		Limit(limit).
-		Find(&raws).Error; err != nil {
-		return nil, fmt.Errorf("list raw jobs: %w", err)
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("list pending notifications: %w", err)
 	}
-	return raws, nil
+	return entries, nil
 }
 
-// UpdateRawJobStatus 更新原始数据状态及 LLM 详情。
-func (s *Store) UpdateRawJobStatus(ctx context.Context, id uint, update RawJobStatusUpdate) error {
-	if update.Status == "" {
-		update.Status = model.RawJobStatusProcessed
+// MarkPendingNotificationDelivered 标记顶层通知批次已成功送达。
+func (s *Store) MarkPendingNotificationDelivered(ctx context.Context, id uint) error {
+	if err := s.db.WithContext(ctx).Model(&model.PendingNotification{}).Where("id = ?", id).
+		Update("status", model.PendingNotificationStatusDelivered).Error; err != nil {
+		return fmt.Errorf("mark pending notification delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkPendingNotificationFailed 记录一次重试失败，更新尝试次数与最近一次错误信息。
+func (s *Store) MarkPendingNotificationFailed(ctx context.Context, id uint, attempts int, lastErr string) error {
+	if err := s.db.WithContext(ctx).Model(&model.PendingNotification{}).Where("id = ?", id).
+		Updates(map[string]any{"attempts": attempts, "last_error": lastErr}).Error; err != nil {
+		return fmt.Errorf("mark pending notification failed: %w", err)
 	}
-	values := map[string]any{
-		"status": update.Status,
-		"reason": update.Reason,
+	return nil
+}
+
+// MarkPendingNotificationAbandoned 标记顶层通知批次已达最大重试次数，不再重试。
+func (s *Store) MarkPendingNotificationAbandoned(ctx context.Context, id uint, lastErr string) error {
+	if err := s.db.WithContext(ctx).Model(&model.PendingNotification{}).Where("id = ?", id).
+		Updates(map[string]any{"status": model.PendingNotificationStatusAbandoned, "last_error": lastErr}).Error; err != nil {
+		return fmt.Errorf("mark pending notification abandoned: %w", err)
 	}
-	if update.Details != nil {
-		values["llm_response"] = update.Details
+	return nil
+}
+
+// CreateJobRun 写入一条新的任务运行记录，默认状态为 pending。
+func (s *Store) CreateJobRun(ctx context.Context, run *model.JobRun) error {
+	if run.Status == "" {
+		run.Status = model.JobRunStatusPending
 	}
-	tx := s.db.WithContext(ctx).Model(&model.RawJob{}).Where("id = ?", id).Updates(values)
-	if tx.Error != nil {
-		return fmt.Errorf("update raw job status: %w", tx.Error)
+	if run.LastActivityAt.IsZero() {
+		run.LastActivityAt = time.Now()
 	}
-	if tx.RowsAffected == 0 {
-		return fmt.Errorf("update raw job status: id %d not found", id)
+	if err := s.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("create job run: %w", err)
 	}
 	return nil
 }
 
-// CreateSubscription 新增订阅。
-func (s *Store) CreateSubscription(ctx context.Context, sub *model.Subscription) error {
-	if err := s.db.WithContext(ctx).Create(sub).Error; err != nil {
-		return fmt.Errorf("create subscription: %w", err)
+// ClaimPendingJobRun 在事务中原子地认领一条待执行任务并标记为执行中，没有待执行任务时返回 nil。
+func (s *Store) ClaimPendingJobRun(ctx context.Context, types []string) (*model.JobRun, error) {
+	var claimed *model.JobRun
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var run model.JobRun
+		q := tx.Where("status = ?", model.JobRunStatusPending)
+		if len(types) > 0 {
+			q = q.Where("type IN ?", types)
+		}
+		if err := q.Order("created_at ASC").First(&run).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&run).Updates(map[string]any{
+			"status":           model.JobRunStatusInProgress,
+			"started_at":       now,
+			"last_activity_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		run.Status = model.JobRunStatusInProgress
+		run.StartedAt = &now
+		run.LastActivityAt = now
+		claimed = &run
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim pending job run: %w", err)
+	}
+	return claimed, nil
+}
+
+// UpdateJobRunProgress 更新任务进度并刷新活跃时间，供长耗时任务汇报心跳以避免被判定为卡死。
+func (s *Store) UpdateJobRunProgress(ctx context.Context, id uint, progress int) error {
+	if err := s.db.WithContext(ctx).Model(&model.JobRun{}).Where("id = ?", id).
+		Updates(map[string]any{"progress": progress, "last_activity_at": time.Now()}).Error; err != nil {
+		return fmt.Errorf("update job run progress: %w", err)
 	}
 	return nil
 }
 
-// ListSubscriptions 返回所有订阅记录。
-func (s *Store) ListSubscriptions(ctx context.Context) ([]model.Subscription, error) {
-	var subs []model.Subscription
-	if err := s.db.WithContext(ctx).Order("created_at ASC").Find(&subs).Error; err != nil {
-		return nil, fmt.Errorf("list subscriptions: %w", err)
+// FinishJobRun 将任务标记为终态，记录结束时间与错误信息（成功时 errMsg 为空）。
+func (s *Store) FinishJobRun(ctx context.Context, id uint, status model.JobRunStatus, errMsg string) error {
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(&model.JobRun{}).Where("id = ?", id).
+		Updates(map[string]any{"status": status, "finished_at": &now, "last_activity_at": now, "error": errMsg}).Error; err != nil {
+		return fmt.Errorf("finish job run: %w", err)
 	}
-	return subs, nil
+	return nil
 }
 
-// GetJob 根据 ID 获取职位。
-func (s *Store) GetJob(ctx context.Context, id string) (*model.Job, error) {
-	var job model.Job
-	if err := s.db.WithContext(ctx).First(&job, "id = ?", id).Error; err != nil {
+// RequeueJobRun 将卡死的任务重新置为 pending 以便被再次认领，并自增 retry_count 供
+// JobServer 判断是否已超过最大重试次数。
+func (s *Store) RequeueJobRun(ctx context.Context, id uint) error {
+	if err := s.db.WithContext(ctx).Model(&model.JobRun{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"status":           model.JobRunStatusPending,
+			"retry_count":      gorm.Expr("retry_count + 1"),
+			"started_at":       nil,
+			"last_activity_at": time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("requeue job run: %w", err)
+	}
+	return nil
+}
+
+// LatestJobRun 返回某类型任务最近一次创建的记录，不存在时返回 nil。
+func (s *Store) LatestJobRun(ctx context.Context, jobType string) (*model.JobRun, error) {
+	var run model.JobRun
+	if err := s.db.WithContext(ctx).Where("type = ?", jobType).Order("created_at DESC").First(&run).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("latest job run: %w", err)
+	}
+	return &run, nil
+}
+
+// StalledJobRuns 返回执行中但活跃时间早于 before 的任务记录，视为已卡死。
+func (s *Store) StalledJobRuns(ctx context.Context, before time.Time) ([]model.JobRun, error) {
+	var runs []model.JobRun
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND last_activity_at < ?", model.JobRunStatusInProgress, before).
+		Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("list stalled job runs: %w", err)
+	}
+	return runs, nil
+}
+
+// ListJobRuns 按类型与状态过滤任务运行记录，按创建时间倒序排列；jobType/status 为空时不过滤。
+func (s *Store) ListJobRuns(ctx context.Context, jobType, status string) ([]model.JobRun, error) {
+	q := s.db.WithContext(ctx).Order("created_at DESC")
+	if jobType != "" {
+		q = q.Where("type = ?", jobType)
+	}
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var runs []model.JobRun
+	if err := q.Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("list job runs: %w", err)
+	}
+	return runs, nil
+}
+
+// CancelJobRun 将处于 pending/in_progress 的任务标记为 canceled，已处于终态的任务无法取消。
+func (s *Store) CancelJobRun(ctx context.Context, id uint) error {
+	now := time.Now()
+	res := s.db.WithContext(ctx).Model(&model.JobRun{}).
+		Where("id = ? AND status IN ?", id, []model.JobRunStatus{model.JobRunStatusPending, model.JobRunStatusInProgress}).
+		Updates(map[string]any{"status": model.JobRunStatusCanceled, "finished_at": &now, "last_activity_at": now})
+	if res.Error != nil {
+		return fmt.Errorf("cancel job run: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("job run %d is not cancelable", id)
+	}
+	return nil
+}
+
+// RescheduleJobRun 将已处于终态（success/error/canceled）的任务重新置为 pending 以便立即再次执行。
+func (s *Store) RescheduleJobRun(ctx context.Context, id uint) error {
+	res := s.db.WithContext(ctx).Model(&model.JobRun{}).
+		Where("id = ? AND status IN ?", id, []model.JobRunStatus{model.JobRunStatusSuccess, model.JobRunStatusError, model.JobRunStatusCanceled}).
+		Updates(map[string]any{
+			"status":           model.JobRunStatusPending,
+			"error":            "",
+			"started_at":       nil,
+			"finished_at":      nil,
+			"last_activity_at": time.Now(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("reschedule job run: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("job run %d is not reschedulable", id)
+	}
+	return nil
+}
+
+// CreateScheduleRun 创建一条调度运行记录，StartedAt 留空时取当前时间。
+func (s *Store) CreateScheduleRun(ctx context.Context, run *model.ScheduleRun) error {
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now()
+	}
+	if run.Status == "" {
+		run.Status = model.ScheduleRunStatusRunning
+	}
+	if err := s.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("create schedule run: %w", err)
+	}
+	return nil
+}
+
+// UpdateScheduleRun 将调度运行标记为终态，写入统计字段、错误信息与结束时间。
+func (s *Store) UpdateScheduleRun(ctx context.Context, id uint, update ScheduleRunUpdate) error {
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(&model.ScheduleRun{}).Where("id = ?", id).Updates(map[string]any{
+		"status":         update.Status,
+		"fetched_count":  update.FetchedCount,
+		"accepted_count": update.AcceptedCount,
+		"rejected_count": update.RejectedCount,
+		"created_count":  update.CreatedCount,
+		"error":          update.Error,
+		"finished_at":    &now,
+	}).Error; err != nil {
+		return fmt.Errorf("update schedule run: %w", err)
+	}
+	return nil
+}
+
+// RecordScheduleRunOutcome 记录一次调度运行中单条 RawJob 的处理结果。
+func (s *Store) RecordScheduleRunOutcome(ctx context.Context, outcome model.ScheduleRunOutcome) error {
+	if err := s.db.WithContext(ctx).Create(&outcome).Error; err != nil {
+		return fmt.Errorf("record schedule run outcome: %w", err)
+	}
+	return nil
+}
+
+// ListScheduleRuns 按 JobName/Status 过滤调度运行历史，按创建时间倒序排列。
+func (s *Store) ListScheduleRuns(ctx context.Context, query ScheduleRunQuery) ([]model.ScheduleRun, error) {
+	db := s.db.WithContext(ctx).Model(&model.ScheduleRun{})
+	if query.JobName != "" {
+		db = db.Where("job_name = ?", query.JobName)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	var runs []model.ScheduleRun
+	if err := db.Order("started_at DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("list schedule runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetScheduleRun 根据 ID 获取调度运行记录。
+func (s *Store) GetScheduleRun(ctx context.Context, id uint) (*model.ScheduleRun, error) {
+	var run model.ScheduleRun
+	if err := s.db.WithContext(ctx).First(&run, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, sql.ErrNoRows
 		}
-		return nil, fmt.Errorf("get job: %w", err)
+		return nil, fmt.Errorf("get schedule run: %w", err)
 	}
-	return &job, nil
+	return &run, nil
 }
 
-func applyJobFilters(db *gorm.DB, opts JobQueryOptions) *gorm.DB {
-	if len(opts.Tags) == 0 {
-		return db
+// ListScheduleRunOutcomes 返回某次调度运行下所有 RawJob 的处理结果。
+func (s *Store) ListScheduleRunOutcomes(ctx context.Context, runID uint) ([]model.ScheduleRunOutcome, error) {
+	var outcomes []model.ScheduleRunOutcome
+	if err := s.db.WithContext(ctx).Where("schedule_run_id = ?", runID).Order("id ASC").Find(&outcomes).Error; err != nil {
+		return nil, fmt.Errorf("list schedule run outcomes: %w", err)
 	}
+	return outcomes, nil
+}
+
+// PruneScheduleRuns 删除 before 之前创建的调度运行记录及其关联的 RawJob 处理结果，供保留策略定期清理。
+func (s *Store) PruneScheduleRuns(ctx context.Context, before time.Time) (int64, error) {
+	var stale []model.ScheduleRun
+	if err := s.db.WithContext(ctx).Where("started_at < ?", before).Find(&stale).Error; err != nil {
+		return 0, fmt.Errorf("list stale schedule runs: %w", err)
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uint, 0, len(stale))
+	for _, run := range stale {
+		ids = append(ids, run.ID)
+	}
+
+	var deleted int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_run_id IN ?", ids).Delete(&model.ScheduleRunOutcome{}).Error; err != nil {
+			return err
+		}
+		res := tx.Where("id IN ?", ids).Delete(&model.ScheduleRun{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("prune schedule runs: %w", err)
+	}
+	return deleted, nil
+}
+
+// ListJobsSince 返回发布时间不早于 since 的职位，按发布时间升序排列，供摘要导出使用。
+func (s *Store) ListJobsSince(ctx context.Context, since time.Time) ([]model.Job, error) {
+	return s.jobs.ListJobsSince(ctx, since)
+}
+
+// GetJob 根据 ID 获取职位；命中只读查询缓存（启用时）则直接返回缓存结果。
+func (s *Store) GetJob(ctx context.Context, id string) (*model.Job, error) {
+	return s.jobs.GetJob(ctx, id)
+}
+
+// TouchJobsSeen 将本次抓取中仍然存在的职位标记为最近可见：更新 LastSeenAt，并把此前因缺席被标记
+// archived 的记录重新置回 open（清空 DisappearedAt、StaleWarnedAt），FirstSeenAt 保持不变。
+func (s *Store) TouchJobsSeen(ctx context.Context, ids []string, seenAt time.Time) error {
+	return s.jobs.TouchJobsSeen(ctx, ids, seenAt)
+}
+
+// ArchiveStaleJobs 将 LastSeenAt 早于 before 且仍为 open 的职位标记为 archived，供调度器在每次抓取后
+// 清理源站已下线的职位；返回本次归档数量。
+func (s *Store) ArchiveStaleJobs(ctx context.Context, before time.Time) (int64, error) {
+	return s.jobs.ArchiveStaleJobs(ctx, before)
+}
+
+// ListStaleOpenJobs 返回仍为 open、FirstSeenAt 早于 olderThan 且尚未发出过期预警（StaleWarnedAt 为空）
+// 的职位，供后台 sweeper 推送一次性“停留过久”提醒。
+func (s *Store) ListStaleOpenJobs(ctx context.Context, olderThan time.Duration) ([]model.Job, error) {
+	return s.jobs.ListStaleOpenJobs(ctx, olderThan)
+}
+
+// MarkStaleWarned 把指定职位的 StaleWarnedAt 置为当前时间，避免同一职位重复发出过期预警。
+func (s *Store) MarkStaleWarned(ctx context.Context, ids []string) error {
+	return s.jobs.MarkStaleWarned(ctx, ids)
+}
+
+func applyJobFilters(db *gorm.DB, opts JobQueryOptions, driver string, sqliteFTS5 bool) *gorm.DB {
 	for _, tag := range opts.Tags {
 		if tag == "" {
 			continue
 		}
-		path := fmt.Sprintf("$.\"%s\"", tag)
-		db = db.Where("json_extract(normalized_tags, ?) = 1", path)
+		db = db.Where(tagFilterClause(driver), tagFilterArg(driver, tag))
 	}
+	if opts.Status != "" {
+		db = db.Where("status = ?", opts.Status)
+	}
+	if opts.MaxAge > 0 {
+		db = db.Where("first_seen_at >= ?", time.Now().Add(-opts.MaxAge))
+	}
+	db = applySearchFilter(db, opts, driver, sqliteFTS5)
 	return db
 }
+
+// tagFilterClause 按 driver 返回 normalized_tags 按标签过滤的 SQL 片段，
+// 配合 tagFilterArg 使用：sqlite 用 json_extract，mysql 用 JSON_CONTAINS_PATH，
+// postgres 的 jsonb ? 操作符直接接受标签名作为参数，无需额外占位符包装。
+func tagFilterClause(driver string) string {
+	switch driver {
+	case DriverMySQL:
+		return "JSON_CONTAINS_PATH(normalized_tags, 'one', ?)"
+	case DriverPostgres:
+		// jsonb 的 ? 操作符与 GORM 的占位符语法冲突，需写成 ?? 转义。
+		return "normalized_tags ?? ?"
+	default:
+		return "json_extract(normalized_tags, ?) = 1"
+	}
+}
+
+// tagFilterArg 返回 tagFilterClause 占位符对应的参数：sqlite/mysql 需要 JSON path
+// 表达式，postgres 的 ? 操作符直接比较标签名本身。
+func tagFilterArg(driver, tag string) string {
+	if driver == DriverPostgres {
+		return tag
+	}
+	return fmt.Sprintf("$.\"%s\"", tag)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"path/filepath"
 	"testing"
 	"time"
@@ -222,6 +223,18 @@ func TestRawJobLifecycle(t *testing.T) {
 		t.Fatalf("expected pending job, got %+v", pending)
 	}
 
+	fetched, err := store.GetRawJob(ctx, pending[0].ID)
+	if err != nil {
+		t.Fatalf("GetRawJob error: %v", err)
+	}
+	if fetched.ExternalID != "raw-1" {
+		t.Fatalf("expected raw-1, got %+v", fetched)
+	}
+
+	if _, err := store.GetRawJob(ctx, pending[0].ID+1000); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows for missing raw job, got %v", err)
+	}
+
 	update := RawJobStatusUpdate{Status: model.RawJobStatusProcessed, Details: datatypes.JSONMap{"score": 5}}
 	if err := store.UpdateRawJobStatus(ctx, pending[0].ID, update); err != nil {
 		t.Fatalf("UpdateRawJobStatus error: %v", err)
@@ -263,3 +276,634 @@ func TestSubscriptionCreateAndList(t *testing.T) {
 		t.Fatalf("expected stored subscription returned, got %+v", subs)
 	}
 }
+
+func TestNotificationOutboxLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	entry := model.NotificationOutbox{
+		SubscriptionID: 1,
+		Channel:        "webhook",
+		Target:         "https://example.com/hook",
+		Payload:        datatypes.JSON(`[{"id":"1"}]`),
+		Attempts:       1,
+		LastError:      "connection refused",
+		NextAttemptAt:  time.Now().Add(-time.Minute),
+	}
+	if err := store.EnqueueOutbox(ctx, entry); err != nil {
+		t.Fatalf("EnqueueOutbox error: %v", err)
+	}
+
+	due, err := store.DueOutboxEntries(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("DueOutboxEntries error: %v", err)
+	}
+	if len(due) != 1 {
+		t.Fatalf("expected 1 due entry, got %d", len(due))
+	}
+	id := due[0].ID
+
+	if err := store.MarkOutboxFailed(ctx, id, 2, time.Now().Add(time.Minute), "still failing"); err != nil {
+		t.Fatalf("MarkOutboxFailed error: %v", err)
+	}
+	due, err = store.DueOutboxEntries(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("DueOutboxEntries error: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected no due entries after rescheduling into the future, got %d", len(due))
+	}
+
+	if err := store.MarkOutboxDelivered(ctx, id); err != nil {
+		t.Fatalf("MarkOutboxDelivered error: %v", err)
+	}
+	due, err = store.DueOutboxEntries(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("DueOutboxEntries error: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected delivered entry to be excluded, got %d", len(due))
+	}
+}
+
+func TestJobRunLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	run := &model.JobRun{Type: "fetch"}
+	if err := store.CreateJobRun(ctx, run); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+	if run.ID == 0 {
+		t.Fatalf("expected job run ID assigned")
+	}
+
+	claimed, err := store.ClaimPendingJobRun(ctx, []string{"fetch"})
+	if err != nil {
+		t.Fatalf("ClaimPendingJobRun error: %v", err)
+	}
+	if claimed == nil || claimed.ID != run.ID {
+		t.Fatalf("expected pending run claimed, got %+v", claimed)
+	}
+	if claimed.Status != model.JobRunStatusInProgress {
+		t.Fatalf("expected claimed run marked in_progress, got %s", claimed.Status)
+	}
+
+	again, err := store.ClaimPendingJobRun(ctx, []string{"fetch"})
+	if err != nil {
+		t.Fatalf("second ClaimPendingJobRun error: %v", err)
+	}
+	if again != nil {
+		t.Fatalf("expected no pending runs left, got %+v", again)
+	}
+
+	if err := store.FinishJobRun(ctx, run.ID, model.JobRunStatusSuccess, ""); err != nil {
+		t.Fatalf("FinishJobRun error: %v", err)
+	}
+
+	latest, err := store.LatestJobRun(ctx, "fetch")
+	if err != nil {
+		t.Fatalf("LatestJobRun error: %v", err)
+	}
+	if latest == nil || latest.Status != model.JobRunStatusSuccess {
+		t.Fatalf("expected latest run marked success, got %+v", latest)
+	}
+}
+
+func TestStalledJobRunsReturnsInProgressPastThreshold(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	run := &model.JobRun{Type: "notify", Status: model.JobRunStatusInProgress, LastActivityAt: time.Now().Add(-time.Hour)}
+	if err := store.CreateJobRun(ctx, run); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+
+	stalled, err := store.StalledJobRuns(ctx, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("StalledJobRuns error: %v", err)
+	}
+	if len(stalled) != 1 || stalled[0].ID != run.ID {
+		t.Fatalf("expected stalled run returned, got %+v", stalled)
+	}
+}
+
+func TestRequeueJobRunResetsToPendingAndIncrementsRetryCount(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	run := &model.JobRun{Type: "fetch", Status: model.JobRunStatusInProgress, LastActivityAt: time.Now().Add(-time.Hour)}
+	if err := store.CreateJobRun(ctx, run); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+
+	if err := store.RequeueJobRun(ctx, run.ID); err != nil {
+		t.Fatalf("RequeueJobRun error: %v", err)
+	}
+
+	latest, err := store.LatestJobRun(ctx, "fetch")
+	if err != nil {
+		t.Fatalf("LatestJobRun error: %v", err)
+	}
+	if latest == nil || latest.Status != model.JobRunStatusPending {
+		t.Fatalf("expected run reset to pending, got %+v", latest)
+	}
+	if latest.RetryCount != 1 {
+		t.Fatalf("expected retry count incremented to 1, got %d", latest.RetryCount)
+	}
+}
+
+func TestListJobRunsFiltersByTypeAndStatus(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	fetchRun := &model.JobRun{Type: "fetch", Status: model.JobRunStatusSuccess}
+	notifyRun := &model.JobRun{Type: "notify", Status: model.JobRunStatusError}
+	if err := store.CreateJobRun(ctx, fetchRun); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+	if err := store.CreateJobRun(ctx, notifyRun); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+
+	byType, err := store.ListJobRuns(ctx, "fetch", "")
+	if err != nil {
+		t.Fatalf("ListJobRuns error: %v", err)
+	}
+	if len(byType) != 1 || byType[0].ID != fetchRun.ID {
+		t.Fatalf("expected only fetch run returned, got %+v", byType)
+	}
+
+	byStatus, err := store.ListJobRuns(ctx, "", string(model.JobRunStatusError))
+	if err != nil {
+		t.Fatalf("ListJobRuns error: %v", err)
+	}
+	if len(byStatus) != 1 || byStatus[0].ID != notifyRun.ID {
+		t.Fatalf("expected only error-status run returned, got %+v", byStatus)
+	}
+}
+
+func TestCancelJobRunMarksPendingRunCanceled(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	run := &model.JobRun{Type: "fetch"}
+	if err := store.CreateJobRun(ctx, run); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+
+	if err := store.CancelJobRun(ctx, run.ID); err != nil {
+		t.Fatalf("CancelJobRun error: %v", err)
+	}
+
+	latest, err := store.LatestJobRun(ctx, "fetch")
+	if err != nil {
+		t.Fatalf("LatestJobRun error: %v", err)
+	}
+	if latest == nil || latest.Status != model.JobRunStatusCanceled {
+		t.Fatalf("expected run canceled, got %+v", latest)
+	}
+
+	if err := store.CancelJobRun(ctx, run.ID); err == nil {
+		t.Fatalf("expected error canceling an already-terminal run")
+	}
+}
+
+func TestRescheduleJobRunResetsTerminalRunToPending(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	run := &model.JobRun{Type: "fetch", Status: model.JobRunStatusError}
+	if err := store.CreateJobRun(ctx, run); err != nil {
+		t.Fatalf("CreateJobRun error: %v", err)
+	}
+
+	if err := store.RescheduleJobRun(ctx, run.ID); err != nil {
+		t.Fatalf("RescheduleJobRun error: %v", err)
+	}
+
+	latest, err := store.LatestJobRun(ctx, "fetch")
+	if err != nil {
+		t.Fatalf("LatestJobRun error: %v", err)
+	}
+	if latest == nil || latest.Status != model.JobRunStatusPending {
+		t.Fatalf("expected run reset to pending, got %+v", latest)
+	}
+
+	if err := store.RescheduleJobRun(ctx, run.ID); err == nil {
+		t.Fatalf("expected error rescheduling a non-terminal (pending) run")
+	}
+}
+
+func TestScheduleRunLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	run := &model.ScheduleRun{JobName: "eleduck", TriggeredBy: model.ScheduleRunTriggerCron}
+	if err := store.CreateScheduleRun(ctx, run); err != nil {
+		t.Fatalf("CreateScheduleRun error: %v", err)
+	}
+	if run.ID == 0 {
+		t.Fatalf("expected schedule run ID assigned")
+	}
+
+	if err := store.RecordScheduleRunOutcome(ctx, model.ScheduleRunOutcome{ScheduleRunID: run.ID, RawJobID: 1, Status: model.RawJobStatusProcessed}); err != nil {
+		t.Fatalf("RecordScheduleRunOutcome error: %v", err)
+	}
+	if err := store.RecordScheduleRunOutcome(ctx, model.ScheduleRunOutcome{ScheduleRunID: run.ID, RawJobID: 2, Status: model.RawJobStatusRejected, Reason: "low score"}); err != nil {
+		t.Fatalf("RecordScheduleRunOutcome error: %v", err)
+	}
+
+	if err := store.UpdateScheduleRun(ctx, run.ID, ScheduleRunUpdate{
+		Status:        model.ScheduleRunStatusSuccess,
+		FetchedCount:  2,
+		AcceptedCount: 1,
+		RejectedCount: 1,
+		CreatedCount:  1,
+	}); err != nil {
+		t.Fatalf("UpdateScheduleRun error: %v", err)
+	}
+
+	fetched, err := store.GetScheduleRun(ctx, run.ID)
+	if err != nil {
+		t.Fatalf("GetScheduleRun error: %v", err)
+	}
+	if fetched.Status != model.ScheduleRunStatusSuccess || fetched.CreatedCount != 1 || fetched.FinishedAt == nil {
+		t.Fatalf("unexpected schedule run after update: %+v", fetched)
+	}
+
+	outcomes, err := store.ListScheduleRunOutcomes(ctx, run.ID)
+	if err != nil {
+		t.Fatalf("ListScheduleRunOutcomes error: %v", err)
+	}
+	if len(outcomes) != 2 {
+		t.Fatalf("expected 2 outcomes, got %d", len(outcomes))
+	}
+
+	runs, err := store.ListScheduleRuns(ctx, ScheduleRunQuery{JobName: "eleduck"})
+	if err != nil {
+		t.Fatalf("ListScheduleRuns error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != run.ID {
+		t.Fatalf("unexpected schedule runs: %+v", runs)
+	}
+
+	if _, err := store.GetScheduleRun(ctx, run.ID+999); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows for missing run, got %v", err)
+	}
+}
+
+func TestPruneScheduleRuns(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	old := &model.ScheduleRun{JobName: "eleduck", StartedAt: time.Now().Add(-48 * time.Hour)}
+	if err := store.CreateScheduleRun(ctx, old); err != nil {
+		t.Fatalf("CreateScheduleRun error: %v", err)
+	}
+	if err := store.RecordScheduleRunOutcome(ctx, model.ScheduleRunOutcome{ScheduleRunID: old.ID, RawJobID: 1}); err != nil {
+		t.Fatalf("RecordScheduleRunOutcome error: %v", err)
+	}
+	recent := &model.ScheduleRun{JobName: "eleduck"}
+	if err := store.CreateScheduleRun(ctx, recent); err != nil {
+		t.Fatalf("CreateScheduleRun error: %v", err)
+	}
+
+	deleted, err := store.PruneScheduleRuns(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("PruneScheduleRuns error: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 pruned run, got %d", deleted)
+	}
+
+	remaining, err := store.ListScheduleRuns(ctx, ScheduleRunQuery{})
+	if err != nil {
+		t.Fatalf("ListScheduleRuns error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != recent.ID {
+		t.Fatalf("expected only recent run remaining, got %+v", remaining)
+	}
+
+	outcomes, err := store.ListScheduleRunOutcomes(ctx, old.ID)
+	if err != nil {
+		t.Fatalf("ListScheduleRunOutcomes error: %v", err)
+	}
+	if len(outcomes) != 0 {
+		t.Fatalf("expected outcomes for pruned run removed, got %+v", outcomes)
+	}
+}
+
+func TestPendingNotificationLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	entry := model.PendingNotification{
+		JobIDs:    datatypes.JSON(`["1","2"]`),
+		Payload:   datatypes.JSON(`[{"id":"1"},{"id":"2"}]`),
+		Attempts:  1,
+		LastError: "smtp timeout",
+	}
+	if err := store.EnqueuePendingNotification(ctx, entry); err != nil {
+		t.Fatalf("EnqueuePendingNotification error: %v", err)
+	}
+
+	pending, err := store.ListPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("ListPendingNotifications error: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending notification, got %d", len(pending))
+	}
+	id := pending[0].ID
+
+	if err := store.MarkPendingNotificationFailed(ctx, id, 2, "still failing"); err != nil {
+		t.Fatalf("MarkPendingNotificationFailed error: %v", err)
+	}
+	pending, err = store.ListPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("ListPendingNotifications error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Attempts != 2 {
+		t.Fatalf("expected attempts updated to 2, got %+v", pending)
+	}
+
+	if err := store.MarkPendingNotificationDelivered(ctx, id); err != nil {
+		t.Fatalf("MarkPendingNotificationDelivered error: %v", err)
+	}
+	pending, err = store.ListPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("ListPendingNotifications error: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected delivered entry excluded, got %d", len(pending))
+	}
+}
+
+func TestRawJobLeaseLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	if _, err := store.UpsertRawJobs(ctx, []model.RawJob{
+		{Source: "eleduck", ExternalID: "lease-1", Title: "Lease 1", PublishedAt: time.Now()},
+		{Source: "eleduck", ExternalID: "lease-2", Title: "Lease 2", PublishedAt: time.Now()},
+	}); err != nil {
+		t.Fatalf("UpsertRawJobs error: %v", err)
+	}
+
+	batch, err := store.AcquireRawJobBatch(ctx, "worker-a", 10, time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireRawJobBatch error: %v", err)
+	}
+	if len(batch) != 2 {
+		t.Fatalf("expected 2 leased jobs, got %d", len(batch))
+	}
+	for _, raw := range batch {
+		if raw.LeaseOwner != "worker-a" {
+			t.Fatalf("expected lease owner worker-a, got %+v", raw)
+		}
+	}
+
+	if again, err := store.AcquireRawJobBatch(ctx, "worker-b", 10, time.Minute); err != nil {
+		t.Fatalf("AcquireRawJobBatch (second worker) error: %v", err)
+	} else if len(again) != 0 {
+		t.Fatalf("expected no jobs available while lease is held, got %d", len(again))
+	}
+
+	if err := store.HeartbeatRawJobs(ctx, "worker-a", []uint{batch[0].ID}, time.Minute); err != nil {
+		t.Fatalf("HeartbeatRawJobs error: %v", err)
+	}
+
+	if err := store.AckRawJobLease(ctx, batch[0].ID); err != nil {
+		t.Fatalf("AckRawJobLease error: %v", err)
+	}
+
+	deadLettered, err := store.NackRawJobLease(ctx, batch[1].ID, time.Minute, 1)
+	if err != nil {
+		t.Fatalf("NackRawJobLease error: %v", err)
+	}
+	if !deadLettered {
+		t.Fatalf("expected job to be dead-lettered after exceeding max attempts")
+	}
+
+	stats, err := store.QueueStats(ctx)
+	if err != nil {
+		t.Fatalf("QueueStats error: %v", err)
+	}
+	if stats.DeadLetter != 1 {
+		t.Fatalf("expected 1 dead-lettered job, got %+v", stats)
+	}
+
+	requeued, err := store.RequeueExpiredLeases(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("RequeueExpiredLeases error: %v", err)
+	}
+	if requeued != 0 {
+		t.Fatalf("expected no pending leases to requeue yet, got %d", requeued)
+	}
+}
+
+func TestNewStoreDefaultsToSQLiteDriver(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStore(filepath.Join(tmp, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	if store.Driver() != DriverSQLite {
+		t.Fatalf("expected driver %q, got %q", DriverSQLite, store.Driver())
+	}
+}
+
+func TestNormalizeDriver(t *testing.T) {
+	cases := map[string]string{
+		"":          DriverSQLite,
+		"sqlite":    DriverSQLite,
+		"SQLite":    DriverSQLite,
+		"postgres":  DriverPostgres,
+		"Postgres ": DriverPostgres,
+		"mysql":     DriverMySQL,
+		"unknown":   DriverSQLite,
+	}
+	for input, want := range cases {
+		if got := normalizeDriver(input); got != want {
+			t.Fatalf("normalizeDriver(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestQueryCacheServesRepeatedListAndCountFromMemory(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStoreWithConfig(Config{Driver: DriverSQLite, DSN: filepath.Join(tmp, "jobs.db"), Cache: QueryCacheConfig{Enabled: true}})
+	if err != nil {
+		t.Fatalf("NewStoreWithConfig error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	if _, err := store.UpsertJobs(ctx, []model.Job{
+		{ID: "cache-1", Title: "Backend", PublishedAt: time.Now(), Source: "eleduck"},
+	}); err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+
+	opts := JobQueryOptions{}
+	if _, err := store.ListJobs(ctx, opts); err != nil {
+		t.Fatalf("ListJobs error: %v", err)
+	}
+	if _, err := store.ListJobs(ctx, opts); err != nil {
+		t.Fatalf("ListJobs (cached) error: %v", err)
+	}
+	if _, err := store.CountJobs(ctx, opts); err != nil {
+		t.Fatalf("CountJobs error: %v", err)
+	}
+	if _, err := store.GetJob(ctx, "cache-1"); err != nil {
+		t.Fatalf("GetJob error: %v", err)
+	}
+	if _, err := store.GetJob(ctx, "cache-1"); err != nil {
+		t.Fatalf("GetJob (cached) error: %v", err)
+	}
+
+	stats := store.CacheStats()
+	if stats.Hits != 2 {
+		t.Fatalf("expected 2 cache hits, got %+v", stats)
+	}
+	if stats.Misses != 3 {
+		t.Fatalf("expected 3 cache misses, got %+v", stats)
+	}
+}
+
+func TestQueryCacheInvalidatedByUpsertJobs(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	store, err := NewStoreWithConfig(Config{Driver: DriverSQLite, DSN: filepath.Join(tmp, "jobs.db"), Cache: QueryCacheConfig{Enabled: true}})
+	if err != nil {
+		t.Fatalf("NewStoreWithConfig error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	total, err := store.CountJobs(ctx, JobQueryOptions{})
+	if err != nil {
+		t.Fatalf("CountJobs error: %v", err)
+	}
+	if total != 0 {
+		t.Fatalf("expected 0 jobs initially, got %d", total)
+	}
+
+	if _, err := store.UpsertJobs(ctx, []model.Job{
+		{ID: "cache-2", Title: "Backend", PublishedAt: time.Now(), Source: "eleduck"},
+	}); err != nil {
+		t.Fatalf("UpsertJobs error: %v", err)
+	}
+
+	total, err = store.CountJobs(ctx, JobQueryOptions{})
+	if err != nil {
+		t.Fatalf("CountJobs error: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected cache to reflect newly upserted job, got %d", total)
+	}
+}
+
+func TestTagFilterClauseAndArgByDriver(t *testing.T) {
+	if clause := tagFilterClause(DriverSQLite); clause != "json_extract(normalized_tags, ?) = 1" {
+		t.Fatalf("unexpected sqlite clause: %q", clause)
+	}
+	if clause := tagFilterClause(DriverMySQL); clause != "JSON_CONTAINS_PATH(normalized_tags, 'one', ?)" {
+		t.Fatalf("unexpected mysql clause: %q", clause)
+	}
+	if clause := tagFilterClause(DriverPostgres); clause != "normalized_tags ?? ?" {
+		t.Fatalf("unexpected postgres clause: %q", clause)
+	}
+
+	if arg := tagFilterArg(DriverSQLite, "backend"); arg != `$."backend"` {
+		t.Fatalf("unexpected sqlite arg: %q", arg)
+	}
+	if arg := tagFilterArg(DriverPostgres, "backend"); arg != "backend" {
+		t.Fatalf("unexpected postgres arg: %q", arg)
+	}
+}
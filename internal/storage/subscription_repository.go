@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// SubscriptionRepository 封装 Subscription 聚合（含配套的敏感词命中记录 FilterViolation）的增删查，
+// 与 JobRepository/RawJobRepository 一起由 Storage 组合、共享同一个 *gorm.DB（见 storage.go）。
+// Store 仍然保留 CreateSubscription 等同名方法作为过渡期的兼容 shim，内部直接转发到这里。
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// WithTx 在一个事务内执行 fn，fn 接收的 tx 绑定到该事务。跨 Job/RawJob/Subscription 聚合的原子操作
+// 请使用 Storage.WithTx。
+func (r *SubscriptionRepository) WithTx(ctx context.Context, fn func(tx *SubscriptionRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&SubscriptionRepository{db: tx})
+	})
+}
+
+// CreateSubscription 创建一条订阅记录。
+func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, sub *model.Subscription) error {
+	if err := r.db.WithContext(ctx).Create(sub).Error; err != nil {
+		return fmt.Errorf("create subscription: %w", err)
+	}
+	return nil
+}
+
+// RecordFilterViolation 记录一次敏感词命中并返回该邮箱的累计命中次数。
+func (r *SubscriptionRepository) RecordFilterViolation(ctx context.Context, email, keyword string) (int64, error) {
+	violation := model.FilterViolation{Email: email, Keyword: keyword}
+	if err := r.db.WithContext(ctx).Create(&violation).Error; err != nil {
+		return 0, fmt.Errorf("create filter violation: %w", err)
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.FilterViolation{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count filter violations: %w", err)
+	}
+	return count, nil
+}
+
+// FreezeSubscriptionsByEmail 将该邮箱下所有订阅置为 frozen 状态。
+func (r *SubscriptionRepository) FreezeSubscriptionsByEmail(ctx context.Context, email string) error {
+	if err := r.db.WithContext(ctx).Model(&model.Subscription{}).
+		Where("email = ?", email).
+		Update("status", model.SubscriptionStatusFrozen).Error; err != nil {
+		return fmt.Errorf("freeze subscriptions: %w", err)
+	}
+	return nil
+}
+
+// UnfreezeSubscription 将指定订阅恢复为 active 状态，供管理员手动解冻使用。
+func (r *SubscriptionRepository) UnfreezeSubscription(ctx context.Context, id uint) error {
+	tx := r.db.WithContext(ctx).Model(&model.Subscription{}).
+		Where("id = ?", id).
+		Update("status", model.SubscriptionStatusActive)
+	if tx.Error != nil {
+		return fmt.Errorf("unfreeze subscription: %w", tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("unfreeze subscription: id %d not found", id)
+	}
+	return nil
+}
+
+// GetSubscription 根据 ID 获取订阅。
+func (r *SubscriptionRepository) GetSubscription(ctx context.Context, id uint) (model.Subscription, error) {
+	var sub model.Subscription
+	if err := r.db.WithContext(ctx).First(&sub, "id = ?", id).Error; err != nil {
+		return model.Subscription{}, fmt.Errorf("get subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// GetSubscriptionByFeedToken 根据个人化 Atom feed 的访问 token 获取订阅，供 /feeds/{token}.xml 使用。
+func (r *SubscriptionRepository) GetSubscriptionByFeedToken(ctx context.Context, token string) (model.Subscription, error) {
+	var sub model.Subscription
+	if err := r.db.WithContext(ctx).First(&sub, "feed_token = ?", token).Error; err != nil {
+		return model.Subscription{}, fmt.Errorf("get subscription by feed token: %w", err)
+	}
+	return sub, nil
+}
+
+// ListSubscriptions 返回所有订阅记录。
+func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context) ([]model.Subscription, error) {
+	var subs []model.Subscription
+	if err := r.db.WithContext(ctx).Order("created_at ASC").Find(&subs).Error; err != nil {
+		return nil, fmt.Errorf("list subscriptions: %w", err)
+	}
+	return subs, nil
+}
@@ -0,0 +1,50 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"remote-radar/internal/model"
+	"remote-radar/internal/notifier"
+	"remote-radar/internal/notifier/dispatch"
+)
+
+// FeedWindow 控制个人化 Atom feed 回溯展示职位的时间窗口。
+const FeedWindow = 14 * 24 * time.Hour
+
+// FeedStore 定义个人化 Atom feed 所需的只读存储接口。
+type FeedStore interface {
+	GetSubscriptionByFeedToken(ctx context.Context, token string) (model.Subscription, error)
+	ListJobsSince(ctx context.Context, since time.Time) ([]model.Job, error)
+}
+
+// FeedService 按订阅的 FeedToken 渲染个人化 Atom feed，只包含该订阅 Tags/FilterExpr 匹配到的职位，
+// 供不方便接收推送的用户通过 RSS 阅读器订阅 /feeds/{token}.xml。
+type FeedService struct {
+	store FeedStore
+	now   func() time.Time
+}
+
+// NewFeedService 创建 FeedService。
+func NewFeedService(store FeedStore) *FeedService {
+	return &FeedService{store: store, now: time.Now}
+}
+
+// Render 返回 token 对应订阅的 Atom feed 文档；token 不存在时返回 error。
+func (s *FeedService) Render(ctx context.Context, token string) ([]byte, error) {
+	sub, err := s.store.GetSubscriptionByFeedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.store.ListJobsSince(ctx, s.now().Add(-FeedWindow))
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+	matches := dispatch.FilterJobsBySubscription(sub, jobs)
+
+	title := fmt.Sprintf("Remote Radar · %s", sub.Channel)
+	id := fmt.Sprintf("urn:remote-radar:subscription:%d", sub.ID)
+	return notifier.RenderAtomFeed(title, id, matches, s.now())
+}
@@ -0,0 +1,66 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"remote-radar/internal/model"
+
+	"gorm.io/datatypes"
+)
+
+func TestFeedServiceRendersMatchingJobsOnly(t *testing.T) {
+	t.Parallel()
+
+	store := &stubFeedStore{
+		sub: model.Subscription{ID: 7, Channel: "webhook", Tags: datatypes.JSONMap{"backend": true}},
+		jobs: []model.Job{
+			{ID: "1", Title: "Backend Engineer", URL: "https://example.com/1", NormalizedTags: datatypes.JSONMap{"backend": true}},
+			{ID: "2", Title: "Frontend Engineer", URL: "https://example.com/2", NormalizedTags: datatypes.JSONMap{"frontend": true}},
+		},
+	}
+	svc := NewFeedService(store)
+
+	data, err := svc.Render(context.Background(), "token-123")
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "Backend Engineer") {
+		t.Fatalf("expected matching job in feed, got %s", content)
+	}
+	if strings.Contains(content, "Frontend Engineer") {
+		t.Fatalf("expected non-matching job to be excluded, got %s", content)
+	}
+}
+
+func TestFeedServicePropagatesLookupError(t *testing.T) {
+	t.Parallel()
+
+	store := &stubFeedStore{err: errors.New("not found")}
+	svc := NewFeedService(store)
+
+	if _, err := svc.Render(context.Background(), "missing"); err == nil {
+		t.Fatalf("expected error for unknown token")
+	}
+}
+
+type stubFeedStore struct {
+	sub  model.Subscription
+	jobs []model.Job
+	err  error
+}
+
+func (s *stubFeedStore) GetSubscriptionByFeedToken(ctx context.Context, token string) (model.Subscription, error) {
+	if s.err != nil {
+		return model.Subscription{}, s.err
+	}
+	return s.sub, nil
+}
+
+func (s *stubFeedStore) ListJobsSince(ctx context.Context, since time.Time) ([]model.Job, error) {
+	return s.jobs, nil
+}
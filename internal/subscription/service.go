@@ -2,11 +2,16 @@ package subscription
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net/mail"
 	"strings"
 
+	"remote-radar/internal/filter"
 	"remote-radar/internal/model"
+	"remote-radar/internal/notifier"
+	"remote-radar/internal/notifier/dispatch"
 
 	"gorm.io/datatypes"
 )
@@ -14,26 +19,40 @@ import (
 // Store 定义持久化接口。
 type Store interface {
 	CreateSubscription(ctx context.Context, sub *model.Subscription) error
+	// RecordFilterViolation 记录一次敏感词命中并返回该邮箱的累计命中次数。
+	RecordFilterViolation(ctx context.Context, email, keyword string) (int64, error)
+	// FreezeSubscriptionsByEmail 将该邮箱下所有订阅置为 frozen 状态。
+	FreezeSubscriptionsByEmail(ctx context.Context, email string) error
 }
 
-// Config 控制可用渠道与可选标签。
+// Config 控制可用渠道、可选标签与敏感词过滤。
 type Config struct {
-	AllowedChannels []string `yaml:"allowed_channels" json:"allowed_channels"`
-	TagCandidates   []string `yaml:"tag_candidates" json:"tag_candidates"`
+	AllowedChannels    []string      `yaml:"allowed_channels" json:"allowed_channels"`
+	TagCandidates      []string      `yaml:"tag_candidates" json:"tag_candidates"`
+	Filter             filter.Config `yaml:"filter" json:"filter"`
+	ViolationThreshold int           `yaml:"violation_threshold" json:"violation_threshold"`
 }
 
 // Request 表示前端订阅请求。
+// Target 为非邮件渠道的投递目标（如 webhook URL、推送 key），email 渠道下可留空。
+// FilterExpr 为可选的布尔表达式 DSL，设置时优先于 Tags 生效，详见 dispatch.ParseFilterExpr。
 type Request struct {
-	Email   string   `json:"email"`
-	Channel string   `json:"channel"`
-	Tags    []string `json:"tags"`
+	Email      string   `json:"email"`
+	Channel    string   `json:"channel"`
+	Target     string   `json:"target"`
+	Tags       []string `json:"tags"`
+	FilterExpr string   `json:"filter_expr"`
 }
 
+const defaultViolationThreshold = 3
+
 // Service 负责验证与写入订阅偏好。
 type Service struct {
-	store    Store
-	channels map[string]struct{}
-	tags     map[string]string
+	store     Store
+	channels  map[string]struct{}
+	tags      map[string]string
+	filter    *filter.Service
+	threshold int
 }
 
 // NewService 创建订阅服务。
@@ -53,7 +72,17 @@ func NewService(store Store, cfg Config) *Service {
 			tagLookup[strings.ToLower(trimmed)] = trimmed
 		}
 	}
-	return &Service{store: store, channels: channelMap, tags: tagLookup}
+	threshold := cfg.ViolationThreshold
+	if threshold <= 0 {
+		threshold = defaultViolationThreshold
+	}
+	return &Service{
+		store:     store,
+		channels:  channelMap,
+		tags:      tagLookup,
+		filter:    filter.NewService(cfg.Filter),
+		threshold: threshold,
+	}
 }
 
 // Create 校验请求并写入数据库。
@@ -74,6 +103,18 @@ func (s *Service) Create(ctx context.Context, req Request) (model.Subscription,
 		return model.Subscription{}, fmt.Errorf("unsupported channel %s", channel)
 	}
 
+	target := strings.TrimSpace(req.Target)
+	if channel != "email" && target == "" {
+		return model.Subscription{}, fmt.Errorf("target required for channel %s", channel)
+	}
+	if err := notifier.ValidateTarget(channel, target); err != nil {
+		return model.Subscription{}, fmt.Errorf("invalid target: %w", err)
+	}
+
+	if matched, keyword := s.filter.MatchContent(localPart(email)); matched {
+		return model.Subscription{}, s.rejectSensitive(ctx, email, keyword)
+	}
+
 	tagMap := datatypes.JSONMap{}
 	for _, tag := range req.Tags {
 		key := strings.ToLower(strings.TrimSpace(tag))
@@ -87,16 +128,66 @@ func (s *Service) Create(ctx context.Context, req Request) (model.Subscription,
 		if canonical == "" {
 			canonical = strings.TrimSpace(tag)
 		}
+		if matched, keyword := s.filter.MatchContent(canonical); matched {
+			return model.Subscription{}, s.rejectSensitive(ctx, email, keyword)
+		}
 		tagMap[canonical] = true
 	}
 
+	filterExpr := strings.TrimSpace(req.FilterExpr)
+	if filterExpr != "" {
+		if _, err := dispatch.ParseFilterExpr(filterExpr); err != nil {
+			return model.Subscription{}, fmt.Errorf("invalid filter_expr: %w", err)
+		}
+	}
+
+	feedToken, err := newFeedToken()
+	if err != nil {
+		return model.Subscription{}, fmt.Errorf("generate feed token: %w", err)
+	}
+
 	sub := model.Subscription{
-		Email:   email,
-		Channel: channel,
-		Tags:    tagMap,
+		Email:      email,
+		Channel:    channel,
+		Target:     target,
+		Tags:       tagMap,
+		FilterExpr: filterExpr,
+		Status:     model.SubscriptionStatusActive,
+		FeedToken:  feedToken,
 	}
 	if err := s.store.CreateSubscription(ctx, &sub); err != nil {
 		return model.Subscription{}, err
 	}
 	return sub, nil
 }
+
+// newFeedToken 生成 32 字节随机 token 的十六进制表示，用于免鉴权访问 /feeds/{token}.xml。
+func newFeedToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// rejectSensitive 记录一次敏感词命中，命中次数达到阈值时自动冻结该邮箱下的所有订阅。
+func (s *Service) rejectSensitive(ctx context.Context, email, keyword string) error {
+	count, err := s.store.RecordFilterViolation(ctx, email, keyword)
+	if err != nil {
+		return fmt.Errorf("record filter violation: %w", err)
+	}
+	if count >= int64(s.threshold) {
+		if err := s.store.FreezeSubscriptionsByEmail(ctx, email); err != nil {
+			return fmt.Errorf("freeze subscriptions: %w", err)
+		}
+	}
+	return fmt.Errorf("rejected: sensitive content detected (%q)", keyword)
+}
+
+// localPart 返回邮箱地址 @ 之前的本地名部分，用于敏感词匹配。
+func localPart(email string) string {
+	if idx := strings.Index(email, "@"); idx >= 0 {
+		return email[:idx]
+	}
+	return email
+}
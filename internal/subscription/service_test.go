@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"remote-radar/internal/filter"
 	"remote-radar/internal/model"
 )
 
@@ -49,6 +50,126 @@ func TestServiceRejectsInvalidInput(t *testing.T) {
 	}
 }
 
+func TestServiceCreatesWebhookSubscriptionWithTarget(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{AllowedChannels: []string{"email", "webhook"}})
+
+	req := Request{Email: "user@example.com", Channel: "webhook", Target: "https://example.com/hook"}
+	sub, err := svc.Create(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if sub.Target != req.Target {
+		t.Fatalf("expected target to be stored, got %+v", sub)
+	}
+	if sub.FeedToken == "" {
+		t.Fatalf("expected a feed token to be generated")
+	}
+}
+
+func TestServiceRejectsMalformedWebhookTarget(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{AllowedChannels: []string{"email", "webhook"}})
+
+	req := Request{Email: "user@example.com", Channel: "webhook", Target: "not-a-url"}
+	if _, err := svc.Create(context.Background(), req); err == nil {
+		t.Fatalf("expected error for malformed webhook target")
+	}
+	if store.calls != 0 {
+		t.Fatalf("expected store not called for malformed target")
+	}
+}
+
+func TestServiceRejectsNonEmailChannelWithoutTarget(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{AllowedChannels: []string{"email", "webhook"}})
+
+	if _, err := svc.Create(context.Background(), Request{Email: "user@example.com", Channel: "webhook"}); err == nil {
+		t.Fatalf("expected error when target is missing for non-email channel")
+	}
+	if store.calls != 0 {
+		t.Fatalf("expected store not called when target is missing")
+	}
+}
+
+func TestServiceCreatesSubscriptionWithFilterExpr(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{AllowedChannels: []string{"email"}})
+
+	req := Request{Email: "user@example.com", Channel: "email", FilterExpr: "tag:backend AND NOT source:acme"}
+	sub, err := svc.Create(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if sub.FilterExpr != req.FilterExpr {
+		t.Fatalf("expected filter_expr to be stored, got %+v", sub)
+	}
+}
+
+func TestServiceRejectsInvalidFilterExpr(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{AllowedChannels: []string{"email"}})
+
+	if _, err := svc.Create(context.Background(), Request{Email: "user@example.com", Channel: "email", FilterExpr: "tag:"}); err == nil {
+		t.Fatal("expected error for invalid filter_expr")
+	}
+	if store.calls != 0 {
+		t.Fatalf("expected store not called when filter_expr is invalid")
+	}
+}
+
+func TestServiceRejectsSensitiveEmailLocalPart(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{
+		AllowedChannels: []string{"email"},
+		Filter:          filter.Config{ContentSensitives: []string{"scam"}},
+	})
+
+	_, err := svc.Create(context.Background(), Request{Email: "scam-lord@example.com", Channel: "email"})
+	if err == nil {
+		t.Fatalf("expected rejection for sensitive email local part")
+	}
+	if store.calls != 0 {
+		t.Fatalf("expected CreateSubscription not called when rejected")
+	}
+	if store.violations["scam-lord@example.com"] != 1 {
+		t.Fatalf("expected one recorded violation, got %d", store.violations["scam-lord@example.com"])
+	}
+}
+
+func TestServiceFreezesAfterRepeatedViolations(t *testing.T) {
+	t.Parallel()
+
+	store := &stubStore{}
+	svc := NewService(store, Config{
+		AllowedChannels:    []string{"email"},
+		Filter:             filter.Config{ContentSensitives: []string{"scam"}},
+		ViolationThreshold: 2,
+	})
+
+	email := "scammer@example.com"
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Create(context.Background(), Request{Email: email, Channel: "email"}); err == nil {
+			t.Fatalf("expected rejection on attempt %d", i)
+		}
+	}
+	if len(store.frozenEmails) != 1 || store.frozenEmails[0] != email {
+		t.Fatalf("expected subscription frozen after reaching threshold, got %+v", store.frozenEmails)
+	}
+}
+
 func TestServicePropagatesStoreError(t *testing.T) {
 	t.Parallel()
 
@@ -62,8 +183,10 @@ func TestServicePropagatesStoreError(t *testing.T) {
 }
 
 type stubStore struct {
-	calls int
-	err   error
+	calls        int
+	err          error
+	violations   map[string]int64
+	frozenEmails []string
 }
 
 func (s *stubStore) CreateSubscription(ctx context.Context, sub *model.Subscription) error {
@@ -74,3 +197,16 @@ func (s *stubStore) CreateSubscription(ctx context.Context, sub *model.Subscript
 	sub.ID = 1
 	return nil
 }
+
+func (s *stubStore) RecordFilterViolation(ctx context.Context, email, keyword string) (int64, error) {
+	if s.violations == nil {
+		s.violations = make(map[string]int64)
+	}
+	s.violations[email]++
+	return s.violations[email], nil
+}
+
+func (s *stubStore) FreezeSubscriptionsByEmail(ctx context.Context, email string) error {
+	s.frozenEmails = append(s.frozenEmails, email)
+	return nil
+}